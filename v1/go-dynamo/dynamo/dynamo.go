@@ -19,6 +19,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 )
 
 const ErrRequestThrottled = "ERR_REQUEST_THROTTLED"
@@ -39,7 +40,7 @@ type DynamoDbLogic interface {
 }
 
 type DynamoDB struct {
-	svc        *dynamodb.DynamoDB
+	svc        dynamodbiface.DynamoDBAPI
 	tables     map[string]*Table
 	failConfig *FailConfig
 }
@@ -431,14 +432,21 @@ func (d *DynamoDB) BatchGet(tableName string, fc *FailConfig, queries []*Query,
 	reqItems := make(map[string]*dynamodb.KeysAndAttributes)
 	keys := []map[string]*dynamodb.AttributeValue{}
 
+	// index of each query by key - DynamoDB does not guarantee
+	// responses are returned in the same order as the requested keys.
+	// No projection is set, so every response includes its key attributes.
+	refIndex := make(map[string]int)
+	found := make([]bool, len(refObjs))
+
 	// create Get requests for each query
-	for _, q := range queries {
+	for i, q := range queries {
 		if q == nil {
 			continue
 		}
 
 		item := keyMaker(q, t)
 		keys = append(keys, item)
+		refIndex[itemKey(item, t)] = i
 	}
 	// populate reqItems map
 	ka := &dynamodb.KeysAndAttributes{Keys: keys}
@@ -469,12 +477,17 @@ func (d *DynamoDB) BatchGet(tableName string, fc *FailConfig, queries []*Query,
 			}
 		}
 
-		for i, r := range result.Responses[t.TableName] {
+		for _, r := range result.Responses[t.TableName] {
+			i, ok := refIndex[itemKey(r, t)]
+			if !ok {
+				continue
+			}
 			ref := refObjs[i]
 			if err := dynamodbattribute.UnmarshalMap(r, &ref); err != nil {
 				return nil, fmt.Errorf("dynamodbattribute.UnmarshalMap, %w", err)
 			}
-			items = append(items, ref)
+			refObjs[i] = ref
+			found[i] = true
 		}
 
 		if len(result.UnprocessedKeys) == 0 {
@@ -484,9 +497,25 @@ func (d *DynamoDB) BatchGet(tableName string, fc *FailConfig, queries []*Query,
 
 	}
 
+	// return found items in the order of the input queries
+	for i, ref := range refObjs {
+		if found[i] {
+			items = append(items, ref)
+		}
+	}
+
 	return items, nil
 }
 
+// itemKey returns a comparable string of the table's key attribute values for the given item.
+func itemKey(item map[string]*dynamodb.AttributeValue, t *Table) string {
+	key := item[t.PrimaryKeyName].String()
+	if t.SortKeyName == "" {
+		return key
+	}
+	return key + "|" + item[t.SortKeyName].String()
+}
+
 func (d *DynamoDB) batchWriteUtil(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
 	result, err := d.svc.BatchWriteItem(input)
 	if err != nil {
@@ -644,24 +673,24 @@ func (d *DynamoDB) batchGetUtil(input *dynamodb.BatchGetItemInput) (*dynamodb.Ba
 	return result, nil
 }
 
+// handleErr maps DynamoDB error codes to typed errors. Unmapped
+// errors are classified with goaws.ClassifyError.
 func handleErr(err error) error {
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
 			case dynamodb.ErrCodeProvisionedThroughputExceededException:
-				return ErrRateLimitExceeded
+				return NewRateLimitExceededErr()
 			case dynamodb.ErrCodeResourceNotFoundException:
-				return ErrResourceNotFound
+				return NewResourceNotFoundErr(aerr.Message())
 			case dynamodb.ErrCodeItemCollectionSizeLimitExceededException:
 				return ErrCollectionSizeExceeded
 			case dynamodb.ErrCodeRequestLimitExceeded:
-				return ErrRateLimitExceeded
+				return NewRateLimitExceededErr()
 			case dynamodb.ErrCodeConditionalCheckFailedException:
 				return NewConditionCheckFailedErr(aerr.Message())
-			case dynamodb.ErrCodeInternalServerError:
-				return err
 			default:
-				return err
+				return goaws.ClassifyError(err)
 			}
 		} else {
 			return err
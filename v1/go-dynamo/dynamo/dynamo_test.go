@@ -0,0 +1,216 @@
+package dynamo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/ggarcia209/go-aws-v2/v1/goaws"
+)
+
+// mockDynamoDB overrides the dynamodbiface.DynamoDBAPI methods under test.
+type mockDynamoDB struct {
+	dynamodbiface.DynamoDBAPI
+	err            error
+	batchGetOutput *dynamodb.BatchGetItemOutput
+}
+
+func (m *mockDynamoDB) GetItem(*dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (m *mockDynamoDB) UpdateItem(*dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func (m *mockDynamoDB) BatchGetItem(*dynamodb.BatchGetItemInput) (*dynamodb.BatchGetItemOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.batchGetOutput, nil
+}
+
+var testTable = &Table{
+	TableName:      TABLE,
+	PrimaryKeyName: "partition",
+	PrimaryKeyType: "S",
+}
+
+func newMockDynamoDB(err error) *DynamoDB {
+	return &DynamoDB{
+		svc:    &mockDynamoDB{err: err},
+		tables: map[string]*Table{TABLE: testTable},
+	}
+}
+
+var errorTests = []struct {
+	name      string
+	err       error
+	sentinel  error
+	retryable bool
+	clientErr bool
+}{
+	{
+		name:      "rate limit exceeded",
+		err:       awserr.New(dynamodb.ErrCodeProvisionedThroughputExceededException, "throttled", nil),
+		sentinel:  ErrRateLimitExceeded,
+		retryable: true,
+		clientErr: true,
+	},
+	{
+		name:      "request limit exceeded",
+		err:       awserr.New(dynamodb.ErrCodeRequestLimitExceeded, "throttled", nil),
+		sentinel:  ErrRateLimitExceeded,
+		retryable: true,
+		clientErr: true,
+	},
+	{
+		name:      "resource not found",
+		err:       awserr.New(dynamodb.ErrCodeResourceNotFoundException, "missing table", nil),
+		sentinel:  ErrResourceNotFound,
+		retryable: false,
+		clientErr: true,
+	},
+	{
+		name:      "internal server error",
+		err:       awserr.NewRequestFailure(awserr.New(dynamodb.ErrCodeInternalServerError, "oops", nil), 500, "req-id"),
+		retryable: true,
+		clientErr: false,
+	},
+}
+
+func TestGetItemErrors(t *testing.T) {
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newMockDynamoDB(tt.err)
+			q := CreateNewQueryObj("test", nil)
+
+			_, err := d.GetItem(q, TABLE, nil, NewExpression())
+			if err == nil {
+				t.Fatalf("FAIL - expected error")
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("FAIL - errors.Is: want %v, got %v", tt.sentinel, err)
+			}
+			var awsErr goaws.AwsError
+			if !errors.As(err, &awsErr) {
+				t.Fatalf("FAIL - errors.As goaws.AwsError: %v", err)
+			}
+			if awsErr.Retryable() != tt.retryable {
+				t.Errorf("FAIL - retryable: want %v, got %v", tt.retryable, awsErr.Retryable())
+			}
+			if awsErr.ClientError() != tt.clientErr {
+				t.Errorf("FAIL - client error: want %v, got %v", tt.clientErr, awsErr.ClientError())
+			}
+		})
+	}
+}
+
+func TestGetItemTableNotFound(t *testing.T) {
+	d := newMockDynamoDB(nil)
+	_, err := d.GetItem(CreateNewQueryObj("test", nil), "missing", nil, NewExpression())
+	var tnf *TableNotFoundErr
+	if !errors.As(err, &tnf) {
+		t.Errorf("FAIL - errors.As TableNotFoundErr: %v", err)
+	}
+	if !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("FAIL - errors.Is ErrTableNotFound: %v", err)
+	}
+}
+
+func TestUpdateItemErrors(t *testing.T) {
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newMockDynamoDB(tt.err)
+			q := CreateNewQueryObj("test", nil)
+
+			err := d.UpdateItem(q, TABLE, NewExpression())
+			if err == nil {
+				t.Fatalf("FAIL - expected error")
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("FAIL - errors.Is: want %v, got %v", tt.sentinel, err)
+			}
+			var awsErr goaws.AwsError
+			if !errors.As(err, &awsErr) {
+				t.Fatalf("FAIL - errors.As goaws.AwsError: %v", err)
+			}
+			if awsErr.Retryable() != tt.retryable {
+				t.Errorf("FAIL - retryable: want %v, got %v", tt.retryable, awsErr.Retryable())
+			}
+		})
+	}
+}
+
+func TestUpdateItemConditionCheckFailed(t *testing.T) {
+	d := newMockDynamoDB(awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil))
+
+	err := d.UpdateItem(CreateNewQueryObj("test", nil), TABLE, NewExpression())
+	var ccf *ConditionCheckFailedErr
+	if !errors.As(err, &ccf) {
+		t.Fatalf("FAIL - errors.As ConditionCheckFailedErr: %v", err)
+	}
+	if ccf.Retryable() || !ccf.ClientError() {
+		t.Errorf("FAIL - expected non-retryable client error")
+	}
+}
+
+type batchRecord struct {
+	Partition string `json:"partition"`
+	Data      string `json:"data"`
+}
+
+func TestBatchGetOutOfOrder(t *testing.T) {
+	response := func(pk, data string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"partition": {S: aws.String(pk)},
+			"data":      {S: aws.String(data)},
+		}
+	}
+
+	d := newMockDynamoDB(nil)
+	d.svc = &mockDynamoDB{
+		batchGetOutput: &dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]*dynamodb.AttributeValue{
+				// returned in a different order than requested, "b" not found
+				TABLE: {response("c", "data-c"), response("a", "data-a")},
+			},
+		},
+	}
+
+	queries := []*Query{
+		CreateNewQueryObj("a", nil),
+		CreateNewQueryObj("b", nil),
+		CreateNewQueryObj("c", nil),
+	}
+	refObjs := []interface{}{&batchRecord{}, &batchRecord{}, &batchRecord{}}
+
+	items, err := d.BatchGet(TABLE, &FailConfig{Base: 50, Cap: 60000}, queries, refObjs, NewExpression())
+	if err != nil {
+		t.Fatalf("FAIL - %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("FAIL - want 2 items, got %d", len(items))
+	}
+
+	var want = []batchRecord{
+		{Partition: "a", Data: "data-a"},
+		{},
+		{Partition: "c", Data: "data-c"},
+	}
+	for i, w := range want {
+		got := refObjs[i].(*batchRecord)
+		if *got != w {
+			t.Errorf("FAIL - refObjs[%d]: want %+v, got %+v", i, w, *got)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package dynamo
 import (
 	"errors"
 	"fmt"
+
+	"github.com/ggarcia209/go-aws-v2/v1/goaws"
 )
 
 var (
@@ -36,6 +38,18 @@ func (e *TableNotFoundErr) Error() string {
 	return fmt.Sprintf("table %s not found", e.tableName)
 }
 
+func (e *TableNotFoundErr) Retryable() bool {
+	return false
+}
+
+func (e *TableNotFoundErr) ClientError() bool {
+	return true
+}
+
+func (e *TableNotFoundErr) Is(target error) bool {
+	return target == ErrTableNotFound
+}
+
 func NewTableNotFoundErr(tableName string) *TableNotFoundErr {
 	return &TableNotFoundErr{tableName: tableName}
 }
@@ -48,6 +62,42 @@ func (e *ConditionCheckFailedErr) Error() string {
 	return fmt.Sprintf("condition check failed: %s", e.msg)
 }
 
+func (e *ConditionCheckFailedErr) Retryable() bool {
+	return false
+}
+
+func (e *ConditionCheckFailedErr) ClientError() bool {
+	return true
+}
+
 func NewConditionCheckFailedErr(msg string) *ConditionCheckFailedErr {
 	return &ConditionCheckFailedErr{msg: msg}
 }
+
+// RateLimitExceededErr is returned when a request is throttled.
+// Matches ErrRateLimitExceeded via errors.Is.
+type RateLimitExceededErr struct {
+	*goaws.RetryableClientError
+}
+
+func (e *RateLimitExceededErr) Is(target error) bool {
+	return target == ErrRateLimitExceeded
+}
+
+func NewRateLimitExceededErr() *RateLimitExceededErr {
+	return &RateLimitExceededErr{goaws.NewRetryableClientError(ErrRateLimitExceeded)}
+}
+
+// ResourceNotFoundErr is returned when the requested table or index does not exist.
+// Matches ErrResourceNotFound via errors.Is.
+type ResourceNotFoundErr struct {
+	*goaws.ClientErr
+}
+
+func (e *ResourceNotFoundErr) Is(target error) bool {
+	return target == ErrResourceNotFound
+}
+
+func NewResourceNotFoundErr(msg string) *ResourceNotFoundErr {
+	return &ResourceNotFoundErr{goaws.NewClientError(fmt.Errorf("resource not found: %s", msg))}
+}
@@ -1,11 +1,10 @@
 package dynamo
 
 import (
-	"log"
-
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/ggarcia209/go-aws-v2/v1/goaws"
 )
 
 /* Expression wrapper type & methods */
@@ -60,6 +59,20 @@ type ExprBuilder struct {
 	KeyCondition *expression.KeyConditionBuilder
 	Projection   *expression.ProjectionBuilder
 	Update       *expression.UpdateBuilder
+	logger       goaws.Logger
+}
+
+// SetLogger sets the Logger that receives diagnostics from BuildExpression.
+func (e *ExprBuilder) SetLogger(logger goaws.Logger) {
+	e.logger = logger
+}
+
+// Logger returns the ExprBuilder's Logger, or a NopLogger if none is set.
+func (e *ExprBuilder) Logger() goaws.Logger {
+	if e.logger == nil {
+		return goaws.NopLogger{}
+	}
+	return e.logger
 }
 
 // SetCondition creates a ConditionBuilder object with the given field name and value.
@@ -114,7 +127,7 @@ func (e *ExprBuilder) BuildExpression() (Expression, error) {
 	}
 	build, err := eb.Build()
 	if err != nil {
-		log.Printf("BuildExpression failed: %v", err)
+		e.Logger().Printf("BuildExpression failed: %v", err)
 		return Expression{}, err
 	}
 	expr.Expression = build
@@ -220,7 +233,7 @@ func NewExpression() Expression {
 
 // NewExprBuilder constructs a new ExprBuilder object.
 func NewExprBuilder() ExprBuilder {
-	return ExprBuilder{}
+	return ExprBuilder{logger: goaws.NopLogger{}}
 }
 
 // NewUpdateExpr constructs a new UpdateExpr object.
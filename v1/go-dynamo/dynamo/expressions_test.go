@@ -1,7 +1,11 @@
 package dynamo
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v1/goaws"
 )
 
 const TABLE = "go-dynamo-test"
@@ -105,3 +109,45 @@ func TestKeyCondition(t *testing.T) {
 	}
 
 }
+
+// testLogger records the messages passed to Printf.
+type testLogger struct {
+	msgs []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.msgs = append(l.msgs, fmt.Sprintf(format, v...))
+}
+
+func TestExpressionBuildLogger(t *testing.T) {
+	logger := &testLogger{}
+
+	// building an empty expression fails
+	eb := NewExprBuilder()
+	eb.SetLogger(logger)
+	if _, err := eb.BuildExpression(); err == nil {
+		t.Fatalf("FAIL - expected error")
+	}
+
+	if len(logger.msgs) != 1 {
+		t.Fatalf("FAIL - messages: want 1, got %d", len(logger.msgs))
+	}
+	if !strings.HasPrefix(logger.msgs[0], "BuildExpression failed: ") {
+		t.Errorf("FAIL - message: got %q", logger.msgs[0])
+	}
+	t.Logf("SUCCESS")
+}
+
+func TestExpressionDefaultLogger(t *testing.T) {
+	builders := map[string]ExprBuilder{"NewExprBuilder": NewExprBuilder(), "zero value": {}}
+	for name, eb := range builders {
+		if _, ok := eb.Logger().(goaws.NopLogger); !ok {
+			t.Errorf("FAIL - %s: want NopLogger, got %T", name, eb.Logger())
+		}
+		// building an empty expression fails and logs to the NopLogger
+		if _, err := eb.BuildExpression(); err == nil {
+			t.Errorf("FAIL - %s: expected error", name)
+		}
+	}
+	t.Logf("SUCCESS")
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
 	"github.com/ggarcia209/go-aws-v2/v1/goaws"
 )
 
@@ -19,7 +21,7 @@ type SesLogic interface {
 }
 
 type SES struct {
-	svc *ses.SES
+	svc sesiface.SESAPI
 }
 
 func NewSES(sess goaws.Session) *SES {
@@ -112,24 +114,7 @@ func (s *SES) SendEmail(to, cc, replyTo []string, from, subject, textBody, htmlB
 
 	// Attempt to send the email.
 	if _, err := s.svc.SendEmail(input); err != nil {
-		// if aerr, ok := err.(awserr.Error); ok {
-		// 	switch aerr.Code() {
-		// 	case ses.ErrCodeMessageRejected:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeMessageRejected, aerr.Error())
-		// 	case ses.ErrCodeMailFromDomainNotVerifiedException:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeMailFromDomainNotVerifiedException, aerr.Error())
-		// 	case ses.ErrCodeConfigurationSetDoesNotExistException:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeConfigurationSetDoesNotExistException, aerr.Error())
-		// 	default:
-		// 		log.Printf("SendEmail failed: %v", aerr.Error())
-		// 	}
-		// } else {
-		// 	// Print the error, cast err to awserr.Error to get the Code and
-		// 	// Message from an error.
-		// 	log.Printf("SendEmail failed: %v", err.Error())
-		// }
-
-		return fmt.Errorf("s.svc.SendEmail: %w", err)
+		return fmt.Errorf("s.svc.SendEmail: %w", handleErr(err))
 	}
 
 	return nil
@@ -183,24 +168,7 @@ func (s *SES) SendEmailWithConfigSet(
 
 	// Attempt to send the email.
 	if _, err := s.svc.SendEmail(input); err != nil {
-		// if aerr, ok := err.(awserr.Error); ok {
-		// 	switch aerr.Code() {
-		// 	case ses.ErrCodeMessageRejected:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeMessageRejected, aerr.Error())
-		// 	case ses.ErrCodeMailFromDomainNotVerifiedException:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeMailFromDomainNotVerifiedException, aerr.Error())
-		// 	case ses.ErrCodeConfigurationSetDoesNotExistException:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeConfigurationSetDoesNotExistException, aerr.Error())
-		// 	default:
-		// 		log.Printf("SendEmail failed: %v", aerr.Error())
-		// 	}
-		// } else {
-		// 	// Print the error, cast err to awserr.Error to get the Code and
-		// 	// Message from an error.
-		// 	log.Printf("SendEmail failed: %v", err.Error())
-		// }
-
-		return fmt.Errorf("s.svc.SendEmail: %w", err)
+		return fmt.Errorf("s.svc.SendEmail: %w", handleErr(err))
 	}
 
 	return nil
@@ -248,25 +216,30 @@ func (s *SES) SendPlainTextEmail(to, cc, replyTo []string, from, subject, textBo
 
 	// Attempt to send the email.
 	if _, err := s.svc.SendEmail(input); err != nil {
-		// if aerr, ok := err.(awserr.Error); ok {
-		// 	switch aerr.Code() {
-		// 	case ses.ErrCodeMessageRejected:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeMessageRejected, aerr.Error())
-		// 	case ses.ErrCodeMailFromDomainNotVerifiedException:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeMailFromDomainNotVerifiedException, aerr.Error())
-		// 	case ses.ErrCodeConfigurationSetDoesNotExistException:
-		// 		log.Printf("SendEmail failed: %v: %v", ses.ErrCodeConfigurationSetDoesNotExistException, aerr.Error())
-		// 	default:
-		// 		log.Printf("SendEmail failed: %v", aerr.Error())
-		// 	}
-		// } else {
-		// 	// Print the error, cast err to awserr.Error to get the Code and
-		// 	// Message from an error.
-		// 	log.Printf("SendEmail failed: %v", err.Error())
-		// }
-
-		return fmt.Errorf("s.svc.SendEmail: %w", err)
+		return fmt.Errorf("s.svc.SendEmail: %w", handleErr(err))
 	}
 
 	return nil
 }
+
+// handleErr maps SES error codes to typed errors. Unmapped
+// errors are classified with goaws.ClassifyError.
+func handleErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	switch aerr.Code() {
+	case ses.ErrCodeMessageRejected:
+		return NewMessageRejectedErr(aerr.Message())
+	case ses.ErrCodeMailFromDomainNotVerifiedException:
+		return NewMailFromDomainNotVerifiedErr(aerr.Message())
+	case ses.ErrCodeConfigurationSetDoesNotExistException:
+		return NewConfigurationSetDoesNotExistErr(aerr.Message())
+	default:
+		return goaws.ClassifyError(err)
+	}
+}
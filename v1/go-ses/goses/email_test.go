@@ -0,0 +1,108 @@
+package goses
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/ggarcia209/go-aws-v2/v1/goaws"
+)
+
+// mockSES overrides the sesiface.SESAPI methods under test.
+type mockSES struct {
+	sesiface.SESAPI
+	err error
+}
+
+func (m *mockSES) SendEmail(*ses.SendEmailInput) (*ses.SendEmailOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ses.SendEmailOutput{}, nil
+}
+
+func newMockSES(err error) *SES {
+	return &SES{svc: &mockSES{err: err}}
+}
+
+var errorTests = []struct {
+	name      string
+	err       error
+	sentinel  error
+	retryable bool
+	clientErr bool
+}{
+	{
+		name:      "message rejected",
+		err:       awserr.New(ses.ErrCodeMessageRejected, "email address is not verified", nil),
+		sentinel:  ErrMessageRejected,
+		retryable: false,
+		clientErr: true,
+	},
+	{
+		name:      "mail from domain not verified",
+		err:       awserr.New(ses.ErrCodeMailFromDomainNotVerifiedException, "mail from domain not verified", nil),
+		sentinel:  ErrMailFromDomainNotVerified,
+		retryable: false,
+		clientErr: true,
+	},
+	{
+		name:      "configuration set does not exist",
+		err:       awserr.New(ses.ErrCodeConfigurationSetDoesNotExistException, "configuration set missing", nil),
+		sentinel:  ErrConfigurationSetDoesNotExist,
+		retryable: false,
+		clientErr: true,
+	},
+	{
+		name:      "internal server error",
+		err:       awserr.NewRequestFailure(awserr.New("InternalFailure", "oops", nil), 500, "req-id"),
+		retryable: true,
+		clientErr: false,
+	},
+}
+
+func TestSendEmailErrors(t *testing.T) {
+	sends := map[string]func(s *SES) error{
+		"SendEmail": func(s *SES) error {
+			return s.SendEmail([]string{"to@test.com"}, nil, nil, "from@test.com", "subject", "text", "<p>html</p>")
+		},
+		"SendEmailWithConfigSet": func(s *SES) error {
+			return s.SendEmailWithConfigSet([]string{"to@test.com"}, nil, nil, "from@test.com", "subject", "text", "<p>html</p>", "config-set")
+		},
+		"SendPlainTextEmail": func(s *SES) error {
+			return s.SendPlainTextEmail([]string{"to@test.com"}, nil, nil, "from@test.com", "subject", "text")
+		},
+	}
+	for method, send := range sends {
+		for _, tt := range errorTests {
+			t.Run(method+"/"+tt.name, func(t *testing.T) {
+				err := send(newMockSES(tt.err))
+				if err == nil {
+					t.Fatalf("FAIL - expected error")
+				}
+				if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+					t.Errorf("FAIL - errors.Is: want %v, got %v", tt.sentinel, err)
+				}
+				var awsErr goaws.AwsError
+				if !errors.As(err, &awsErr) {
+					t.Fatalf("FAIL - errors.As goaws.AwsError: %v", err)
+				}
+				if awsErr.Retryable() != tt.retryable {
+					t.Errorf("FAIL - retryable: want %v, got %v", tt.retryable, awsErr.Retryable())
+				}
+				if awsErr.ClientError() != tt.clientErr {
+					t.Errorf("FAIL - client error: want %v, got %v", tt.clientErr, awsErr.ClientError())
+				}
+			})
+		}
+	}
+}
+
+func TestSendEmailSuccess(t *testing.T) {
+	s := newMockSES(nil)
+	if err := s.SendEmail([]string{"to@test.com"}, nil, nil, "from@test.com", "subject", "text", ""); err != nil {
+		t.Errorf("FAIL - unexpected error: %v", err)
+	}
+}
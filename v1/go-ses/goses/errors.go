@@ -0,0 +1,58 @@
+package goses
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ggarcia209/go-aws-v2/v1/goaws"
+)
+
+var (
+	ErrMessageRejected              = errors.New("message rejected")
+	ErrMailFromDomainNotVerified    = errors.New("mail from domain not verified")
+	ErrConfigurationSetDoesNotExist = errors.New("configuration set does not exist")
+)
+
+// MessageRejectedErr is returned when SES rejects the message, e.g. because
+// it contains a virus or the sender is not verified.
+// Matches ErrMessageRejected via errors.Is.
+type MessageRejectedErr struct {
+	*goaws.ClientErr
+}
+
+func (e *MessageRejectedErr) Is(target error) bool {
+	return target == ErrMessageRejected
+}
+
+func NewMessageRejectedErr(msg string) *MessageRejectedErr {
+	return &MessageRejectedErr{goaws.NewClientError(fmt.Errorf("%w: %s", ErrMessageRejected, msg))}
+}
+
+// MailFromDomainNotVerifiedErr is returned when the sender's custom MAIL FROM
+// domain has not been verified.
+// Matches ErrMailFromDomainNotVerified via errors.Is.
+type MailFromDomainNotVerifiedErr struct {
+	*goaws.ClientErr
+}
+
+func (e *MailFromDomainNotVerifiedErr) Is(target error) bool {
+	return target == ErrMailFromDomainNotVerified
+}
+
+func NewMailFromDomainNotVerifiedErr(msg string) *MailFromDomainNotVerifiedErr {
+	return &MailFromDomainNotVerifiedErr{goaws.NewClientError(fmt.Errorf("%w: %s", ErrMailFromDomainNotVerified, msg))}
+}
+
+// ConfigurationSetDoesNotExistErr is returned when the requested configuration set does not exist.
+// Matches ErrConfigurationSetDoesNotExist via errors.Is.
+type ConfigurationSetDoesNotExistErr struct {
+	*goaws.ClientErr
+}
+
+func (e *ConfigurationSetDoesNotExistErr) Is(target error) bool {
+	return target == ErrConfigurationSetDoesNotExist
+}
+
+func NewConfigurationSetDoesNotExistErr(msg string) *ConfigurationSetDoesNotExistErr {
+	return &ConfigurationSetDoesNotExistErr{goaws.NewClientError(fmt.Errorf("%w: %s", ErrConfigurationSetDoesNotExist, msg))}
+}
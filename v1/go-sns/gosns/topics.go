@@ -25,12 +25,16 @@ type SnsLogic interface {
 }
 
 type SNS struct {
-	svc *sns.SNS
+	svc    *sns.SNS
+	logger goaws.Logger
 }
 
+// NewSNS constructs a new SNS object. Diagnostics are written
+// to the Session's Logger.
 func NewSNS(sess goaws.Session) *SNS {
 	return &SNS{
-		svc: sns.New(sess.GetSession()),
+		svc:    sns.New(sess.GetSession()),
+		logger: sess.Logger(),
 	}
 }
 
@@ -42,7 +46,8 @@ func InitSesh(sess goaws.Session) *sns.SNS {
 	return sns.New(sess.GetSession())
 }
 
-// ListTopics prints and returns a list of all SNS topics' ARNs in the AWS account.
+// ListTopics returns a list of all SNS topics' ARNs in the AWS account.
+// Each ARN is also written to the Session's Logger.
 func (s *SNS) ListTopics() ([]string, error) {
 	arns := []string{}
 
@@ -52,9 +57,9 @@ func (s *SNS) ListTopics() ([]string, error) {
 		return arns, fmt.Errorf("s.svc.ListTopics: %w", err)
 	}
 
-	// print topic ARNs
+	// log topic ARNs
 	for _, t := range result.Topics {
-		fmt.Println(*t.TopicArn)
+		s.logger.Printf("ListTopics: %s", *t.TopicArn)
 		arns = append(arns, *t.TopicArn)
 	}
 
@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -218,12 +217,16 @@ type SqsMessagesLogic interface {
 }
 
 type SqsMessages struct {
-	svc *sqs.SQS
+	svc    *sqs.SQS
+	logger goaws.Logger
 }
 
+// NewSqsMessages constructs a new SqsMessages object. Diagnostics
+// are written to the Session's Logger.
 func NewSqsMessages(sess goaws.Session) *SqsMessages {
 	return &SqsMessages{
-		svc: sqs.New(sess.GetSession()),
+		svc:    sqs.New(sess.GetSession()),
+		logger: sess.Logger(),
 	}
 }
 
@@ -476,15 +479,15 @@ func (s *SqsMessages) ChangeMessageVisibilityBatch(req BatchUpdateVisibilityTime
 	resp := BatchUpdateVisibilityTimeoutResponse{}
 
 	if len(req.MessageIDs) != len(req.ReceiptHandles) {
-		log.Printf("ChangeMessageVisibilityBatch failed: Invalid request")
+		s.logger.Printf("ChangeMessageVisibilityBatch failed: Invalid request")
 		return resp, fmt.Errorf("INVALID_REQUEST")
 	}
 	if len(req.MessageIDs) == 0 {
-		log.Printf("ChangeMessageVisibilityBatch failed: Empty request")
+		s.logger.Printf("ChangeMessageVisibilityBatch failed: Empty request")
 		return resp, fmt.Errorf("EMPTY_REQUEST")
 	}
 	if len(req.MessageIDs) > 10 {
-		log.Printf("ChangeMessageVisibilityBatch failed: Too many entries (%d); max 10", len(req.MessageIDs))
+		s.logger.Printf("ChangeMessageVisibilityBatch failed: Too many entries (%d); max 10", len(req.MessageIDs))
 		return resp, fmt.Errorf("INVALID_REQUEST")
 	}
 	input := &sqs.ChangeMessageVisibilityBatchInput{}
@@ -0,0 +1,163 @@
+package goaws
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// AwsError is a generic interface for implementing
+// error handling for each service. Mirrors the v2
+// goaws.AwsError interface to ease migration.
+type AwsError interface {
+	Error() string
+	Retryable() bool
+	ClientError() bool
+}
+
+type GenericError struct {
+	msg       string
+	retryable bool
+	clientErr bool
+	err       error
+}
+
+func (e *GenericError) Error() string {
+	return e.msg
+}
+
+func (e *GenericError) Retryable() bool {
+	return e.retryable
+}
+
+func (e *GenericError) ClientError() bool {
+	return e.clientErr
+}
+
+// Unwrap returns the original error, so errors.Is and errors.As can match it.
+func (e *GenericError) Unwrap() error {
+	return e.err
+}
+
+func NewGenericError(err error, retryable bool, clientErr bool) *GenericError {
+	if err == nil {
+		return nil
+	}
+	return &GenericError{
+		msg:       err.Error(),
+		retryable: retryable,
+		clientErr: clientErr,
+		err:       err,
+	}
+}
+
+type InternalError struct {
+	msg string
+	err error
+}
+
+func (e *InternalError) Error() string {
+	return e.msg
+}
+
+func (e *InternalError) Retryable() bool {
+	return false
+}
+
+func (e *InternalError) ClientError() bool {
+	return false
+}
+
+// Unwrap returns the original error, so errors.Is and errors.As can match it.
+func (e *InternalError) Unwrap() error {
+	return e.err
+}
+
+func NewInternalError(err error) *InternalError {
+	if err == nil {
+		return nil
+	}
+	return &InternalError{
+		msg: err.Error(),
+		err: err,
+	}
+}
+
+type ClientErr struct {
+	msg string
+}
+
+func (e *ClientErr) Error() string {
+	return e.msg
+}
+
+func (e *ClientErr) Retryable() bool {
+	return false
+}
+
+func (e *ClientErr) ClientError() bool {
+	return true
+}
+
+func NewClientError(err error) *ClientErr {
+	if err == nil {
+		return nil
+	}
+	return &ClientErr{
+		msg: err.Error(),
+	}
+}
+
+type RetryableClientError struct {
+	msg string
+}
+
+func (e *RetryableClientError) Error() string {
+	return e.msg
+}
+
+func (e *RetryableClientError) Retryable() bool {
+	return true
+}
+
+func (e *RetryableClientError) ClientError() bool {
+	return true
+}
+
+func NewRetryableClientError(err error) *RetryableClientError {
+	if err == nil {
+		return nil
+	}
+	return &RetryableClientError{
+		msg: err.Error(),
+	}
+}
+
+// ClassifyError wraps an aws-sdk-go error in an AwsError using the
+// SDK's throttle / retryable error codes and the HTTP status code of
+// the failed request. Errors that did not originate from an AWS request
+// are returned as non-retryable InternalErrors. The original error can
+// be matched with errors.Is and errors.As.
+func ClassifyError(err error) AwsError {
+	if err == nil {
+		return nil
+	}
+
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return NewInternalError(err)
+	}
+
+	retryable := request.IsErrorThrottle(aerr) || request.IsErrorRetryable(aerr)
+	clientErr := false
+
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		status := reqErr.StatusCode()
+		clientErr = status >= 400 && status < 500
+		retryable = retryable || status >= 500
+	}
+
+	return NewGenericError(err, retryable, clientErr)
+}
@@ -0,0 +1,47 @@
+package goaws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+func TestClassifyError(t *testing.T) {
+	var tests = []struct {
+		name      string
+		err       error
+		retryable bool
+		clientErr bool
+	}{
+		{name: "non aws error", err: errors.New("test error"), retryable: false, clientErr: false},
+		{name: "throttled", err: awserr.NewRequestFailure(awserr.New("ThrottlingException", "slow down", nil), 400, "req-1"), retryable: true, clientErr: true},
+		{name: "bad request", err: awserr.NewRequestFailure(awserr.New("ValidationException", "bad input", nil), 400, "req-2"), retryable: false, clientErr: true},
+		{name: "server error", err: awserr.NewRequestFailure(awserr.New("InternalServerError", "oops", nil), 500, "req-3"), retryable: true, clientErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := ClassifyError(tt.err)
+			if ae == nil {
+				t.Fatalf("FAIL - expected error")
+			}
+			if ae.Error() != tt.err.Error() {
+				t.Errorf("FAIL - error: want %q, got %q", tt.err.Error(), ae.Error())
+			}
+			if ae.Retryable() != tt.retryable {
+				t.Errorf("FAIL - retryable: want %v, got %v", tt.retryable, ae.Retryable())
+			}
+			if ae.ClientError() != tt.clientErr {
+				t.Errorf("FAIL - client error: want %v, got %v", tt.clientErr, ae.ClientError())
+			}
+			if !errors.Is(ae, tt.err) {
+				t.Errorf("FAIL - unwrap: want %v in chain", tt.err)
+			}
+		})
+	}
+
+	if ClassifyError(nil) != nil {
+		t.Errorf("FAIL - expected nil error")
+	}
+}
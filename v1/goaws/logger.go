@@ -0,0 +1,12 @@
+package goaws
+
+// Logger receives diagnostic messages from the go-aws packages.
+// *log.Logger satisfies Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// NopLogger discards all messages. It is the default Logger.
+type NopLogger struct{}
+
+func (NopLogger) Printf(format string, v ...interface{}) {}
@@ -0,0 +1,21 @@
+package goaws
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestSessionLogger(t *testing.T) {
+	var sess Session
+	if _, ok := sess.Logger().(NopLogger); !ok {
+		t.Errorf("FAIL - default logger: want NopLogger, got %T", sess.Logger())
+	}
+
+	var buf bytes.Buffer
+	sess.SetLogger(log.New(&buf, "", 0))
+	sess.Logger().Printf("test %d", 1)
+	if buf.String() != "test 1\n" {
+		t.Errorf("FAIL - message: want %q, got %q", "test 1\n", buf.String())
+	}
+}
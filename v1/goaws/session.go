@@ -11,6 +11,7 @@ import (
 // Session contains an AWS Session for use with other AWS services in the go-aws package.
 type Session struct {
 	session *session.Session
+	logger  Logger
 }
 
 // Retrieve AWS Session from Session object.
@@ -18,6 +19,19 @@ func (s *Session) GetSession() *session.Session {
 	return s.session
 }
 
+// SetLogger sets the Logger passed to the service clients constructed from the Session.
+func (s *Session) SetLogger(logger Logger) {
+	s.logger = logger
+}
+
+// Logger returns the Session's Logger, or a NopLogger if none is set.
+func (s *Session) Logger() Logger {
+	if s.logger == nil {
+		return NopLogger{}
+	}
+	return s.logger
+}
+
 func NewDefaultSession() Session {
 	// Initialize a session that the SDK will use to load
 	// credentials from the shared credentials file ~/.aws/credentials
@@ -0,0 +1,89 @@
+// This file contains objects for implementing an exponential backoff
+// algorithm for retrying AWS requests.
+package goaws
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Retries stores parameters for the exponential backoff algorithm.
+// Attempt, Elapsed, MaxRetiresReached should always be initialized to 0, 0, false.
+type Retries struct {
+	base    int64
+	cap     int64
+	jitter  int64
+	attempt int64
+	elapsed int64
+	clock   Clock
+}
+
+type FailConfig struct {
+	Base   int64 `json:"base"`
+	Cap    int64 `json:"cap"`
+	Jitter int64 `json:"jitter"`
+	clock  Clock
+}
+
+func (f *FailConfig) NewRetries() *Retries {
+	clock := f.clock
+	if clock == nil {
+		clock = RealClock{}
+	}
+	return &Retries{base: f.Base, cap: f.Cap, jitter: f.Jitter, clock: clock}
+}
+
+// WithClock returns a copy of f whose retries read the time and wait using clock.
+func (f *FailConfig) WithClock(clock Clock) *FailConfig {
+	fc := *f
+	fc.clock = clock
+	return &fc
+}
+
+func NewFailConfig(base, cap, jitter int64) *FailConfig {
+	return &FailConfig{Base: base, Cap: cap, Jitter: jitter}
+}
+
+// DefaultFailConfig is the default configuration for the exponential backoff alogrithm
+// with a base wait time of 50 miliseconds, and max wait time of 1 minute (60000 ms).
+var DefaultFailConfig = &FailConfig{Base: 50, Cap: 60000, Jitter: 250}
+
+// ExponentialBackoff implements the exponential backoff algorithm for request retries
+// and returns true when the max number of retries has been reached (r.Elapsed > r.Cap).
+func (r *Retries) ExponentialBackoff() error {
+	return r.ExponentialBackoffContext(context.Background())
+}
+
+// ExponentialBackoffContext is like ExponentialBackoff, but returns a DeadlineExceededError
+// without waiting if ctx is canceled or its deadline would pass before the next retry.
+func (r *Retries) ExponentialBackoffContext(ctx context.Context) error {
+	if r.elapsed >= r.cap {
+		return NewMaxRetriesExceededError()
+	}
+	// the deadline is compared against r.clock below, so only check for cancellation here
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return NewDeadlineExceededError()
+	}
+
+	// exponential backoff with full jitter
+	r.attempt += 1.0
+	rnd := rand.New(rand.NewSource(r.clock.Now().UnixNano()))
+	jitter := rnd.Int63n(r.jitter)
+	sleep := r.base * int64(math.Pow(2.0, float64(r.attempt)))
+	wait := sleep + jitter
+	if r.elapsed+wait > r.cap {
+		// wait until cap is reached
+		wait = r.cap - r.elapsed
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && r.clock.Now().Add(time.Duration(wait)*time.Millisecond).After(deadline) {
+		return NewDeadlineExceededError()
+	}
+
+	r.clock.Sleep(time.Duration(wait) * time.Millisecond)
+	r.elapsed += wait
+	return nil
+}
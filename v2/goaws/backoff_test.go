@@ -0,0 +1,110 @@
+package goaws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetries_ExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		name          string
+		fc            *FailConfig
+		expectedSleep []time.Duration
+	}{
+		{
+			name: "DoublesUntilCap",
+			fc:   NewFailConfig(50, 1000, 1),
+			expectedSleep: []time.Duration{
+				100 * time.Millisecond,
+				200 * time.Millisecond,
+				400 * time.Millisecond,
+				300 * time.Millisecond, // remaining time until cap
+			},
+		},
+		{
+			name:          "CapReachedOnFirstRetry",
+			fc:            NewFailConfig(50, 60, 1),
+			expectedSleep: []time.Duration{60 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			retries := tt.fc.WithClock(clock).NewRetries()
+
+			for range tt.expectedSleep {
+				require.NoError(t, retries.ExponentialBackoff())
+			}
+			err := retries.ExponentialBackoff()
+			assert.ErrorIs(t, err, ErrMaxRetriesExceeded)
+			assert.Equal(t, tt.expectedSleep, clock.Slept())
+		})
+	}
+}
+
+func TestRetries_ExponentialBackoff_Jitter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := NewFailConfig(50, 60000, 250)
+
+	// the jitter is seeded from the clock, so retries started at the same time wait the same durations
+	var slept [2][]time.Duration
+	for i := range slept {
+		clock := NewFakeClock(start)
+		retries := fc.WithClock(clock).NewRetries()
+		for range 3 {
+			require.NoError(t, retries.ExponentialBackoff())
+		}
+		slept[i] = clock.Slept()
+	}
+	assert.Equal(t, slept[0], slept[1])
+
+	for i, d := range slept[0] {
+		base := time.Duration(50<<(i+1)) * time.Millisecond
+		assert.GreaterOrEqual(t, d, base)
+		assert.Less(t, d, base+250*time.Millisecond)
+	}
+}
+
+func TestRetries_ExponentialBackoffContext(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	retries := NewFailConfig(50, 60000, 1).WithClock(clock).NewRetries()
+
+	// 100ms and 200ms waits fit before the deadline, the next 400ms wait doesn't
+	ctx, cancel := context.WithDeadline(context.Background(), start.Add(500*time.Millisecond))
+	defer cancel()
+	require.NoError(t, retries.ExponentialBackoffContext(ctx))
+	require.NoError(t, retries.ExponentialBackoffContext(ctx))
+	err := retries.ExponentialBackoffContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, ErrDeadlineExceeded)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, clock.Slept())
+}
+
+func TestRetries_ExponentialBackoffContext_Canceled(t *testing.T) {
+	t.Parallel()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	retries := NewFailConfig(50, 60000, 1).WithClock(clock).NewRetries()
+
+	// a canceled ctx has no deadline, but the retry mustn't wait
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := retries.ExponentialBackoffContext(ctx)
+	assert.ErrorIs(t, err, ErrDeadlineExceeded)
+	assert.Empty(t, clock.Slept())
+}
+
+func TestFailConfig_WithClock(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	fc := DefaultFailConfig.WithClock(clock)
+	assert.Equal(t, clock, fc.NewRetries().clock)
+	assert.Nil(t, DefaultFailConfig.clock)
+	assert.Equal(t, RealClock{}, DefaultFailConfig.NewRetries().clock)
+}
@@ -0,0 +1,22 @@
+package goaws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	assert.Implements(t, (*Clock)(nil), c)
+	assert.Implements(t, (*Clock)(nil), RealClock{})
+
+	c.Sleep(100 * time.Millisecond)
+	c.Advance(time.Second)
+	c.Sleep(200 * time.Millisecond)
+
+	assert.Equal(t, start.Add(1300*time.Millisecond), c.Now())
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, c.Slept())
+}
@@ -6,15 +6,107 @@ package goaws
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
+// STSClientAPI defines the interface for the AWS STS client methods used by this package.
+//
+//go:generate mockgen -destination=./sts_client_test.go -package=goaws . STSClientAPI
+type STSClientAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
 type AwsConfig struct {
 	Config aws.Config
+	// sts overrides the STS client created from Config; used in tests.
+	sts STSClientAPI
+}
+
+// Region returns the AWS region service clients created from c are configured for.
+func (c *AwsConfig) Region() string {
+	return c.Config.Region
+}
+
+// AccountID returns the ID of the AWS account that c's credentials belong to,
+// e.g. for building ARNs, by calling STS GetCallerIdentity.
+func (c *AwsConfig) AccountID(ctx context.Context) (string, error) {
+	svc := c.sts
+	if svc == nil {
+		svc = sts.NewFromConfig(c.Config)
+	}
+
+	out, err := svc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", NewServiceError(fmt.Errorf("svc.GetCallerIdentity: %w", err))
+	}
+
+	return aws.ToString(out.Account), nil
+}
+
+// CredentialsValid retrieves c's current credentials and reports whether they're
+// unexpired and when they expire, so long-running services using temporary credentials
+// can refresh them proactively. The expiry is the zero time for credentials that
+// don't expire.
+func (c *AwsConfig) CredentialsValid(ctx context.Context) (bool, time.Time, error) {
+	if c.Config.Credentials == nil {
+		return false, time.Time{}, NewClientError(errors.New("no credentials provider configured"))
+	}
+
+	creds, err := c.Config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return false, time.Time{}, NewServiceError(fmt.Errorf("c.Config.Credentials.Retrieve: %w", err))
+	}
+	if !creds.CanExpire {
+		return true, time.Time{}, nil
+	}
+
+	return !creds.Expired(), creds.Expires, nil
+}
+
+// HTTPOptions tunes the SDK's default HTTP client. Zero values keep the SDK defaults.
+type HTTPOptions struct {
+	Timeout             time.Duration `json:"timeout"`
+	MaxIdleConns        int           `json:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout"`
+}
+
+// WithHTTPClient sets the HTTP client used by every service client created
+// from c, and returns c for chaining.
+// ex: cfg.WithHTTPClient(&http.Client{Timeout: 10 * time.Second})
+func (c *AwsConfig) WithHTTPClient(client aws.HTTPClient) *AwsConfig {
+	c.Config.HTTPClient = client
+	return c
+}
+
+// WithHTTPOptions sets the HTTP client used by every service client created from c
+// to the SDK's default client tuned per opts, and returns c for chaining.
+// ex: cfg.WithHTTPOptions(HTTPOptions{Timeout: 10 * time.Second, MaxIdleConnsPerHost: 100})
+func (c *AwsConfig) WithHTTPOptions(opts HTTPOptions) *AwsConfig {
+	client := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		if opts.MaxIdleConns > 0 {
+			tr.MaxIdleConns = opts.MaxIdleConns
+		}
+		if opts.MaxIdleConnsPerHost > 0 {
+			tr.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		}
+		if opts.IdleConnTimeout > 0 {
+			tr.IdleConnTimeout = opts.IdleConnTimeout
+		}
+	})
+	if opts.Timeout > 0 {
+		client = client.WithTimeout(opts.Timeout)
+	}
+	return c.WithHTTPClient(client)
 }
 
 func NewDefaultConfig(ctx context.Context) (*AwsConfig, error) {
@@ -38,6 +130,24 @@ func NewConfigWithProfile(ctx context.Context, profile string) (*AwsConfig, erro
 	return &AwsConfig{Config: cfg}, nil
 }
 
+// NewConfigForProfile loads the configuration for the given shared config profile
+// in the given region. If region is empty, the profile's region is used.
+func NewConfigForProfile(ctx context.Context, profile, region string) (*AwsConfig, error) {
+	optFns := []func(*config.LoadOptions) error{
+		config.WithSharedConfigProfile(profile),
+	}
+	if region != "" {
+		optFns = append(optFns, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("config.LoadDefaultConfig: %w", err)
+	}
+
+	return &AwsConfig{Config: cfg}, nil
+}
+
 func NewConfigFromEnv(
 	ctx context.Context,
 	accessKeyId,
@@ -0,0 +1,231 @@
+package goaws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewConfigForProfile(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	credentialsFile := filepath.Join(dir, "credentials")
+	require.NoError(t, os.WriteFile(configFile, []byte("[profile test-profile]\nregion = us-west-2\n"), 0o600))
+	require.NoError(t, os.WriteFile(credentialsFile, []byte("[test-profile]\naws_access_key_id = AKIDTEST\naws_secret_access_key = secret\n"), 0o600))
+
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsFile)
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	tests := []struct {
+		name           string
+		profile        string
+		region         string
+		expectedRegion string
+		expectError    bool
+	}{
+		{name: "explicit region", profile: "test-profile", region: "eu-central-1", expectedRegion: "eu-central-1"},
+		{name: "profile region", profile: "test-profile", expectedRegion: "us-west-2"},
+		{name: "missing profile", profile: "missing-profile", region: "us-east-1", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewConfigForProfile(context.Background(), tt.profile, tt.region)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRegion, cfg.Config.Region)
+
+			creds, err := cfg.Config.Credentials.Retrieve(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "AKIDTEST", creds.AccessKeyID)
+		})
+	}
+}
+
+func TestAwsConfig_WithHTTPClient(t *testing.T) {
+	cfg, err := NewConfigFromEnv(context.Background(), "AKIDTEST", "secret", "")
+	require.NoError(t, err)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	assert.Same(t, cfg, cfg.WithHTTPClient(client))
+	assert.Same(t, client, cfg.Config.HTTPClient)
+}
+
+func TestAwsConfig_WithHTTPOptions(t *testing.T) {
+	tests := []struct {
+		name                string
+		opts                HTTPOptions
+		expectedTimeout     time.Duration
+		expectedIdleConns   int
+		expectedIdlePerHost int
+	}{
+		{
+			name:                "Tuned",
+			opts:                HTTPOptions{Timeout: 10 * time.Second, MaxIdleConns: 200, MaxIdleConnsPerHost: 100, IdleConnTimeout: time.Minute},
+			expectedTimeout:     10 * time.Second,
+			expectedIdleConns:   200,
+			expectedIdlePerHost: 100,
+		},
+		{
+			name:                "Defaults",
+			expectedIdleConns:   awshttp.DefaultHTTPTransportMaxIdleConns,
+			expectedIdlePerHost: awshttp.DefaultHTTPTransportMaxIdleConnsPerHost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewConfigFromEnv(context.Background(), "AKIDTEST", "secret", "")
+			require.NoError(t, err)
+
+			cfg.WithHTTPOptions(tt.opts)
+
+			client, ok := cfg.Config.HTTPClient.(*awshttp.BuildableClient)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedTimeout, client.GetTimeout())
+			tr := client.GetTransport()
+			assert.Equal(t, tt.expectedIdleConns, tr.MaxIdleConns)
+			assert.Equal(t, tt.expectedIdlePerHost, tr.MaxIdleConnsPerHost)
+		})
+	}
+}
+
+func TestAwsConfig_Region(t *testing.T) {
+	cfg := &AwsConfig{Config: aws.Config{Region: "us-west-2"}}
+	assert.Equal(t, "us-west-2", cfg.Region())
+}
+
+func TestAwsConfig_AccountID(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockOut       *sts.GetCallerIdentityOutput
+		mockErr       error
+		expected      string
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockOut: &sts.GetCallerIdentityOutput{
+				Account: aws.String("123456789012"),
+				Arn:     aws.String("arn:aws:iam::123456789012:user/test"),
+				UserId:  aws.String("AIDATEST"),
+			},
+			expected: "123456789012",
+		},
+		{
+			name:          "Error",
+			mockErr:       errors.New("sts fail"),
+			expectedError: NewInternalError(errors.New("svc.GetCallerIdentity: sts fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSTSClientAPI(ctrl)
+			m.EXPECT().GetCallerIdentity(gomock.Any(), &sts.GetCallerIdentityInput{}).Return(tt.mockOut, tt.mockErr).Times(1)
+			cfg := &AwsConfig{Config: aws.Config{Region: "us-east-1"}, sts: m}
+
+			account, err := cfg.AccountID(context.Background())
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*AwsError)(nil), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, account)
+		})
+	}
+}
+
+func TestAwsConfig_CredentialsValid(t *testing.T) {
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		provider       aws.CredentialsProvider
+		expectedValid  bool
+		expectedExpiry time.Time
+		expectedError  error
+	}{
+		{
+			name: "Valid",
+			provider: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "AKIDTEST", SecretAccessKey: "secret", CanExpire: true, Expires: future}, nil
+			}),
+			expectedValid:  true,
+			expectedExpiry: future,
+		},
+		{
+			name: "Expired",
+			provider: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "AKIDTEST", SecretAccessKey: "secret", CanExpire: true, Expires: past}, nil
+			}),
+			expectedValid:  false,
+			expectedExpiry: past,
+		},
+		{
+			name: "NoExpiry",
+			provider: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "AKIDTEST", SecretAccessKey: "secret"}, nil
+			}),
+			expectedValid: true,
+		},
+		{
+			name: "RetrieveError",
+			provider: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{}, errors.New("token expired")
+			}),
+			expectedError: NewInternalError(errors.New("c.Config.Credentials.Retrieve: token expired")),
+		},
+		{
+			name:          "NoProvider",
+			expectedError: NewClientError(errors.New("no credentials provider configured")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &AwsConfig{Config: aws.Config{Region: "us-east-1", Credentials: tt.provider}}
+
+			valid, expires, err := cfg.CredentialsValid(context.Background())
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*AwsError)(nil), err)
+				assert.False(t, valid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValid, valid)
+			assert.True(t, tt.expectedExpiry.Equal(expires))
+		})
+	}
+}
@@ -1,5 +1,28 @@
 package goaws
 
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+)
+
+// ErrNotFound is matched via errors.Is by each service error type that
+// represents a missing resource (tables, queues, objects, secrets, etc...).
+var ErrNotFound = errors.New("not found")
+
+// ErrAccessDenied is matched via errors.Is by AccessDeniedError and by each
+// service error type that represents a permissions failure.
+var ErrAccessDenied = errors.New("access denied")
+
+// ErrMaxRetriesExceeded is matched via errors.Is by MaxRetriesExceededError.
+var ErrMaxRetriesExceeded = errors.New("max retries exceeded")
+
+// ErrDeadlineExceeded is matched via errors.Is by DeadlineExceededError.
+var ErrDeadlineExceeded = errors.New("deadline exceeded before next retry")
+
 // AwsError is a generic interface for implementing
 // error handling for each service.
 type AwsError interface {
@@ -8,10 +31,27 @@ type AwsError interface {
 	ClientError() bool
 }
 
+// RequestIDer is implemented by the errors in this package that carry the AWS
+// request ID of the failed call, e.g. for support cases. Retrieve it with errors.As.
+// ex: var rid goaws.RequestIDer; if errors.As(err, &rid) { log.Print(rid.RequestID()) }
+type RequestIDer interface {
+	RequestID() string
+}
+
+// requestID returns the AWS request ID carried by err, if any.
+func requestID(err error) string {
+	var re interface{ ServiceRequestID() string }
+	if errors.As(err, &re) {
+		return re.ServiceRequestID()
+	}
+	return ""
+}
+
 type GenericError struct {
 	msg       string
 	retryable bool
 	clientErr bool
+	requestID string
 }
 
 func (e *GenericError) Error() string {
@@ -26,6 +66,10 @@ func (e *GenericError) ClientError() bool {
 	return e.clientErr
 }
 
+func (e *GenericError) RequestID() string {
+	return e.requestID
+}
+
 func NewGenericError(err error, retryable bool, clientErr bool) *GenericError {
 	if err == nil {
 		return nil
@@ -34,11 +78,13 @@ func NewGenericError(err error, retryable bool, clientErr bool) *GenericError {
 		msg:       err.Error(),
 		retryable: retryable,
 		clientErr: clientErr,
+		requestID: requestID(err),
 	}
 }
 
 type InternalError struct {
-	msg string
+	msg       string
+	requestID string
 }
 
 func (e *InternalError) Error() string {
@@ -53,17 +99,23 @@ func (e *InternalError) ClientError() bool {
 	return false
 }
 
+func (e *InternalError) RequestID() string {
+	return e.requestID
+}
+
 func NewInternalError(err error) *InternalError {
 	if err == nil {
 		return nil
 	}
 	return &InternalError{
-		msg: err.Error(),
+		msg:       err.Error(),
+		requestID: requestID(err),
 	}
 }
 
 type ClientErr struct {
-	msg string
+	msg       string
+	requestID string
 }
 
 func (e *ClientErr) Error() string {
@@ -78,17 +130,23 @@ func (e *ClientErr) ClientError() bool {
 	return true
 }
 
+func (e *ClientErr) RequestID() string {
+	return e.requestID
+}
+
 func NewClientError(err error) *ClientErr {
 	if err == nil {
 		return nil
 	}
 	return &ClientErr{
-		msg: err.Error(),
+		msg:       err.Error(),
+		requestID: requestID(err),
 	}
 }
 
 type RetryableInternalError struct {
-	msg string
+	msg       string
+	requestID string
 }
 
 func (e *RetryableInternalError) Error() string {
@@ -103,17 +161,23 @@ func (e *RetryableInternalError) ClientError() bool {
 	return false
 }
 
+func (e *RetryableInternalError) RequestID() string {
+	return e.requestID
+}
+
 func NewRetryableInternalError(err error) *RetryableInternalError {
 	if err == nil {
 		return nil
 	}
 	return &RetryableInternalError{
-		msg: err.Error(),
+		msg:       err.Error(),
+		requestID: requestID(err),
 	}
 }
 
 type RetryableClientError struct {
-	msg string
+	msg       string
+	requestID string
 }
 
 func (e *RetryableClientError) Error() string {
@@ -128,11 +192,87 @@ func (e *RetryableClientError) ClientError() bool {
 	return true
 }
 
+func (e *RetryableClientError) RequestID() string {
+	return e.requestID
+}
+
 func NewRetryableClientError(err error) *RetryableClientError {
 	if err == nil {
 		return nil
 	}
 	return &RetryableClientError{
-		msg: err.Error(),
+		msg:       err.Error(),
+		requestID: requestID(err),
 	}
 }
+
+// AccessDeniedError is returned when the caller's credentials are not
+// permitted to perform the requested operation.
+type AccessDeniedError struct {
+	*ClientErr
+}
+
+func NewAccessDeniedError(err error) *AccessDeniedError {
+	if err == nil {
+		return nil
+	}
+	return &AccessDeniedError{NewClientError(err)}
+}
+
+func (e *AccessDeniedError) Is(target error) bool {
+	return target == ErrAccessDenied
+}
+
+// IsAccessDenied reports whether err is an AWS AccessDenied or
+// AccessDeniedException error, or an HTTP 403 response.
+func IsAccessDenied(err error) bool {
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		switch ae.ErrorCode() {
+		case "AccessDenied", "AccessDeniedException":
+			return true
+		}
+	}
+	var re *awshttp.ResponseError
+	return errors.As(err, &re) && re.ResponseError != nil && re.Response != nil &&
+		re.HTTPStatusCode() == http.StatusForbidden
+}
+
+// NewServiceError returns an AccessDeniedError if err is an access denied
+// error, or an InternalError otherwise.
+func NewServiceError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if IsAccessDenied(err) {
+		return NewAccessDeniedError(err)
+	}
+	return NewInternalError(err)
+}
+
+// MaxRetriesExceededError is returned by Retries when the retry time cap is reached.
+type MaxRetriesExceededError struct {
+	*ClientErr
+}
+
+func NewMaxRetriesExceededError() *MaxRetriesExceededError {
+	return &MaxRetriesExceededError{NewClientError(errors.New("max retries exceeded"))}
+}
+
+func (e *MaxRetriesExceededError) Is(target error) bool {
+	return target == ErrMaxRetriesExceeded
+}
+
+// DeadlineExceededError is returned by Retries when the context deadline
+// would pass before the next retry. It also matches context.DeadlineExceeded.
+type DeadlineExceededError struct {
+	*ClientErr
+}
+
+func NewDeadlineExceededError() *DeadlineExceededError {
+	return &DeadlineExceededError{NewClientError(errors.New("context deadline exceeded before next retry"))}
+}
+
+func (e *DeadlineExceededError) Is(target error) bool {
+	return target == ErrDeadlineExceeded || target == context.DeadlineExceeded
+}
@@ -2,8 +2,13 @@ package goaws
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,3 +77,77 @@ func TestNewRetryableClientError(t *testing.T) {
 	assert.Equal(t, true, ce.ClientError())
 	assert.Implements(t, (*AwsError)(nil), ce)
 }
+
+func TestRequestID(t *testing.T) {
+	awsErr := &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusInternalServerError}},
+			Err:      errors.New("internal failure"),
+		},
+		RequestID: "req-123",
+	}
+	wrapped := fmt.Errorf("s.svc.Call: %w", awsErr)
+
+	var tests = []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "generic", err: NewGenericError(wrapped, true, false), expected: "req-123"},
+		{name: "internal", err: NewInternalError(wrapped), expected: "req-123"},
+		{name: "client", err: NewClientError(wrapped), expected: "req-123"},
+		{name: "retryable internal", err: NewRetryableInternalError(wrapped), expected: "req-123"},
+		{name: "retryable client", err: NewRetryableClientError(wrapped), expected: "req-123"},
+		{name: "no request id", err: NewInternalError(errors.New("test error")), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var rid RequestIDer
+			require.ErrorAs(t, fmt.Errorf("wrapped: %w", tt.err), &rid)
+			assert.Equal(t, tt.expected, rid.RequestID())
+		})
+	}
+}
+
+func TestNewServiceError(t *testing.T) {
+	responseError := func(status int) error {
+		return &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+				Err:      errors.New(http.StatusText(status)),
+			},
+		}
+	}
+
+	var tests = []struct {
+		name         string
+		err          error
+		accessDenied bool
+	}{
+		{name: "AccessDenied", err: &smithy.GenericAPIError{Code: "AccessDenied"}, accessDenied: true},
+		{name: "AccessDeniedException", err: &smithy.GenericAPIError{Code: "AccessDeniedException"}, accessDenied: true},
+		{name: "Forbidden", err: responseError(http.StatusForbidden), accessDenied: true},
+		{name: "OtherCode", err: &smithy.GenericAPIError{Code: "ValidationException"}, accessDenied: false},
+		{name: "OtherStatus", err: responseError(http.StatusInternalServerError), accessDenied: false},
+		{name: "Error", err: errors.New("test error"), accessDenied: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("s.svc.Call: %w", tt.err)
+			assert.Equal(t, tt.accessDenied, IsAccessDenied(wrapped))
+
+			err := NewServiceError(wrapped)
+			require.Error(t, err)
+			assert.EqualError(t, err, wrapped.Error())
+			assert.Implements(t, (*AwsError)(nil), err)
+			assert.Equal(t, tt.accessDenied, errors.Is(err, ErrAccessDenied))
+			assert.Equal(t, tt.accessDenied, err.(AwsError).ClientError())
+		})
+	}
+
+	assert.NoError(t, NewServiceError(nil))
+}
@@ -0,0 +1,163 @@
+package godynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+)
+
+// AutoScalingLogic defines methods for configuring auto scaling of provisioned tables
+//
+//go:generate mockgen -destination=../mocks/godynamomock/autoscaling.go -package=godynamomock . AutoScalingLogic
+type AutoScalingLogic interface {
+	RegisterScalableTarget(ctx context.Context, target ScalableTarget) error
+	PutScalingPolicy(ctx context.Context, policy ScalingPolicy) (string, error)
+}
+
+// AutoScalingClientAPI defines the interface for the AWS Application Auto Scaling client methods used by this package.
+//
+//go:generate mockgen -destination=./autoscaling_client_api_test.go -package=godynamo . AutoScalingClientAPI
+type AutoScalingClientAPI interface {
+	RegisterScalableTarget(ctx context.Context, params *applicationautoscaling.RegisterScalableTargetInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.RegisterScalableTargetOutput, error)
+	PutScalingPolicy(ctx context.Context, params *applicationautoscaling.PutScalingPolicyInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.PutScalingPolicyOutput, error)
+}
+
+type AutoScaling struct {
+	svc AutoScalingClientAPI
+}
+
+func NewAutoScaling(svc AutoScalingClientAPI) *AutoScaling {
+	return &AutoScaling{svc: svc}
+}
+
+// RegisterScalableTarget registers the read or write capacity of a provisioned table
+// or global secondary index as a scalable target with the given capacity bounds.
+// A target must be registered before a scaling policy can be applied to it.
+func (a *AutoScaling) RegisterScalableTarget(ctx context.Context, target ScalableTarget) error {
+	if target.TableName == "" {
+		return NewNilModelError()
+	}
+	dimension, err := scalableDimension(target.IndexName, target.Capacity)
+	if err != nil {
+		return err
+	}
+	if target.MinCapacity < 1 || target.MaxCapacity < target.MinCapacity {
+		return goaws.NewClientError(fmt.Errorf("invalid capacity range: min %d, max %d", target.MinCapacity, target.MaxCapacity))
+	}
+
+	input := &applicationautoscaling.RegisterScalableTargetInput{
+		ServiceNamespace:  types.ServiceNamespaceDynamodb,
+		ResourceId:        aws.String(scalingResourceID(target.TableName, target.IndexName)),
+		ScalableDimension: dimension,
+		MinCapacity:       aws.Int32(target.MinCapacity),
+		MaxCapacity:       aws.Int32(target.MaxCapacity),
+	}
+	if _, err := a.svc.RegisterScalableTarget(ctx, input); err != nil {
+		return handleAutoScalingErr(fmt.Errorf("a.svc.RegisterScalableTarget: %w", err))
+	}
+
+	return nil
+}
+
+// PutScalingPolicy creates or updates a target tracking policy that keeps the read or
+// write capacity utilization of a registered scalable target at policy.TargetUtilization
+// percent, and returns the policy ARN. PolicyName defaults to
+// "<table>[-<index>]-<read|write>-scaling-policy" if empty.
+func (a *AutoScaling) PutScalingPolicy(ctx context.Context, policy ScalingPolicy) (string, error) {
+	if policy.TableName == "" {
+		return "", NewNilModelError()
+	}
+	dimension, err := scalableDimension(policy.IndexName, policy.Capacity)
+	if err != nil {
+		return "", err
+	}
+	if policy.TargetUtilization < 20 || policy.TargetUtilization > 90 {
+		return "", goaws.NewClientError(fmt.Errorf("invalid target utilization: %v (must be between 20 and 90)", policy.TargetUtilization))
+	}
+
+	metric := types.MetricTypeDynamoDBReadCapacityUtilization
+	if policy.Capacity == CapacityWrite {
+		metric = types.MetricTypeDynamoDBWriteCapacityUtilization
+	}
+
+	name := policy.PolicyName
+	if name == "" {
+		name = policy.TableName
+		if policy.IndexName != "" {
+			name += "-" + policy.IndexName
+		}
+		name += fmt.Sprintf("-%s-scaling-policy", policy.Capacity)
+	}
+
+	config := &types.TargetTrackingScalingPolicyConfiguration{
+		TargetValue: aws.Float64(policy.TargetUtilization),
+		PredefinedMetricSpecification: &types.PredefinedMetricSpecification{
+			PredefinedMetricType: metric,
+		},
+	}
+	if policy.ScaleInCooldown > 0 {
+		config.ScaleInCooldown = aws.Int32(policy.ScaleInCooldown)
+	}
+	if policy.ScaleOutCooldown > 0 {
+		config.ScaleOutCooldown = aws.Int32(policy.ScaleOutCooldown)
+	}
+
+	input := &applicationautoscaling.PutScalingPolicyInput{
+		PolicyName:                               aws.String(name),
+		ServiceNamespace:                         types.ServiceNamespaceDynamodb,
+		ResourceId:                               aws.String(scalingResourceID(policy.TableName, policy.IndexName)),
+		ScalableDimension:                        dimension,
+		PolicyType:                               types.PolicyTypeTargetTrackingScaling,
+		TargetTrackingScalingPolicyConfiguration: config,
+	}
+	result, err := a.svc.PutScalingPolicy(ctx, input)
+	if err != nil {
+		return "", handleAutoScalingErr(fmt.Errorf("a.svc.PutScalingPolicy: %w", err))
+	}
+
+	return aws.ToString(result.PolicyARN), nil
+}
+
+// scalingResourceID returns the Application Auto Scaling resource ID of a table or index.
+func scalingResourceID(tableName, indexName string) string {
+	if indexName == "" {
+		return "table/" + tableName
+	}
+	return "table/" + tableName + "/index/" + indexName
+}
+
+// scalableDimension returns the scalable dimension of the read or write capacity of a table or index.
+func scalableDimension(indexName string, capacity Capacity) (types.ScalableDimension, error) {
+	switch {
+	case capacity == CapacityRead && indexName == "":
+		return types.ScalableDimensionDynamoDBTableReadCapacityUnits, nil
+	case capacity == CapacityWrite && indexName == "":
+		return types.ScalableDimensionDynamoDBTableWriteCapacityUnits, nil
+	case capacity == CapacityRead:
+		return types.ScalableDimensionDynamoDBIndexReadCapacityUnits, nil
+	case capacity == CapacityWrite:
+		return types.ScalableDimensionDynamoDBIndexWriteCapacityUnits, nil
+	default:
+		return "", goaws.NewClientError(fmt.Errorf("invalid capacity: %q", capacity))
+	}
+}
+
+func handleAutoScalingErr(err error) error {
+	var (
+		validation *types.ValidationException
+		notFound   *types.ObjectNotFoundException
+	)
+	switch {
+	case errors.As(err, &validation):
+		return goaws.NewClientError(err)
+	case errors.As(err, &notFound):
+		return NewResourceNotFoundError(aws.ToString(notFound.Message))
+	default:
+		return goaws.NewServiceError(err)
+	}
+}
@@ -0,0 +1,202 @@
+package godynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	astypes "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+	"github.com/aws/smithy-go"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestNewAutoScaling(t *testing.T) {
+	a := NewAutoScaling(applicationautoscaling.New(applicationautoscaling.Options{Region: "us-east-1"}))
+	assert.NotNil(t, a.svc)
+	assert.Implements(t, (*AutoScalingLogic)(nil), a)
+}
+
+func TestAutoScaling_RegisterScalableTarget(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        ScalableTarget
+		mockSetup     func(t *testing.T, m *MockAutoScalingClientAPI)
+		expectedError error
+	}{
+		{
+			name:   "Table",
+			target: ScalableTarget{TableName: "test-table", Capacity: CapacityRead, MinCapacity: 5, MaxCapacity: 100},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().RegisterScalableTarget(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *applicationautoscaling.RegisterScalableTargetInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.RegisterScalableTargetOutput, error) {
+						assert.Equal(t, astypes.ServiceNamespaceDynamodb, in.ServiceNamespace)
+						assert.Equal(t, "table/test-table", aws.ToString(in.ResourceId))
+						assert.Equal(t, astypes.ScalableDimensionDynamoDBTableReadCapacityUnits, in.ScalableDimension)
+						assert.Equal(t, int32(5), aws.ToInt32(in.MinCapacity))
+						assert.Equal(t, int32(100), aws.ToInt32(in.MaxCapacity))
+						return &applicationautoscaling.RegisterScalableTargetOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name:   "Index",
+			target: ScalableTarget{TableName: "test-table", IndexName: "gsi1", Capacity: CapacityWrite, MinCapacity: 1, MaxCapacity: 10},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().RegisterScalableTarget(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *applicationautoscaling.RegisterScalableTargetInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.RegisterScalableTargetOutput, error) {
+						assert.Equal(t, "table/test-table/index/gsi1", aws.ToString(in.ResourceId))
+						assert.Equal(t, astypes.ScalableDimensionDynamoDBIndexWriteCapacityUnits, in.ScalableDimension)
+						return &applicationautoscaling.RegisterScalableTargetOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name:          "InvalidCapacity",
+			target:        ScalableTarget{TableName: "test-table", Capacity: "both", MinCapacity: 1, MaxCapacity: 10},
+			mockSetup:     func(t *testing.T, m *MockAutoScalingClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New(`invalid capacity: "both"`)),
+		},
+		{
+			name:          "InvalidRange",
+			target:        ScalableTarget{TableName: "test-table", Capacity: CapacityRead, MinCapacity: 10, MaxCapacity: 5},
+			mockSetup:     func(t *testing.T, m *MockAutoScalingClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New("invalid capacity range: min 10, max 5")),
+		},
+		{
+			name:          "NoTable",
+			target:        ScalableTarget{Capacity: CapacityRead, MinCapacity: 1, MaxCapacity: 10},
+			mockSetup:     func(t *testing.T, m *MockAutoScalingClientAPI) {},
+			expectedError: NewNilModelError(),
+		},
+		{
+			name:   "ValidationError",
+			target: ScalableTarget{TableName: "test-table", Capacity: CapacityRead, MinCapacity: 1, MaxCapacity: 10},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().RegisterScalableTarget(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, &astypes.ValidationException{Message: aws.String("table is on-demand")}).Times(1)
+			},
+			expectedError: goaws.NewClientError(errors.New("a.svc.RegisterScalableTarget: ValidationException: table is on-demand")),
+		},
+		{
+			name:   "AccessDenied",
+			target: ScalableTarget{TableName: "test-table", Capacity: CapacityRead, MinCapacity: 1, MaxCapacity: 10},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().RegisterScalableTarget(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "denied"}).Times(1)
+			},
+			expectedError: goaws.NewAccessDeniedError(errors.New("a.svc.RegisterScalableTarget: api error AccessDeniedException: denied")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockAutoScalingClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			a := NewAutoScaling(m)
+
+			err := a.RegisterScalableTarget(context.Background(), tt.target)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAutoScaling_PutScalingPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        ScalingPolicy
+		mockSetup     func(t *testing.T, m *MockAutoScalingClientAPI)
+		expectedArn   string
+		expectedError error
+	}{
+		{
+			name:   "Write",
+			policy: ScalingPolicy{TableName: "test-table", Capacity: CapacityWrite, TargetUtilization: 70, ScaleInCooldown: 60},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().PutScalingPolicy(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *applicationautoscaling.PutScalingPolicyInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.PutScalingPolicyOutput, error) {
+						assert.Equal(t, "test-table-write-scaling-policy", aws.ToString(in.PolicyName))
+						assert.Equal(t, "table/test-table", aws.ToString(in.ResourceId))
+						assert.Equal(t, astypes.ScalableDimensionDynamoDBTableWriteCapacityUnits, in.ScalableDimension)
+						assert.Equal(t, astypes.PolicyTypeTargetTrackingScaling, in.PolicyType)
+						config := in.TargetTrackingScalingPolicyConfiguration
+						require.NotNil(t, config)
+						assert.Equal(t, 70.0, aws.ToFloat64(config.TargetValue))
+						assert.Equal(t, astypes.MetricTypeDynamoDBWriteCapacityUtilization, config.PredefinedMetricSpecification.PredefinedMetricType)
+						assert.Equal(t, int32(60), aws.ToInt32(config.ScaleInCooldown))
+						assert.Nil(t, config.ScaleOutCooldown)
+						return &applicationautoscaling.PutScalingPolicyOutput{PolicyARN: aws.String("arn:policy")}, nil
+					}).Times(1)
+			},
+			expectedArn: "arn:policy",
+		},
+		{
+			name:   "IndexRead",
+			policy: ScalingPolicy{TableName: "test-table", IndexName: "gsi1", Capacity: CapacityRead, PolicyName: "custom", TargetUtilization: 50},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().PutScalingPolicy(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *applicationautoscaling.PutScalingPolicyInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.PutScalingPolicyOutput, error) {
+						assert.Equal(t, "custom", aws.ToString(in.PolicyName))
+						assert.Equal(t, "table/test-table/index/gsi1", aws.ToString(in.ResourceId))
+						assert.Equal(t, astypes.ScalableDimensionDynamoDBIndexReadCapacityUnits, in.ScalableDimension)
+						assert.Equal(t, astypes.MetricTypeDynamoDBReadCapacityUtilization, in.TargetTrackingScalingPolicyConfiguration.PredefinedMetricSpecification.PredefinedMetricType)
+						return &applicationautoscaling.PutScalingPolicyOutput{PolicyARN: aws.String("arn:index-policy")}, nil
+					}).Times(1)
+			},
+			expectedArn: "arn:index-policy",
+		},
+		{
+			name:          "InvalidTarget",
+			policy:        ScalingPolicy{TableName: "test-table", Capacity: CapacityRead, TargetUtilization: 95},
+			mockSetup:     func(t *testing.T, m *MockAutoScalingClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New("invalid target utilization: 95 (must be between 20 and 90)")),
+		},
+		{
+			name:   "TargetNotRegistered",
+			policy: ScalingPolicy{TableName: "test-table", Capacity: CapacityRead, TargetUtilization: 70},
+			mockSetup: func(t *testing.T, m *MockAutoScalingClientAPI) {
+				m.EXPECT().PutScalingPolicy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, &astypes.ObjectNotFoundException{Message: aws.String("no scalable target")}).Times(1)
+			},
+			expectedError: NewResourceNotFoundError("no scalable target"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockAutoScalingClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			a := NewAutoScaling(m)
+
+			arn, err := a.PutScalingPolicy(context.Background(), tt.policy)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedArn, arn)
+			}
+		})
+	}
+}
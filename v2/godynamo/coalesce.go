@@ -0,0 +1,81 @@
+package godynamo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// getItemFlights coalesces concurrent GetItem calls for the same item into a
+// single DynamoDB call whose result is shared by every caller.
+type getItemFlights struct {
+	mu    sync.Mutex
+	calls map[string]*getItemCall
+}
+
+// getItemCall is an in-flight GetItem call.
+type getItemCall struct {
+	done chan struct{}
+	item map[string]types.AttributeValue
+	err  error
+}
+
+// WithGetItemCoalescing makes concurrent GetItem calls for the same table, key,
+// projection and read consistency share a single DynamoDB call, and returns q for
+// chaining. Calls that join an in-flight read receive its result, including any
+// error caused by the first caller's context being canceled, or return their own
+// context's error if it's canceled first.
+// ex: q := NewQueries(svc, tables, nil).WithGetItemCoalescing()
+func (q *Queries) WithGetItemCoalescing() *Queries {
+	q.flights = &getItemFlights{calls: make(map[string]*getItemCall)}
+	return q
+}
+
+// getItem calls GetItem with input, joining an identical in-flight call if
+// coalescing is enabled, and returns the item.
+func (q *Queries) getItem(ctx context.Context, t *Table, input *dynamodb.GetItemInput) (map[string]types.AttributeValue, error) {
+	if q.flights == nil {
+		result, err := q.svc.GetItem(ctx, input)
+		if err != nil {
+			return nil, handleErr(fmt.Errorf("q.svc.GetItem: %w", err))
+		}
+		return result.Item, nil
+	}
+
+	// fmt prints maps sorted by key, so equal inputs produce equal keys; %q quotes
+	// each string so distinct inputs can't produce the same key
+	key := fmt.Sprintf("%q %q %t %q %q",
+		t.TableName, itemKey(input.Key, t), aws.ToBool(input.ConsistentRead), aws.ToString(input.ProjectionExpression), input.ExpressionAttributeNames)
+
+	q.flights.mu.Lock()
+	if call, ok := q.flights.calls[key]; ok {
+		q.flights.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return call.item, call.err
+	}
+	call := &getItemCall{done: make(chan struct{})}
+	q.flights.calls[key] = call
+	q.flights.mu.Unlock()
+
+	result, err := q.svc.GetItem(ctx, input)
+	if err != nil {
+		call.err = handleErr(fmt.Errorf("q.svc.GetItem: %w", err))
+	} else {
+		call.item = result.Item
+	}
+
+	q.flights.mu.Lock()
+	delete(q.flights.calls, key)
+	q.flights.mu.Unlock()
+	close(call.done)
+
+	return call.item, call.err
+}
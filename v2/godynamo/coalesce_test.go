@@ -0,0 +1,117 @@
+package godynamo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestQueries_WithGetItemCoalescing(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			close(started)
+			<-release
+			return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+				"id":   in.Key["id"],
+				"name": &types.AttributeValueMemberS{Value: "test"},
+			}}, nil
+		}).Times(1)
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+	q := NewQueries(m, tables, nil).WithGetItemCoalescing()
+
+	const callers = 10
+	items := make([]map[string]any, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	get := func(i int) {
+		defer wg.Done()
+		errs[i] = q.GetItem(context.Background(), GetItemParams{
+			Query:     CreateNewQueryObj("1", nil),
+			TableName: "test-table",
+			ItemPtr:   &items[i],
+		})
+	}
+
+	wg.Add(1)
+	go get(0)
+	<-started
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go get(i)
+	}
+	// give the other callers time to join the in-flight read
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range callers {
+		require.NoError(t, errs[i])
+		assert.Equal(t, map[string]any{"id": "1", "name": "test"}, items[i])
+	}
+	assert.Empty(t, q.flights.calls)
+}
+
+func TestQueries_WithGetItemCoalescing_WaiterCanceled(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			close(started)
+			<-release
+			return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{"id": in.Key["id"]}}, nil
+		}).Times(1)
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+	q := NewQueries(m, tables, nil).WithGetItemCoalescing()
+
+	var item map[string]any
+	done := make(chan error)
+	go func() {
+		done <- q.GetItem(context.Background(), GetItemParams{
+			Query:     CreateNewQueryObj("1", nil),
+			TableName: "test-table",
+			ItemPtr:   &item,
+		})
+	}()
+	<-started
+
+	// the waiter returns as soon as its own context is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var waiterItem map[string]any
+	err := q.GetItem(ctx, GetItemParams{
+		Query:     CreateNewQueryObj("1", nil),
+		TableName: "test-table",
+		ItemPtr:   &waiterItem,
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, waiterItem)
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, map[string]any{"id": "1"}, item)
+}
@@ -0,0 +1,79 @@
+package godynamo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// cursorValue is the JSON encoding of a key attribute value.
+// Key attributes are always strings, numbers or binary.
+type cursorValue struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+	B []byte  `json:"B,omitempty"`
+}
+
+// EncodeCursor encodes the LastKey of a query or scan result as an opaque, URL safe
+// cursor string that can be returned to API clients and passed back to DecodeCursor
+// to resume paging. An empty key is encoded as an empty cursor.
+func EncodeCursor(lastKey map[string]types.AttributeValue) (string, error) {
+	if len(lastKey) == 0 {
+		return "", nil
+	}
+
+	values := make(map[string]cursorValue, len(lastKey))
+	for name, av := range lastKey {
+		switch v := av.(type) {
+		case *types.AttributeValueMemberS:
+			values[name] = cursorValue{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			values[name] = cursorValue{N: &v.Value}
+		case *types.AttributeValueMemberB:
+			values[name] = cursorValue{B: v.Value}
+		default:
+			return "", NewInvalidCursorError(fmt.Errorf("unsupported key attribute type %T for %s", av, name))
+		}
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", NewInvalidCursorError(fmt.Errorf("json.Marshal: %w", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes a cursor returned by EncodeCursor back to the LastKey it was
+// encoded from, to be used as the ExclusiveStartKey of the next query or scan.
+// An empty cursor decodes to a nil key.
+func DecodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, NewInvalidCursorError(fmt.Errorf("base64.RawURLEncoding.DecodeString: %w", err))
+	}
+	var values map[string]cursorValue
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, NewInvalidCursorError(fmt.Errorf("json.Unmarshal: %w", err))
+	}
+
+	key := make(map[string]types.AttributeValue, len(values))
+	for name, v := range values {
+		switch {
+		case v.S != nil && v.N == nil && v.B == nil:
+			key[name] = &types.AttributeValueMemberS{Value: *v.S}
+		case v.N != nil && v.S == nil && v.B == nil:
+			key[name] = &types.AttributeValueMemberN{Value: *v.N}
+		case v.B != nil && v.S == nil && v.N == nil:
+			key[name] = &types.AttributeValueMemberB{Value: v.B}
+		default:
+			return nil, NewInvalidCursorError(fmt.Errorf("invalid value for key attribute %s", name))
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,65 @@
+package godynamo
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  map[string]types.AttributeValue
+	}{
+		{name: "empty", key: nil},
+		{name: "string key", key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: "user#1/2?x=y"},
+		}},
+		{name: "composite key", key: map[string]types.AttributeValue{
+			"id":      &types.AttributeValueMemberS{Value: "1"},
+			"year":    &types.AttributeValueMemberN{Value: "2024"},
+			"payload": &types.AttributeValueMemberB{Value: []byte{0, 1, 255}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cursor, err := EncodeCursor(tt.key)
+			require.NoError(t, err)
+			assert.NotContains(t, cursor, "+")
+			assert.NotContains(t, cursor, "/")
+
+			key, err := DecodeCursor(cursor)
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestCursor_Invalid(t *testing.T) {
+	_, err := EncodeCursor(map[string]types.AttributeValue{"id": &types.AttributeValueMemberBOOL{Value: true}})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not base64!"},
+		{name: "not json", cursor: base64.RawURLEncoding.EncodeToString([]byte("{"))},
+		{name: "no value", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"id":{}}`))},
+		{name: "multiple values", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"id":{"S":"1","N":"1"}}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := DecodeCursor(tt.cursor)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
@@ -20,6 +21,7 @@ type DynamoDB struct {
 	Tables       TablesLogic
 	Queries      QueriesLogic
 	Transactions TransactionsLogic
+	AutoScaling  AutoScalingLogic
 }
 
 func NewDynamoDB(config goaws.AwsConfig, tables []*Table, failConfig *FailConfig) *DynamoDB {
@@ -31,10 +33,16 @@ func NewDynamoDB(config goaws.AwsConfig, tables []*Table, failConfig *FailConfig
 	svc := dynamodb.New(dynamodb.Options{
 		Region:      config.Config.Region,
 		Credentials: config.Config.Credentials,
+		HTTPClient:  config.Config.HTTPClient,
 	})
 	return &DynamoDB{
 		Queries:      NewQueries(svc, tm, failConfig),
 		Tables:       NewTables(svc, tm),
 		Transactions: NewTransactions(svc, failConfig),
+		AutoScaling: NewAutoScaling(applicationautoscaling.New(applicationautoscaling.Options{
+			Region:      config.Config.Region,
+			Credentials: config.Config.Credentials,
+			HTTPClient:  config.Config.HTTPClient,
+		})),
 	}
 }
@@ -4,9 +4,36 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 )
 
+// Sentinel errors matched by the corresponding error types via errors.Is.
+var (
+	ErrTableNotFound          = errors.New("table not found")
+	ErrNilModel               = errors.New("input model is nil")
+	ErrConditionCheckFailed   = errors.New("condition check failed")
+	ErrRateLimitExceeded      = errors.New("rate limit exceeded")
+	ErrResourceNotFound       = errors.New("resource not found")
+	ErrCollectionSizeExceeded = errors.New("collection size exceeded")
+	ErrReferenceObjectsCount  = errors.New("number of reference objects does not match number of queries")
+	ErrResourceInUse          = errors.New("resource in use")
+	ErrMaxRetriesExceeded     = goaws.ErrMaxRetriesExceeded
+	ErrUnprocessedItems       = errors.New("unprocessed items")
+	ErrDeadlineExceeded       = goaws.ErrDeadlineExceeded
+	ErrItemTooLarge           = errors.New("item too large")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrBadTxRequest           = errors.New("bad transaction request")
+	ErrTxConditionCheckFailed = errors.New("transaction condition check failed")
+	ErrTxThrottled            = errors.New("transaction throttled")
+	ErrInvalidRequestType     = errors.New("invalid request type")
+	ErrTxConflict             = errors.New("transaction conflict")
+	ErrTxInProgress           = errors.New("transaction in progress")
+	ErrTxItemsExceedsLimit    = errors.New("transaction items exceeds limit of 25")
+	ErrInvalidKeyType         = errors.New("invalid key type")
+	ErrDuplicateCreate        = errors.New("duplicate create")
+)
+
 type TableNotFoundError struct {
 	*goaws.ClientErr
 }
@@ -15,6 +42,10 @@ func NewTableNotFoundError(tableName string) *TableNotFoundError {
 	return &TableNotFoundError{goaws.NewClientError(fmt.Errorf("table not found: %s", tableName))}
 }
 
+func (e *TableNotFoundError) Is(target error) bool {
+	return target == ErrTableNotFound || target == goaws.ErrNotFound
+}
+
 type NilModelError struct {
 	*goaws.ClientErr
 }
@@ -23,12 +54,24 @@ func NewNilModelError() *NilModelError {
 	return &NilModelError{goaws.NewClientError(errors.New("input model is nil"))}
 }
 
+func (e *NilModelError) Is(target error) bool {
+	return target == ErrNilModel
+}
+
+// ConditionCheckFailedError is returned when a conditional write's condition fails.
+// Item holds the item's current attributes if the write was made by a Queries
+// configured with WithReturnValuesOnConditionCheckFailure, and is nil otherwise.
 type ConditionCheckFailedError struct {
 	*goaws.ClientErr
+	Item map[string]types.AttributeValue
 }
 
 func NewConditionCheckFailedError(msg string) *ConditionCheckFailedError {
-	return &ConditionCheckFailedError{goaws.NewClientError(fmt.Errorf("condition check failed: %s", msg))}
+	return &ConditionCheckFailedError{ClientErr: goaws.NewClientError(fmt.Errorf("condition check failed: %s", msg))}
+}
+
+func (e *ConditionCheckFailedError) Is(target error) bool {
+	return target == ErrConditionCheckFailed
 }
 
 type RateLimitExceededError struct {
@@ -39,6 +82,10 @@ func NewRateLimitExceededError() *RateLimitExceededError {
 	return &RateLimitExceededError{goaws.NewRetryableClientError(errors.New("rate limit exceeded"))}
 }
 
+func (e *RateLimitExceededError) Is(target error) bool {
+	return target == ErrRateLimitExceeded
+}
+
 type ResourceNotFoundError struct {
 	*goaws.ClientErr
 }
@@ -47,6 +94,10 @@ func NewResourceNotFoundError(resource string) *ResourceNotFoundError {
 	return &ResourceNotFoundError{goaws.NewClientError(fmt.Errorf("resource not found: %s", resource))}
 }
 
+func (e *ResourceNotFoundError) Is(target error) bool {
+	return target == ErrResourceNotFound || target == goaws.ErrNotFound
+}
+
 type CollectionSizeExceededError struct {
 	*goaws.ClientErr
 }
@@ -55,6 +106,10 @@ func NewCollectionSizeExceededError(size int) *CollectionSizeExceededError {
 	return &CollectionSizeExceededError{goaws.NewClientError(fmt.Errorf("collection size exceeded: %d", size))}
 }
 
+func (e *CollectionSizeExceededError) Is(target error) bool {
+	return target == ErrCollectionSizeExceeded
+}
+
 type ReferenceObjectsCountError struct {
 	*goaws.ClientErr
 }
@@ -63,6 +118,10 @@ func NewReferenceObjectsCountError() *ReferenceObjectsCountError {
 	return &ReferenceObjectsCountError{goaws.NewClientError(errors.New("number of reference objects does not match number of queries"))}
 }
 
+func (e *ReferenceObjectsCountError) Is(target error) bool {
+	return target == ErrReferenceObjectsCount
+}
+
 type ResourceInUseError struct {
 	*goaws.RetryableClientError
 }
@@ -71,12 +130,41 @@ func NewResourceInUseError(resource string) *ResourceInUseError {
 	return &ResourceInUseError{goaws.NewRetryableClientError(fmt.Errorf("resource in use: %s", resource))}
 }
 
-type MaxRetriesExceededError struct {
-	*goaws.ClientErr
+func (e *ResourceInUseError) Is(target error) bool {
+	return target == ErrResourceInUse
 }
 
+type MaxRetriesExceededError = goaws.MaxRetriesExceededError
+
 func NewMaxRetriesExceededError() *MaxRetriesExceededError {
-	return &MaxRetriesExceededError{goaws.NewClientError(errors.New("max retries exceeded"))}
+	return goaws.NewMaxRetriesExceededError()
+}
+
+// UnprocessedItemsError is returned by BatchWriteCreate and BatchWrite when the retry
+// budget is exhausted before every item was written. Items holds the marshaled items that
+// were not processed so callers can persist them elsewhere (e.g. a DLQ), and Keys holds
+// the keys of the BatchWrite deletes that were not processed.
+type UnprocessedItemsError struct {
+	*goaws.ClientErr
+	Items []map[string]types.AttributeValue
+	Keys  []map[string]types.AttributeValue
+}
+
+func NewUnprocessedItemsError(items []map[string]types.AttributeValue) *UnprocessedItemsError {
+	return newUnprocessedWritesError(items, nil)
+}
+
+// newUnprocessedWritesError returns an UnprocessedItemsError for unprocessed puts and deletes.
+func newUnprocessedWritesError(items, keys []map[string]types.AttributeValue) *UnprocessedItemsError {
+	return &UnprocessedItemsError{
+		ClientErr: goaws.NewClientError(fmt.Errorf("max retries exceeded: %d unprocessed items", len(items)+len(keys))),
+		Items:     items,
+		Keys:      keys,
+	}
+}
+
+func (e *UnprocessedItemsError) Is(target error) bool {
+	return target == ErrUnprocessedItems || target == ErrMaxRetriesExceeded
 }
 
 type BadTxRequestError struct {
@@ -87,6 +175,10 @@ func NewBadTxRequestError() *BadTxRequestError {
 	return &BadTxRequestError{goaws.NewClientError(errors.New("bad transaction request"))}
 }
 
+func (e *BadTxRequestError) Is(target error) bool {
+	return target == ErrBadTxRequest
+}
+
 type TxConditonCheckFailedError struct {
 	*goaws.ClientErr
 }
@@ -95,6 +187,10 @@ func NewTxConditonCheckFailedError(msg string) *TxConditonCheckFailedError {
 	return &TxConditonCheckFailedError{goaws.NewClientError(fmt.Errorf("transaction condition check failed: %s", msg))}
 }
 
+func (e *TxConditonCheckFailedError) Is(target error) bool {
+	return target == ErrTxConditionCheckFailed
+}
+
 type TxThrottledError struct {
 	*goaws.RetryableClientError
 }
@@ -103,6 +199,10 @@ func NewTxThrottledError() *TxThrottledError {
 	return &TxThrottledError{goaws.NewRetryableClientError(errors.New("transaction throttled"))}
 }
 
+func (e *TxThrottledError) Is(target error) bool {
+	return target == ErrTxThrottled
+}
+
 type InvalidRequestTypeError struct {
 	*goaws.ClientErr
 }
@@ -111,6 +211,10 @@ func NewInvalidRequestTypeError() *InvalidRequestTypeError {
 	return &InvalidRequestTypeError{goaws.NewClientError(errors.New("invalid request type"))}
 }
 
+func (e *InvalidRequestTypeError) Is(target error) bool {
+	return target == ErrInvalidRequestType
+}
+
 type TxConflictError struct {
 	*goaws.RetryableClientError
 }
@@ -119,6 +223,10 @@ func NewTxConflictError() *TxConflictError {
 	return &TxConflictError{goaws.NewRetryableClientError(errors.New("transaction conflict"))}
 }
 
+func (e *TxConflictError) Is(target error) bool {
+	return target == ErrTxConflict
+}
+
 type TxInProgressError struct {
 	*goaws.ClientErr
 }
@@ -127,6 +235,10 @@ func NewTxInProgressError() *TxInProgressError {
 	return &TxInProgressError{goaws.NewClientError(errors.New("transaction in progress"))}
 }
 
+func (e *TxInProgressError) Is(target error) bool {
+	return target == ErrTxInProgress
+}
+
 type TxItemsExceedsLimitError struct {
 	*goaws.ClientErr
 }
@@ -134,3 +246,73 @@ type TxItemsExceedsLimitError struct {
 func NewTxItemsExceedsLimitError() *TxItemsExceedsLimitError {
 	return &TxItemsExceedsLimitError{goaws.NewClientError(errors.New("transaction items exceeds limit of 25"))}
 }
+
+func (e *TxItemsExceedsLimitError) Is(target error) bool {
+	return target == ErrTxItemsExceedsLimit
+}
+
+// DeadlineExceededError is returned by the batch methods when the context deadline
+// would pass before the next retry. It also matches context.DeadlineExceeded.
+type DeadlineExceededError = goaws.DeadlineExceededError
+
+func NewDeadlineExceededError() *DeadlineExceededError {
+	return goaws.NewDeadlineExceededError()
+}
+
+// ItemTooLargeError is returned when a marshaled item exceeds MaxItemSize.
+// Size is the measured size of the item in bytes.
+type ItemTooLargeError struct {
+	*goaws.ClientErr
+	Size int
+}
+
+func NewItemTooLargeError(size int) *ItemTooLargeError {
+	return &ItemTooLargeError{
+		ClientErr: goaws.NewClientError(fmt.Errorf("item size %d bytes exceeds limit of %d bytes", size, MaxItemSize)),
+		Size:      size,
+	}
+}
+
+func (e *ItemTooLargeError) Is(target error) bool {
+	return target == ErrItemTooLarge
+}
+
+type InvalidCursorError struct {
+	*goaws.ClientErr
+}
+
+func NewInvalidCursorError(err error) *InvalidCursorError {
+	return &InvalidCursorError{goaws.NewClientError(fmt.Errorf("invalid cursor: %w", err))}
+}
+
+func (e *InvalidCursorError) Is(target error) bool {
+	return target == ErrInvalidCursor
+}
+
+// InvalidKeyTypeError is returned when a Query's key value isn't a string,
+// number or []byte, the only types DynamoDB accepts for key attributes.
+type InvalidKeyTypeError struct {
+	*goaws.ClientErr
+}
+
+func NewInvalidKeyTypeError(attr string, val any) *InvalidKeyTypeError {
+	return &InvalidKeyTypeError{goaws.NewClientError(fmt.Errorf("invalid key type %T for key attribute '%s': must be string, number or []byte", val, attr))}
+}
+
+func (e *InvalidKeyTypeError) Is(target error) bool {
+	return target == ErrInvalidKeyType
+}
+
+// DuplicateCreateError is returned by CreateItemWithParams when CreateItemParams.FailOnDuplicate
+// is set and the item was already created with the same idempotency token.
+type DuplicateCreateError struct {
+	*goaws.ClientErr
+}
+
+func NewDuplicateCreateError(token string) *DuplicateCreateError {
+	return &DuplicateCreateError{goaws.NewClientError(fmt.Errorf("item already created with idempotency token: %s", token))}
+}
+
+func (e *DuplicateCreateError) Is(target error) bool {
+	return target == ErrDuplicateCreate
+}
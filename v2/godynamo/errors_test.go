@@ -0,0 +1,53 @@
+package godynamo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		sentinel error
+		notFound bool
+	}{
+		{name: "table not found", err: NewTableNotFoundError("test"), sentinel: ErrTableNotFound, notFound: true},
+		{name: "nil model", err: NewNilModelError(), sentinel: ErrNilModel},
+		{name: "condition check failed", err: NewConditionCheckFailedError("test"), sentinel: ErrConditionCheckFailed},
+		{name: "rate limit exceeded", err: NewRateLimitExceededError(), sentinel: ErrRateLimitExceeded},
+		{name: "resource not found", err: NewResourceNotFoundError("test"), sentinel: ErrResourceNotFound, notFound: true},
+		{name: "collection size exceeded", err: NewCollectionSizeExceededError(26), sentinel: ErrCollectionSizeExceeded},
+		{name: "reference objects count", err: NewReferenceObjectsCountError(), sentinel: ErrReferenceObjectsCount},
+		{name: "resource in use", err: NewResourceInUseError("test"), sentinel: ErrResourceInUse},
+		{name: "max retries exceeded", err: NewMaxRetriesExceededError(), sentinel: ErrMaxRetriesExceeded},
+		{name: "unprocessed items", err: NewUnprocessedItemsError(nil), sentinel: ErrUnprocessedItems},
+		{name: "deadline exceeded", err: NewDeadlineExceededError(), sentinel: ErrDeadlineExceeded},
+		{name: "item too large", err: NewItemTooLargeError(MaxItemSize + 1), sentinel: ErrItemTooLarge},
+		{name: "invalid cursor", err: NewInvalidCursorError(errors.New("test")), sentinel: ErrInvalidCursor},
+		{name: "invalid key type", err: NewInvalidKeyTypeError("id", true), sentinel: ErrInvalidKeyType},
+		{name: "duplicate create", err: NewDuplicateCreateError("token-1"), sentinel: ErrDuplicateCreate},
+		{name: "bad tx request", err: NewBadTxRequestError(), sentinel: ErrBadTxRequest},
+		{name: "tx condition check failed", err: NewTxConditonCheckFailedError("test"), sentinel: ErrTxConditionCheckFailed},
+		{name: "tx throttled", err: NewTxThrottledError(), sentinel: ErrTxThrottled},
+		{name: "invalid request type", err: NewInvalidRequestTypeError(), sentinel: ErrInvalidRequestType},
+		{name: "tx conflict", err: NewTxConflictError(), sentinel: ErrTxConflict},
+		{name: "tx in progress", err: NewTxInProgressError(), sentinel: ErrTxInProgress},
+		{name: "tx items exceeds limit", err: NewTxItemsExceedsLimitError(), sentinel: ErrTxItemsExceedsLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.Equal(t, tt.notFound, errors.Is(wrapped, goaws.ErrNotFound))
+			assert.NotErrorIs(t, wrapped, errors.New(tt.sentinel.Error()))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package godynamo
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -10,42 +12,65 @@ import (
 /* Expression wrapper type & methods */
 
 // Expression wraps the AWS expression.Expression object.
+// A nil or zero-value Expression is treated as no expression:
+// each of its methods returns nil.
 type Expression struct {
 	Expression expression.Expression `json:"expression"`
 }
 
 // Condition returns the Condition expression.
 func (e *Expression) Condition() *string {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.Condition()
 }
 
 // Condition returns the Condition expression.
 func (e *Expression) Filter() *string {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.Filter()
 }
 
 // Condition returns the KeyCondition expression.
 func (e *Expression) KeyCondition() *string {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.KeyCondition()
 }
 
 // Condition returns the expression's Names.
 func (e *Expression) Names() map[string]string {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.Names()
 }
 
 // Condition returns the Projection expression.
 func (e *Expression) Projection() *string {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.Projection()
 }
 
 // Condition returns the Update expression.
 func (e *Expression) Update() *string {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.Update()
 }
 
 // Condition returns the expression's Attribute Values.
 func (e *Expression) Values() map[string]types.AttributeValue {
+	if e == nil {
+		return nil
+	}
 	return e.Expression.Values()
 }
 
@@ -86,6 +111,60 @@ func (e *ExprBuilder) SetProjection(names []string) {
 	e.Projection = &proj
 }
 
+// ProjectionFromStruct returns the attribute names of v's fields for use with
+// SetProjection, as the default encoder names them: from dynamodbav tags, falling
+// back to the field name. Fields tagged "-" and unexported fields are skipped, and the
+// fields of embedded structs without a name tag are included as attributevalue flattens
+// them. v may be a struct or a pointer to one; other types return nil.
+// ex: eb.SetProjection(ProjectionFromStruct(User{}))
+func ProjectionFromStruct(v any) []string {
+	return ProjectionFromStructTag(v, "dynamodbav")
+}
+
+// ProjectionFromStructTag is like ProjectionFromStruct, but reads the attribute names
+// from the given tag key, e.g. "json" for Queries using EncodeJSONTags.
+// ex: eb.SetProjection(ProjectionFromStructTag(User{}, "json"))
+func ProjectionFromStructTag(v any, tagKey string) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return structFieldNames(t, tagKey)
+}
+
+// structFieldNames returns the attribute names of the fields of struct type t,
+// read from the given tag key.
+func structFieldNames(t reflect.Type, tagKey string) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get(tagKey), ",")
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			names = append(names, structFieldNames(ft, tagKey)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
 // SetUpdate sets the Update field with a predefined UpdateExpr object.
 func (e *ExprBuilder) SetUpdate(update UpdateExpr) {
 	e.Update = &update.Update
@@ -159,6 +238,22 @@ func (u *UpdateExpr) Set(name string, value any) {
 	u.Update = update
 }
 
+// SetPath sets the value for the attribute at the given name path with no conditions.
+// Use SetPath with expression.Name(...).AppendName(...) to build nested map paths explicitly.
+func (u *UpdateExpr) SetPath(path expression.NameBuilder, value any) {
+	update := u.Update.Set(path, expression.Value(value))
+	u.Update = update
+}
+
+// SetLiteral sets the value for the given field name with no conditions.
+// Unlike Set, dots in the name are not treated as nested map paths.
+//
+//	Ex: SetLiteral("a.b", 1) sets the top-level attribute "a.b"
+func (u *UpdateExpr) SetLiteral(name string, value any) {
+	update := u.Update.Set(expression.NameNoDotSplit(name), expression.Value(value))
+	u.Update = update
+}
+
 // SetIfNotExists sets a new field + value conditionally, if the given field name does not exist.
 func (u *UpdateExpr) SetIfNotExists(name string, value any) {
 	update := u.Update.Set(expression.Name(name), expression.IfNotExists(expression.Name(name), expression.Value(value)))
@@ -201,11 +296,50 @@ func (u *UpdateExpr) SetListAppend(name string, list any) {
 	u.Update = update
 }
 
+// SetListIndex sets the element at the given index of the list at the given field name.
+//
+//	Ex: 'SET #name[2] = :value'
+func (u *UpdateExpr) SetListIndex(name string, index int, value any) {
+	update := u.Update.Set(listIndex(name, index), expression.Value(value))
+	u.Update = update
+}
+
+// RemoveListIndex removes the element at the given index of the list at the given field name.
+// Remaining elements are shifted down to fill the gap.
+//
+//	Ex: 'REMOVE #name[2]'
+func (u *UpdateExpr) RemoveListIndex(name string, index int) {
+	update := u.Update.Remove(listIndex(name, index))
+	u.Update = update
+}
+
+// AddToSet adds the given values to the string set at the given field name,
+// creating the set if it does not exist.
+//
+//	Ex: 'ADD #name :values'
+func (u *UpdateExpr) AddToSet(name string, values []string) {
+	update := u.Update.Add(expression.Name(name), expression.Value(types.AttributeValueMemberSS{Value: values}))
+	u.Update = update
+}
+
+// DeleteFromSet removes the given values from the string set at the given field name.
+//
+//	Ex: 'DELETE #name :values'
+func (u *UpdateExpr) DeleteFromSet(name string, values []string) {
+	update := u.Update.Delete(expression.Name(name), expression.Value(types.AttributeValueMemberSS{Value: values}))
+	u.Update = update
+}
+
 // Reset clears the Update expression.
 func (u *UpdateExpr) Reset() {
 	u.Update = expression.UpdateBuilder{}
 }
 
+// listIndex returns the name path for the element at the given index of a list attribute.
+func listIndex(name string, index int) expression.NameBuilder {
+	return expression.Name(fmt.Sprintf("%s[%d]", name, index))
+}
+
 /* Object Constructors */
 // NewExpression constructs a new Expression object.
 func NewExpression() Expression {
@@ -244,7 +378,7 @@ type KeyConditions struct {
 	KeyCondition *expression.KeyConditionBuilder
 }
 
-// And creates an AND boolean condition.
+// BeginsWith adds a begins_with condition on the given sort key, ANDed with any existing key conditions.
 func (c *KeyConditions) BeginsWith(name string, prefix string) *KeyConditions {
 	condition := expression.Key(name).BeginsWith(prefix)
 	if c.KeyCondition != nil {
@@ -257,6 +391,7 @@ func (c *KeyConditions) BeginsWith(name string, prefix string) *KeyConditions {
 	return c
 }
 
+// Between adds a BETWEEN condition on the given sort key, ANDed with any existing key conditions.
 func (c *KeyConditions) Between(name string, lower, upper any) *KeyConditions {
 	condition := expression.Key(name).Between(expression.Value(lower), expression.Value(upper))
 	if c.KeyCondition != nil {
@@ -269,6 +404,7 @@ func (c *KeyConditions) Between(name string, lower, upper any) *KeyConditions {
 	return c
 }
 
+// Equal adds an equality condition on the given key, ANDed with any existing key conditions.
 func (c *KeyConditions) Equal(name string, value any) *KeyConditions {
 	condition := expression.Key(name).Equal(expression.Value(value))
 	if c.KeyCondition != nil {
@@ -281,6 +417,7 @@ func (c *KeyConditions) Equal(name string, value any) *KeyConditions {
 	return c
 }
 
+// GreaterThan adds a > condition on the given sort key, ANDed with any existing key conditions.
 func (c *KeyConditions) GreaterThan(name string, value any) *KeyConditions {
 	condition := expression.Key(name).GreaterThan(expression.Value(value))
 	if c.KeyCondition != nil {
@@ -293,6 +430,7 @@ func (c *KeyConditions) GreaterThan(name string, value any) *KeyConditions {
 	return c
 }
 
+// GreaterThanEqual adds a >= condition on the given sort key, ANDed with any existing key conditions.
 func (c *KeyConditions) GreaterThanEqual(name string, value any) *KeyConditions {
 	condition := expression.Key(name).GreaterThanEqual(expression.Value(value))
 	if c.KeyCondition != nil {
@@ -305,6 +443,7 @@ func (c *KeyConditions) GreaterThanEqual(name string, value any) *KeyConditions
 	return c
 }
 
+// LessThan adds a < condition on the given sort key, ANDed with any existing key conditions.
 func (c *KeyConditions) LessThan(name string, value any) *KeyConditions {
 	condition := expression.Key(name).LessThan(expression.Value(value))
 	if c.KeyCondition != nil {
@@ -317,6 +456,7 @@ func (c *KeyConditions) LessThan(name string, value any) *KeyConditions {
 	return c
 }
 
+// LessThanEqual adds a <= condition on the given sort key, ANDed with any existing key conditions.
 func (c *KeyConditions) LessThanEqual(name string, value any) *KeyConditions {
 	condition := expression.Key(name).LessThanEqual(expression.Value(value))
 	if c.KeyCondition != nil {
@@ -1,7 +1,11 @@
 package godynamo
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 const TABLE = "go-dynamo-test"
@@ -17,6 +21,14 @@ func TestNewExpression(t *testing.T) {
 	t.Logf("SUCCESS")
 }
 
+func TestNilExpression(t *testing.T) {
+	var e *Expression
+	if e.Condition() != nil || e.Filter() != nil || e.KeyCondition() != nil ||
+		e.Projection() != nil || e.Update() != nil || e.Names() != nil || e.Values() != nil {
+		t.Errorf("FAIL - nil Expression returned non-nil value")
+	}
+}
+
 func TestExpressionBuild(t *testing.T) {
 	var tests = []struct {
 		fieldname string
@@ -105,3 +117,201 @@ func TestKeyCondition(t *testing.T) {
 	}
 
 }
+
+func TestUpdateExprSets(t *testing.T) {
+	var tests = []struct {
+		op     string
+		name   string
+		values []string
+		want   string
+	}{
+		{op: "add", name: "tags", values: []string{"a", "b"}, want: "ADD #0 :0\n"},
+		{op: "delete", name: "tags", values: []string{"c"}, want: "DELETE #0 :0\n"},
+	}
+	for _, test := range tests {
+		ud := NewUpdateExpr()
+		switch test.op {
+		case "add":
+			ud.AddToSet(test.name, test.values)
+		case "delete":
+			ud.DeleteFromSet(test.name, test.values)
+		}
+
+		eb := NewExprBuilder()
+		eb.SetUpdate(ud)
+		expr, err := eb.BuildExpression()
+		if err != nil {
+			t.Errorf("FAIL %v", err)
+			return
+		}
+
+		if res := *expr.Update(); res != test.want {
+			t.Errorf("FAIL - got: %q; want: %q", res, test.want)
+		}
+		if expr.Names()["#0"] != test.name {
+			t.Errorf("FAIL - got name: %s; want: %s", expr.Names()["#0"], test.name)
+		}
+		ss, ok := expr.Values()[":0"].(*types.AttributeValueMemberSS)
+		if !ok {
+			t.Errorf("FAIL - got value type %T; want string set", expr.Values()[":0"])
+			continue
+		}
+		if !reflect.DeepEqual(ss.Value, test.values) {
+			t.Errorf("FAIL - got values: %v; want: %v", ss.Value, test.values)
+		}
+	}
+}
+
+func TestUpdateExprPaths(t *testing.T) {
+	var tests = []struct {
+		name      string
+		update    func(u *UpdateExpr)
+		want      string
+		wantNames map[string]string
+	}{
+		{
+			name:      "nested",
+			update:    func(u *UpdateExpr) { u.Set("a.b", 1) },
+			want:      "SET #0.#1 = :0\n",
+			wantNames: map[string]string{"#0": "a", "#1": "b"},
+		},
+		{
+			name:      "nested path",
+			update:    func(u *UpdateExpr) { u.SetPath(expression.Name("a").AppendName(expression.Name("b")), 1) },
+			want:      "SET #0.#1 = :0\n",
+			wantNames: map[string]string{"#0": "a", "#1": "b"},
+		},
+		{
+			name:      "literal dot",
+			update:    func(u *UpdateExpr) { u.SetLiteral("a.b", 1) },
+			want:      "SET #0 = :0\n",
+			wantNames: map[string]string{"#0": "a.b"},
+		},
+		{
+			name:      "set list index",
+			update:    func(u *UpdateExpr) { u.SetListIndex("items", 2, "x") },
+			want:      "SET #0[2] = :0\n",
+			wantNames: map[string]string{"#0": "items"},
+		},
+		{
+			name:      "remove list index",
+			update:    func(u *UpdateExpr) { u.RemoveListIndex("items", 2) },
+			want:      "REMOVE #0[2]\n",
+			wantNames: map[string]string{"#0": "items"},
+		},
+	}
+	for _, test := range tests {
+		ud := NewUpdateExpr()
+		test.update(&ud)
+
+		eb := NewExprBuilder()
+		eb.SetUpdate(ud)
+		expr, err := eb.BuildExpression()
+		if err != nil {
+			t.Errorf("FAIL %s: %v", test.name, err)
+			return
+		}
+
+		if res := *expr.Update(); res != test.want {
+			t.Errorf("FAIL %s - got: %q; want: %q", test.name, res, test.want)
+		}
+		if !reflect.DeepEqual(expr.Names(), test.wantNames) {
+			t.Errorf("FAIL %s - got names: %v; want: %v", test.name, expr.Names(), test.wantNames)
+		}
+	}
+}
+
+func TestProjectionFromStruct(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `dynamodbav:"created_by"`
+		UpdatedBy string `json:"updated_by"`
+	}
+	type User struct {
+		ID       string `dynamodbav:"id" json:"user_id"`
+		Email    string `json:"email,omitempty"`
+		Name     string
+		Password string `dynamodbav:"-"`
+		Token    string `json:"-"`
+		Renamed  string `dynamodbav:",omitempty" json:"ignored"`
+		internal string
+		Audit
+		Owner *Audit `dynamodbav:"owner"`
+	}
+
+	var tests = []struct {
+		name   string
+		v      any
+		tagKey string
+		want   []string
+	}{
+		{
+			name: "struct",
+			v:    User{internal: "secret"},
+			want: []string{"id", "Email", "Name", "Token", "Renamed", "created_by", "UpdatedBy", "owner"},
+		},
+		{
+			name:   "json tags",
+			v:      User{},
+			tagKey: "json",
+			want:   []string{"user_id", "email", "Name", "Password", "ignored", "CreatedBy", "updated_by", "Owner"},
+		},
+		{name: "pointer", v: &Audit{}, want: []string{"created_by", "UpdatedBy"}},
+		{name: "non-struct", v: "id", want: nil},
+		{name: "nil", v: nil, want: nil},
+	}
+	for _, test := range tests {
+		res := ProjectionFromStruct(test.v)
+		if test.tagKey != "" {
+			res = ProjectionFromStructTag(test.v, test.tagKey)
+		}
+		if !reflect.DeepEqual(res, test.want) {
+			t.Errorf("FAIL %s - got: %v; want: %v", test.name, res, test.want)
+		}
+	}
+
+	eb := NewExprBuilder()
+	eb.SetProjection(ProjectionFromStruct(Audit{}))
+	expr, err := eb.BuildExpression()
+	if err != nil {
+		t.Errorf("FAIL %v", err)
+		return
+	}
+	if res := *expr.Projection(); res != "#0, #1" {
+		t.Errorf("FAIL - got: %q; want: %q", res, "#0, #1")
+	}
+	if want := map[string]string{"#0": "created_by", "#1": "UpdatedBy"}; !reflect.DeepEqual(expr.Names(), want) {
+		t.Errorf("FAIL - got names: %v; want: %v", expr.Names(), want)
+	}
+}
+
+func TestKeyConditionOperators(t *testing.T) {
+	var tests = []struct {
+		name  string
+		build func(c *KeyConditions)
+		want  string
+	}{
+		{name: "begins_with", build: func(c *KeyConditions) { c.Equal("pk", 1).BeginsWith("sk", "pre") }, want: "(#0 = :0) AND (begins_with (#1, :1))"},
+		{name: "between", build: func(c *KeyConditions) { c.Equal("pk", 1).Between("sk", 2, 5) }, want: "(#0 = :0) AND (#1 BETWEEN :1 AND :2)"},
+		{name: "equal", build: func(c *KeyConditions) { c.Equal("pk", 1) }, want: "#0 = :0"},
+		{name: "greater_than", build: func(c *KeyConditions) { c.Equal("pk", 1).GreaterThan("sk", 2) }, want: "(#0 = :0) AND (#1 > :1)"},
+		{name: "greater_than_equal", build: func(c *KeyConditions) { c.Equal("pk", 1).GreaterThanEqual("sk", 2) }, want: "(#0 = :0) AND (#1 >= :1)"},
+		{name: "less_than", build: func(c *KeyConditions) { c.Equal("pk", 1).LessThan("sk", 2) }, want: "(#0 = :0) AND (#1 < :1)"},
+		{name: "less_than_equal", build: func(c *KeyConditions) { c.Equal("pk", 1).LessThanEqual("sk", 2) }, want: "(#0 = :0) AND (#1 <= :1)"},
+	}
+	for _, test := range tests {
+		cond := NewKeyCondition()
+		test.build(&cond)
+
+		eb := NewExprBuilder()
+		eb.SetKeyCondition(cond)
+		expr, err := eb.BuildExpression()
+		if err != nil {
+			t.Errorf("FAIL %s: %v", test.name, err)
+			continue
+		}
+
+		if res := *expr.KeyCondition(); res != test.want {
+			t.Errorf("FAIL %s - got: %s; want: %s", test.name, res, test.want)
+		}
+	}
+}
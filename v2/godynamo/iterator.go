@@ -0,0 +1,137 @@
+package godynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+)
+
+// QueryIterator iterates over the items matching a query, reading pages from DynamoDB
+// as they are needed so only one page is held in memory at a time.
+//
+//	it := q.QueryIterator(params)
+//	for it.Next(ctx) {
+//		var item Item
+//		if err := it.Scan(&item); err != nil {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type QueryIterator struct {
+	q     *Queries
+	input *dynamodb.QueryInput
+	page  []map[string]types.AttributeValue
+	pos   int
+	done  bool
+	err   error
+}
+
+// QueryIterator returns a QueryIterator over the items in the given Table matching the
+// given expression parameters. params.PerPage sets the number of items read per page.
+func (q *Queries) QueryIterator(params QueryItemsParams) *QueryIterator {
+	it := &QueryIterator{q: q, pos: -1}
+
+	t := q.getTable(params.TableName)
+	if t == nil {
+		it.err = NewTableNotFoundError(params.TableName)
+		return it
+	}
+	it.input, it.err = q.queryInput(t, params)
+	return it
+}
+
+// Next advances the iterator to the next item, reading the next page if the current
+// page is exhausted. It returns false when there are no more items or an error occurs.
+func (it *QueryIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.pos++
+	// pages may be empty when the filter expression discards every item
+	for it.pos >= len(it.page) {
+		if it.done {
+			return false
+		}
+
+		result, err := it.q.svc.Query(ctx, it.input)
+		if err != nil {
+			it.err = handleErr(fmt.Errorf("q.svc.Query: %w", err))
+			return false
+		}
+
+		it.page = result.Items
+		it.pos = 0
+		if len(result.LastEvaluatedKey) == 0 {
+			it.done = true
+		}
+		it.input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	return true
+}
+
+// Scan unmarshals the current item into out, which must be a non-nil pointer.
+func (it *QueryIterator) Scan(out any) error {
+	if it.pos < 0 || it.pos >= len(it.page) {
+		return goaws.NewClientError(errors.New("no current item: Next must return true before Scan"))
+	}
+	if err := attributevalue.UnmarshalMapWithOptions(it.page[it.pos], out, it.q.decoderOpts...); err != nil {
+		return goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+	}
+	return nil
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *QueryIterator) Err() error {
+	return it.err
+}
+
+// ScanEach scans the given Table for items matching the given expression parameters,
+// reading every page and calling fn with each item in turn. params.PerPage sets the
+// number of items read per page. Scanning stops at the first error returned by fn,
+// which is returned as is.
+//
+//	err := q.ScanEach(ctx, params, func(row QueryRow) error {
+//		return export(row)
+//	})
+func (q *Queries) ScanEach(ctx context.Context, params QueryItemsParams, fn func(row QueryRow) error) error {
+	t := q.getTable(params.TableName)
+	if t == nil {
+		return NewTableNotFoundError(params.TableName)
+	}
+
+	input, err := q.scanInput(t, params)
+	if err != nil {
+		return err
+	}
+
+	for {
+		result, err := q.svc.Scan(ctx, input)
+		if err != nil {
+			return handleErr(fmt.Errorf("q.svc.Scan: %w", err))
+		}
+
+		for _, res := range result.Items {
+			row := QueryRow{}
+			if err := attributevalue.UnmarshalMapWithOptions(res, &row, q.decoderOpts...); err != nil {
+				return goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,254 @@
+package godynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestQueries_QueryIterator(t *testing.T) {
+	type item struct {
+		ID string `dynamodbav:"id"`
+	}
+	row := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	tests := []struct {
+		name          string
+		tableName     string
+		mockSetup     func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:      "TwoPages",
+			tableName: "test-table",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							assert.Nil(t, in.ExclusiveStartKey)
+							return &dynamodb.QueryOutput{
+								Items:            []map[string]types.AttributeValue{row("1"), row("2")},
+								LastEvaluatedKey: row("2"),
+							}, nil
+						}).Times(1),
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							assert.Equal(t, row("2"), in.ExclusiveStartKey)
+							return &dynamodb.QueryOutput{
+								Items: []map[string]types.AttributeValue{row("3")},
+							}, nil
+						}).Times(1),
+				)
+			},
+			expectedIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:      "EmptyPage",
+			tableName: "test-table",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+						LastEvaluatedKey: row("2"),
+					}, nil).Times(1),
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+						Items: []map[string]types.AttributeValue{row("3")},
+					}, nil).Times(1),
+				)
+			},
+			expectedIDs: []string{"3"},
+		},
+		{
+			name:      "ErrorOnSecondPage",
+			tableName: "test-table",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+						Items:            []map[string]types.AttributeValue{row("1")},
+						LastEvaluatedKey: row("1"),
+					}, nil).Times(1),
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("query error")).Times(1),
+				)
+			},
+			expectedIDs:   []string{"1"},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.Query: query error")),
+		},
+		{
+			name:          "TableNotFound",
+			tableName:     "missing-table",
+			mockSetup:     func(_ *testing.T, _ *MockDynamoDBQueriesClientAPI) {},
+			expectedIDs:   []string{},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			q := NewQueries(m, tables, nil)
+
+			it := q.QueryIterator(QueryItemsParams{TableName: tt.tableName, Expression: NewExpression()})
+
+			ids := make([]string, 0)
+			for it.Next(context.Background()) {
+				var out item
+				require.NoError(t, it.Scan(&out))
+				ids = append(ids, out.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+
+			// exhausted iterators stay exhausted
+			assert.False(t, it.Next(context.Background()))
+
+			if tt.expectedError != nil {
+				require.Error(t, it.Err())
+				assert.EqualError(t, it.Err(), tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), it.Err())
+			} else {
+				assert.NoError(t, it.Err())
+			}
+		})
+	}
+}
+
+func TestQueryIterator_ScanBeforeNext(t *testing.T) {
+	q := NewQueries(nil, map[string]*Table{"test-table": {TableName: "test-table", PrimaryKeyName: "id"}}, nil)
+	it := q.QueryIterator(QueryItemsParams{TableName: "test-table", Expression: NewExpression()})
+
+	var out map[string]any
+	err := it.Scan(&out)
+	require.Error(t, err)
+	assert.Implements(t, (*goaws.AwsError)(nil), err)
+}
+
+func TestQueries_ScanEach(t *testing.T) {
+	row := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+	errStop := errors.New("stop")
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	twoPages := func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+		gomock.InOrder(
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+					assert.Nil(t, in.ExclusiveStartKey)
+					return &dynamodb.ScanOutput{
+						Items:            []map[string]types.AttributeValue{row("1"), row("2")},
+						LastEvaluatedKey: row("2"),
+					}, nil
+				}).Times(1),
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+					assert.Equal(t, row("2"), in.ExclusiveStartKey)
+					return &dynamodb.ScanOutput{
+						Items: []map[string]types.AttributeValue{row("3"), row("4")},
+					}, nil
+				}).Times(1),
+		)
+	}
+
+	tests := []struct {
+		name          string
+		tableName     string
+		stopAt        string
+		mockSetup     func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:        "TwoPages",
+			tableName:   "test-table",
+			mockSetup:   twoPages,
+			expectedIDs: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:          "StopOnSecondPage",
+			tableName:     "test-table",
+			stopAt:        "3",
+			mockSetup:     twoPages,
+			expectedIDs:   []string{"1", "2", "3"},
+			expectedError: errStop,
+		},
+		{
+			name:      "StopOnFirstPage",
+			tableName: "test-table",
+			stopAt:    "1",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.ScanOutput{
+					Items:            []map[string]types.AttributeValue{row("1"), row("2")},
+					LastEvaluatedKey: row("2"),
+				}, nil).Times(1)
+			},
+			expectedIDs:   []string{"1"},
+			expectedError: errStop,
+		},
+		{
+			name:      "ScanError",
+			tableName: "test-table",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("scan error")).Times(1)
+			},
+			expectedIDs:   []string{},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.Scan: scan error")),
+		},
+		{
+			name:          "TableNotFound",
+			tableName:     "missing-table",
+			mockSetup:     func(_ *testing.T, _ *MockDynamoDBQueriesClientAPI) {},
+			expectedIDs:   []string{},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			q := NewQueries(m, tables, nil)
+
+			ids := make([]string, 0)
+			err := q.ScanEach(context.Background(), QueryItemsParams{TableName: tt.tableName, Expression: NewExpression()}, func(row QueryRow) error {
+				id := row["id"].(string)
+				ids = append(ids, id)
+				if id == tt.stopAt {
+					return errStop
+				}
+				return nil
+			})
+			assert.Equal(t, tt.expectedIDs, ids)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
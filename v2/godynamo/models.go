@@ -1,8 +1,12 @@
 package godynamo
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
@@ -10,12 +14,56 @@ import (
 
 // Table represents a table and holds basic information about it.
 // This object is used to access the Dynamo Table requested for each CRUD op.
+// ProjectionFields optionally lists the top-level attributes returned by GetItem,
+// QueryItems and ScanItems when the caller's Expression has no projection.
+// Indexes optionally maps secondary index names to their key schema; it's required
+// to resume QueryItemsUntilLimit on an index.
 type Table struct {
-	TableName      string
+	TableName        string
+	PrimaryKeyName   string
+	PrimaryKeyType   string
+	SortKeyName      string
+	SortKeyType      string
+	ProjectionFields []string
+	Indexes          map[string]Index
+}
+
+// Index holds the key attribute names of a secondary index of a Table.
+// SortKeyName is empty if the index has no sort key.
+type Index struct {
 	PrimaryKeyName string
-	PrimaryKeyType string
 	SortKeyName    string
-	SortKeyType    string
+}
+
+// Capacity identifies the read or write capacity of a provisioned table or index.
+type Capacity string
+
+const (
+	CapacityRead  Capacity = "read"
+	CapacityWrite Capacity = "write"
+)
+
+// ScalableTarget describes the read or write capacity of a table, or of its global
+// secondary index IndexName if set, to register with Application Auto Scaling.
+type ScalableTarget struct {
+	TableName   string   `json:"table_name"`
+	IndexName   string   `json:"index_name,omitempty"`
+	Capacity    Capacity `json:"capacity"`
+	MinCapacity int32    `json:"min_capacity"`
+	MaxCapacity int32    `json:"max_capacity"`
+}
+
+// ScalingPolicy describes a target tracking scaling policy for a registered ScalableTarget.
+// TargetUtilization is the consumed to provisioned capacity percentage to maintain (20-90).
+// Cooldowns are in seconds; the service defaults are used if zero.
+type ScalingPolicy struct {
+	TableName         string   `json:"table_name"`
+	IndexName         string   `json:"index_name,omitempty"`
+	Capacity          Capacity `json:"capacity"`
+	PolicyName        string   `json:"policy_name,omitempty"`
+	TargetUtilization float64  `json:"target_utilization"`
+	ScaleInCooldown   int32    `json:"scale_in_cooldown,omitempty"`
+	ScaleOutCooldown  int32    `json:"scale_out_cooldown,omitempty"`
 }
 
 type ListTableParams struct {
@@ -23,6 +71,80 @@ type ListTableParams struct {
 	Limit      *int32  `json:"limit"`
 }
 
+// TTLStatus describes a table's time to live setting. Status is one of
+// ENABLING, ENABLED, DISABLING or DISABLED.
+type TTLStatus struct {
+	Status        string `json:"status"`
+	AttributeName string `json:"attribute_name,omitempty"`
+}
+
+// EnabledOn returns true if TTL is enabled on the table with attr as the TTL attribute.
+func (s *TTLStatus) EnabledOn(attr string) bool {
+	return s != nil && s.Status == string(types.TimeToLiveStatusEnabled) && s.AttributeName == attr
+}
+
+func newTTLStatus(desc *types.TimeToLiveDescription) *TTLStatus {
+	if desc == nil {
+		return &TTLStatus{Status: string(types.TimeToLiveStatusDisabled)}
+	}
+	return &TTLStatus{
+		Status:        string(desc.TimeToLiveStatus),
+		AttributeName: aws.ToString(desc.AttributeName),
+	}
+}
+
+// ExportResponse describes a table export to S3 started by ExportToS3.
+type ExportResponse struct {
+	ExportArn      string    `json:"export_arn"`
+	Status         string    `json:"status"`
+	S3Bucket       string    `json:"s3_bucket"`
+	S3Prefix       string    `json:"s3_prefix,omitempty"`
+	ExportManifest string    `json:"export_manifest,omitempty"`
+	ItemCount      int64     `json:"item_count,omitempty"`
+	StartTime      time.Time `json:"start_time,omitempty"`
+	EndTime        time.Time `json:"end_time,omitempty"`
+	FailureMessage string    `json:"failure_message,omitempty"`
+}
+
+func newExportResponse(desc *types.ExportDescription) *ExportResponse {
+	if desc == nil {
+		return &ExportResponse{}
+	}
+	return &ExportResponse{
+		ExportArn:      aws.ToString(desc.ExportArn),
+		Status:         string(desc.ExportStatus),
+		S3Bucket:       aws.ToString(desc.S3Bucket),
+		S3Prefix:       aws.ToString(desc.S3Prefix),
+		ExportManifest: aws.ToString(desc.ExportManifest),
+		ItemCount:      aws.ToInt64(desc.ItemCount),
+		StartTime:      aws.ToTime(desc.StartTime),
+		EndTime:        aws.ToTime(desc.EndTime),
+		FailureMessage: aws.ToString(desc.FailureMessage),
+	}
+}
+
+// DefaultIdempotencyAttribute is the attribute CreateItemWithParams writes the
+// idempotency token to if CreateItemParams.IdempotencyAttribute is empty.
+const DefaultIdempotencyAttribute = "idempotency_token"
+
+// CreateItemParams holds the input for CreateItemWithParams. The item is only
+// written if Expression's condition, when set, holds.
+//
+// If IdempotencyToken is set, it is written to IdempotencyAttribute and the item is
+// only created if no item with the same key exists, so a retried create can't
+// overwrite or duplicate it. If the stored item holds the same token, the create is
+// treated as a retry: the stored item is unmarshaled into ExistingItemPtr, if set,
+// and no error is returned, or DuplicateCreateError is returned if FailOnDuplicate is set.
+type CreateItemParams struct {
+	Item                 any        `json:"item"`
+	TableName            string     `json:"table_name"`
+	Expression           Expression `json:"expression"`
+	IdempotencyToken     string     `json:"idempotency_token,omitempty"`
+	IdempotencyAttribute string     `json:"idempotency_attribute,omitempty"`
+	ExistingItemPtr      any        `json:"existing_item_ptr,omitempty"`
+	FailOnDuplicate      bool       `json:"fail_on_duplicate,omitempty"`
+}
+
 type GetItemParams struct {
 	Query           *Query     `json:"query"`
 	TableName       string     `json:"table_name"`
@@ -31,12 +153,37 @@ type GetItemParams struct {
 	ConsistentReads bool       `json:"consistent_reads"`
 }
 
+// BatchGetParams holds the input for BatchGetWithParams and BatchGetAligned.
+// ConsistentReads requests strongly consistent reads for every key in the batch.
+type BatchGetParams struct {
+	TableName       string     `json:"table_name"`
+	Queries         []*Query   `json:"queries"`
+	Expression      Expression `json:"expression"`
+	ConsistentReads bool       `json:"consistent_reads"`
+}
+
+// BatchGetResult is the result of BatchGetAligned for a single query.
+// Item is nil if Found is unset.
+type BatchGetResult struct {
+	Query *Query   `json:"query"`
+	Item  QueryRow `json:"item,omitempty"`
+	Found bool     `json:"found"`
+}
+
+// QueryItemsParams holds the input for the query and scan methods. StartKey is either
+// a model holding the key attributes of the item to start after, which is marshaled,
+// or the LastKey of a prior result (e.g. from DecodeCursor), which is used as is.
+// IndexName reads from a secondary index instead of the table; without a projection,
+// all of the index's projected attributes are returned. Count returns only the number
+// of matching items in the results' Count field and can't be combined with a projection.
 type QueryItemsParams struct {
 	TableName       string     `json:"table_name"`
+	IndexName       string     `json:"index_name,omitempty"`
 	StartKey        any        `json:"start_key"`
 	Expression      Expression `json:"expression"`
 	PerPage         *int32     `json:"per_page"`
 	ConsistentReads bool       `json:"consistent_reads"`
+	Count           bool       `json:"count,omitempty"`
 }
 
 // CreateNewTableObj creates a new Table struct.
@@ -59,7 +206,7 @@ func CreateNewTableObj(tableName, pKeyName, pType, sKeyName, sType string) *Tabl
 	pt := typeMap[pType]
 	st := typeMap[sType]
 
-	return &Table{tableName, pKeyName, pt, sKeyName, st}
+	return &Table{TableName: tableName, PrimaryKeyName: pKeyName, PrimaryKeyType: pt, SortKeyName: sKeyName, SortKeyType: st}
 }
 
 /* Queries */
@@ -74,18 +221,74 @@ type Query struct {
 	UpdateValue     any
 }
 
+// ConditionalDelete is a single delete request passed to ConditionalDeleteAll.
+// If Conditional is set, the item is only deleted if it meets the condition
+// expression passed to ConditionalDeleteAll. Item is set to the item's current
+// attributes if its condition fails and WithReturnValuesOnConditionCheckFailure is set.
+type ConditionalDelete struct {
+	Query       *Query                          `json:"query"`
+	Conditional bool                            `json:"conditional"`
+	Item        map[string]types.AttributeValue `json:"-"`
+}
+
+// QueryResults holds a page of results. HasMore is true if LastKey is set and
+// more items may remain; pass LastKey as the next call's StartKey to fetch them.
+// Count is the number of items in the page that matched the filter expression.
 type QueryResults struct {
 	Rows    []QueryRow                      `json:"results"`
+	Count   int32                           `json:"count"`
 	PerPage int32                           `json:"per_page,omitempty"`
 	LastKey map[string]types.AttributeValue `json:"last_key,omitempty"`
+	HasMore bool                            `json:"has_more"`
 }
 
 type QueryRow = map[string]any
 
+// WriteMetrics holds the capacity consumed by a write and, for tables with local
+// secondary indexes, the estimated size range in GB of the item collection written to.
+// Item collections are limited to 10GB.
+type WriteMetrics struct {
+	ConsumedCapacityUnits float64                         `json:"consumed_capacity_units"`
+	ItemCollectionKey     map[string]types.AttributeValue `json:"item_collection_key,omitempty"`
+	SizeEstimateRangeGB   []float64                       `json:"size_estimate_range_gb,omitempty"`
+}
+
+func newWriteMetrics(cc *types.ConsumedCapacity, icm *types.ItemCollectionMetrics) *WriteMetrics {
+	m := &WriteMetrics{}
+	if cc != nil {
+		m.ConsumedCapacityUnits = aws.ToFloat64(cc.CapacityUnits)
+	}
+	if icm != nil {
+		m.ItemCollectionKey = icm.ItemCollectionKey
+		m.SizeEstimateRangeGB = icm.SizeEstimateRangeGB
+	}
+	return m
+}
+
+// BatchStatement is a single PartiQL statement and its positional parameters
+// executed as part of a BatchExecuteStatement request.
+type BatchStatement struct {
+	Statement      string `json:"statement"`
+	Params         []any  `json:"params"`
+	ConsistentRead bool   `json:"consistent_read"`
+}
+
+// BatchStatementResult holds the outcome of a single BatchStatement.
+// Item is set for successful reads; Err is set if the statement failed.
+type BatchStatementResult struct {
+	Item QueryRow `json:"item,omitempty"`
+	Err  error    `json:"-"`
+}
+
+// ScanResults holds a page of results. HasMore is true if LastKey is set and
+// more items may remain; pass LastKey as the next call's StartKey to fetch them.
+// Count is the number of items in the page that matched the filter expression.
 type ScanResults struct {
 	Rows    []QueryRow                      `json:"results"`
+	Count   int32                           `json:"count"`
 	PerPage int32                           `json:"per_page,omitempty"`
 	LastKey map[string]types.AttributeValue `json:"last_key,omitempty"`
+	HasMore bool                            `json:"has_more"`
 }
 
 // New creates a new query by setting the Partition Key and Sort Key values.
@@ -112,72 +315,123 @@ func CreateNewQueryObj(pval, sval interface{}) *Query {
 	return &Query{PrimaryValue: pval, SortValue: sval}
 }
 
-func createAV(val any) types.AttributeValue {
-
-	if val == nil { // setNull
-		av := &types.AttributeValueMemberNULL{Value: true}
-		return av
+// keyAV marshals val as the value of the key attribute name. Keys must be strings,
+// numbers or binary; an InvalidKeyTypeError is returned for any other type.
+func keyAV(name string, val any) (types.AttributeValue, error) {
+	switch v := val.(type) {
+	case string:
+		return &types.AttributeValueMemberS{Value: v}, nil
+	case []byte:
+		return &types.AttributeValueMemberB{Value: v}, nil
+	case int:
+		return &types.AttributeValueMemberN{Value: strconv.Itoa(v)}, nil
+	case int8, int16, int32, int64:
+		return &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", v)}, nil
+	case uint, uint8, uint16, uint32, uint64:
+		return &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", v)}, nil
+	case float32:
+		return &types.AttributeValueMemberN{Value: strconv.FormatFloat(float64(v), 'f', -1, 32)}, nil
+	case float64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	default:
+		return nil, NewInvalidKeyTypeError(name, val)
 	}
-	if _, ok := val.([]byte); ok {
-		av := &types.AttributeValueMemberB{Value: val.([]byte)}
-		return av
-	}
-	if _, ok := val.(bool); ok {
-		av := &types.AttributeValueMemberBOOL{Value: val.(bool)}
-		return av
-	}
-	if _, ok := val.([][]byte); ok {
-		av := &types.AttributeValueMemberBS{Value: val.([][]byte)}
-		return av
+}
+
+// projection returns the projection expression and attribute names for expr,
+// applying t.ProjectionFields if expr has no projection of its own.
+func projection(t *Table, expr Expression) (*string, map[string]string) {
+	if expr.Projection() != nil || len(t.ProjectionFields) == 0 {
+		return expr.Projection(), expr.Names()
 	}
-	if _, ok := val.([]types.AttributeValue); ok {
-		av := &types.AttributeValueMemberL{Value: val.([]types.AttributeValue)}
-		return av
+
+	// placeholders can't collide with the "#0", "#1", ... names generated by the expression builder
+	names := make(map[string]string, len(expr.Names())+len(t.ProjectionFields))
+	for k, v := range expr.Names() {
+		names[k] = v
 	}
-	if _, ok := val.(map[string]types.AttributeValue); ok {
-		av := &types.AttributeValueMemberM{Value: val.(map[string]types.AttributeValue)}
-		return av
+	placeholders := make([]string, len(t.ProjectionFields))
+	for i, field := range t.ProjectionFields {
+		placeholders[i] = fmt.Sprintf("#proj%d", i)
+		names[placeholders[i]] = field
 	}
+	return aws.String(strings.Join(placeholders, ", ")), names
+}
 
-	if _, ok := val.(int); ok {
-		av := &types.AttributeValueMemberN{Value: strconv.Itoa(val.(int))}
-		return av
-	}
-	if _, ok := val.([]int); ok {
+// withKeyAttributes appends t's key attributes to the projection proj if it
+// doesn't already include them.
+func withKeyAttributes(t *Table, proj *string, names map[string]string) (*string, map[string]string) {
+	return withAttributes(proj, names, []string{t.PrimaryKeyName, t.SortKeyName})
+}
 
-		ns := func(is []int) []string {
-			list := []string{}
-			for _, n := range is {
-				str := strconv.Itoa(n)
-				list = append(list, str)
-			}
-			return list
-		}(val.([]int))
+// withAttributes appends the given top-level attributes to the projection proj
+// if it doesn't already include them. Empty attribute names are ignored.
+func withAttributes(proj *string, names map[string]string, attrs []string) (*string, map[string]string) {
+	if proj == nil {
+		return proj, names
+	}
 
-		av := &types.AttributeValueMemberNS{Value: ns}
+	projected := make(map[string]bool)
+	for _, path := range strings.Split(aws.ToString(proj), ",") {
+		// only the top-level attribute of a document path can be a key
+		attr := strings.TrimSpace(path)
+		if i := strings.IndexAny(attr, ".["); i >= 0 {
+			attr = attr[:i]
+		}
+		if name, ok := names[attr]; ok {
+			attr = name
+		}
+		projected[attr] = true
+	}
 
-		return av
+	var missing []string
+	for _, key := range attrs {
+		if key != "" && !projected[key] {
+			projected[key] = true
+			missing = append(missing, key)
+		}
 	}
-	if _, ok := val.(string); ok {
-		av := &types.AttributeValueMemberS{Value: val.(string)}
-		return av
+	if len(missing) == 0 {
+		return proj, names
 	}
-	if _, ok := val.(string); ok {
-		av := &types.AttributeValueMemberS{Value: val.(string)}
-		return av
+
+	expr := aws.ToString(proj)
+	merged := make(map[string]string, len(names)+len(missing))
+	for k, v := range names {
+		merged[k] = v
 	}
-	return nil
+	i := 0
+	for _, key := range missing {
+		// skip placeholders added by an earlier call
+		placeholder := fmt.Sprintf("#projKey%d", i)
+		for _, ok := merged[placeholder]; ok; _, ok = merged[placeholder] {
+			i++
+			placeholder = fmt.Sprintf("#projKey%d", i)
+		}
+		merged[placeholder] = key
+		expr += ", " + placeholder
+	}
+	return aws.String(expr), merged
 }
 
 // keyMaker creates a map of Partition and Sort Keys.
-func keyMaker(q *Query, t *Table) map[string]types.AttributeValue {
+// An InvalidKeyTypeError is returned if either key value isn't a string, number or []byte.
+func keyMaker(q *Query, t *Table) (map[string]types.AttributeValue, error) {
 	keys := make(map[string]types.AttributeValue)
-	keys[t.PrimaryKeyName] = createAV(q.PrimaryValue)
+	pk, err := keyAV(t.PrimaryKeyName, q.PrimaryValue)
+	if err != nil {
+		return nil, err
+	}
+	keys[t.PrimaryKeyName] = pk
 	if t.SortKeyName == "" {
-		return keys
+		return keys, nil
+	}
+	sk, err := keyAV(t.SortKeyName, q.SortValue)
+	if err != nil {
+		return nil, err
 	}
-	keys[t.SortKeyName] = createAV(q.SortValue)
-	return keys
+	keys[t.SortKeyName] = sk
+	return keys, nil
 }
 
 /* Transactions */
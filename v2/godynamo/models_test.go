@@ -0,0 +1,116 @@
+package godynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestKeyMaker(t *testing.T) {
+	table := &Table{TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S", SortKeyName: "sk", SortKeyType: "N"}
+
+	tests := []struct {
+		name          string
+		query         *Query
+		expected      map[string]types.AttributeValue
+		expectedError error
+	}{
+		{
+			name:  "StringAndInt",
+			query: CreateNewQueryObj("1", 2),
+			expected: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: "1"},
+				"sk": &types.AttributeValueMemberN{Value: "2"},
+			},
+		},
+		{
+			name:  "BinaryAndFloat",
+			query: CreateNewQueryObj([]byte("abc"), 1.5),
+			expected: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberB{Value: []byte("abc")},
+				"sk": &types.AttributeValueMemberN{Value: "1.5"},
+			},
+		},
+		{
+			name:  "SizedInts",
+			query: CreateNewQueryObj("1", int64(-3)),
+			expected: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: "1"},
+				"sk": &types.AttributeValueMemberN{Value: "-3"},
+			},
+		},
+		{
+			name:  "Unsigned",
+			query: CreateNewQueryObj("1", uint32(7)),
+			expected: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: "1"},
+				"sk": &types.AttributeValueMemberN{Value: "7"},
+			},
+		},
+		{
+			name:          "NumberSet",
+			query:         CreateNewQueryObj("1", []int{1, 2}),
+			expectedError: NewInvalidKeyTypeError("sk", []int{1, 2}),
+		},
+		{
+			name:          "BinarySet",
+			query:         CreateNewQueryObj([][]byte{[]byte("a")}, 1),
+			expectedError: NewInvalidKeyTypeError("id", [][]byte{[]byte("a")}),
+		},
+		{
+			name:          "Map",
+			query:         CreateNewQueryObj(map[string]types.AttributeValue{}, 1),
+			expectedError: NewInvalidKeyTypeError("id", map[string]types.AttributeValue{}),
+		},
+		{
+			name:          "Bool",
+			query:         CreateNewQueryObj("1", true),
+			expectedError: NewInvalidKeyTypeError("sk", true),
+		},
+		{
+			name:          "MissingSortKey",
+			query:         CreateNewQueryObj("1", nil),
+			expectedError: NewInvalidKeyTypeError("sk", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			keys, err := keyMaker(tt.query, table)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrInvalidKeyType)
+				assert.Nil(t, keys)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, keys)
+			}
+		})
+	}
+}
+
+func TestQueries_InvalidKeyType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+	// the request must not be sent
+	q := NewQueries(NewMockDynamoDBQueriesClientAPI(ctrl), tables, nil)
+
+	var item QueryRow
+	err := q.GetItem(context.Background(), GetItemParams{Query: CreateNewQueryObj([]int{1}, nil), TableName: "test-table", ItemPtr: &item})
+
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid key type []int for key attribute 'id': must be string, number or []byte")
+	assert.Implements(t, (*goaws.AwsError)(nil), err)
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -17,14 +21,34 @@ import (
 //go:generate mockgen -destination=../mocks/godynamomock/queries.go -package=godynamomock . QueriesLogic
 type QueriesLogic interface {
 	CreateItem(ctx context.Context, item any, tableName string) error
+	CreateItemWithMetrics(ctx context.Context, item any, tableName string) (*WriteMetrics, error)
+	CreateItemIfNotExists(ctx context.Context, item any, tableName, keyAttr string) error
+	CreateItemWithTTL(ctx context.Context, item any, tableName, ttlAttr string, expireAt time.Time) error
+	CreateItemWithParams(ctx context.Context, params CreateItemParams) error
+	PutRawItem(ctx context.Context, tableName string, item map[string]types.AttributeValue, expr Expression) error
 	GetItem(ctx context.Context, params GetItemParams) error
 	UpdateItem(ctx context.Context, query *Query, tableName string, expr Expression) error
+	UpdateItemWithMetrics(ctx context.Context, query *Query, tableName string, expr Expression) (*WriteMetrics, error)
+	RemoveIf(ctx context.Context, query *Query, tableName, attr string, cond Conditions) error
 	DeleteItem(ctx context.Context, query *Query, tableName string) error
 	BatchWriteCreate(ctx context.Context, tableName string, items []any) error
 	BatchWriteDelete(ctx context.Context, tableName string, queries []*Query) error
+	BatchWrite(ctx context.Context, tableName string, puts []any, deletes []*Query) error
+	ConditionalDeleteAll(ctx context.Context, tableName string, queries []ConditionalDelete, expr Expression) ([]*Query, error)
 	BatchGet(ctx context.Context, tableName string, queries []*Query, expr Expression) ([]QueryRow, error)
+	BatchGetWithParams(ctx context.Context, params BatchGetParams) ([]QueryRow, error)
+	BatchGetAll(ctx context.Context, tableName string, queries []*Query, expr Expression) ([]QueryRow, error)
+	BatchGetAligned(ctx context.Context, params BatchGetParams) ([]BatchGetResult, error)
 	QueryItems(ctx context.Context, params QueryItemsParams) (*QueryResults, error)
+	QueryItemsUntilLimit(ctx context.Context, params QueryItemsParams, limit int) (*QueryResults, error)
+	QueryIterator(params QueryItemsParams) *QueryIterator
 	ScanItems(ctx context.Context, params QueryItemsParams) (*ScanResults, error)
+	ScanEach(ctx context.Context, params QueryItemsParams, fn func(row QueryRow) error) error
+	ScanMissingAttribute(ctx context.Context, tableName, attr string, model any) error
+	ExecuteStatement(ctx context.Context, statement string, params []any) (*QueryResults, error)
+	RegisterTable(table *Table)
+	UnregisterTable(tableName string)
+	BatchExecuteStatement(ctx context.Context, statements []BatchStatement) ([]BatchStatementResult, error)
 }
 
 // DynamoDBQueriesClientAPI defines the interface for the AWS DynamoDB client methods used by this package.
@@ -39,12 +63,24 @@ type DynamoDBQueriesClientAPI interface {
 	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
 	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	ExecuteStatement(ctx context.Context, params *dynamodb.ExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error)
+	BatchExecuteStatement(ctx context.Context, params *dynamodb.BatchExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchExecuteStatementOutput, error)
 }
 
+// batchGetLimit is the maximum number of keys per BatchGetItem request.
+const batchGetLimit = 100
+
 type Queries struct {
-	svc    DynamoDBQueriesClientAPI
-	tables map[string]*Table
-	fc     *FailConfig
+	svc         DynamoDBQueriesClientAPI
+	tables      map[string]*Table
+	fc          *FailConfig
+	encoderOpts []func(*attributevalue.EncoderOptions)
+	decoderOpts []func(*attributevalue.DecoderOptions)
+	timestamps  *timestamps
+	projectKeys bool
+	returnOld   bool
+	flights     *getItemFlights
+	mu          sync.RWMutex
 }
 
 func NewQueries(svc DynamoDBQueriesClientAPI, tables map[string]*Table, fc *FailConfig) *Queries {
@@ -55,21 +91,219 @@ func NewQueries(svc DynamoDBQueriesClientAPI, tables map[string]*Table, fc *Fail
 	return &Queries{svc: svc, tables: tables, fc: fc}
 }
 
-// CreateItem puts a new item in the table.
+// WithDecoderOptions sets the attributevalue decoder options used when unmarshaling
+// items returned by the Queries methods, and returns q for chaining.
+// ex: q := NewQueries(svc, tables, nil).WithDecoderOptions(UseNumber)
+func (q *Queries) WithDecoderOptions(optFns ...func(*attributevalue.DecoderOptions)) *Queries {
+	q.decoderOpts = optFns
+	return q
+}
+
+// WithEncoderOptions sets the attributevalue encoder options used when marshaling
+// items, keys and parameters passed to the Queries methods, and returns q for chaining.
+// ex: q := NewQueries(svc, tables, nil).WithEncoderOptions(EncodeJSONTags).WithDecoderOptions(DecodeJSONTags)
+func (q *Queries) WithEncoderOptions(optFns ...func(*attributevalue.EncoderOptions)) *Queries {
+	q.encoderOpts = optFns
+	return q
+}
+
+// WithProjectedKeys makes GetItem, QueryItems and ScanItems add the table's key
+// attributes to any projection that omits them, so returned rows always include
+// their keys, and returns q for chaining.
+// ex: q := NewQueries(svc, tables, nil).WithProjectedKeys()
+func (q *Queries) WithProjectedKeys() *Queries {
+	q.projectKeys = true
+	return q
+}
+
+// WithReturnValuesOnConditionCheckFailure makes conditional writes by CreateItemIfNotExists,
+// CreateItemWithParams, PutRawItem, UpdateItem and RemoveIf request the item's
+// current attributes if their condition fails, and returns q for chaining. The attributes
+// are attached to the returned ConditionCheckFailedError, saving a read to diagnose the conflict.
+// ex: q := NewQueries(svc, tables, nil).WithReturnValuesOnConditionCheckFailure()
+func (q *Queries) WithReturnValuesOnConditionCheckFailure() *Queries {
+	q.returnOld = true
+	return q
+}
+
+// returnValuesOnConditionCheckFailure returns the ReturnValuesOnConditionCheckFailure
+// setting for conditional writes, or the zero value, which is omitted from the request,
+// if WithReturnValuesOnConditionCheckFailure isn't set.
+func (q *Queries) returnValuesOnConditionCheckFailure() types.ReturnValuesOnConditionCheckFailure {
+	if q.returnOld {
+		return types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+	return ""
+}
+
+// projection returns the projection expression and attribute names for expr,
+// including t's key attributes if q.projectKeys is set.
+func (q *Queries) projection(t *Table, expr Expression) (*string, map[string]string) {
+	proj, names := projection(t, expr)
+	if q.projectKeys {
+		proj, names = withKeyAttributes(t, proj, names)
+	}
+	return proj, names
+}
+
+// RegisterTable makes the given table available to the Queries methods.
+func (q *Queries) RegisterTable(table *Table) {
+	if table == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.tables == nil {
+		q.tables = make(map[string]*Table)
+	}
+	q.tables[table.TableName] = table
+}
+
+// UnregisterTable removes the given table from the tables available to the Queries methods.
+func (q *Queries) UnregisterTable(tableName string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.tables, tableName)
+}
+
+// getTable returns the registered table with the given name, or nil if not found.
+func (q *Queries) getTable(tableName string) *Table {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.tables[tableName]
+}
+
+// UseNumber is a decoder option that decodes N attributes as attributevalue.Number
+// rather than float64 when the destination is an interface (such as a QueryRow value),
+// preserving the full precision of large integers and decimal values.
+func UseNumber(o *attributevalue.DecoderOptions) {
+	o.UseNumber = true
+}
+
+// EncodeJSONTags is an encoder option that reads attribute names and options
+// from struct fields' json tags instead of dynamodbav tags.
+func EncodeJSONTags(o *attributevalue.EncoderOptions) {
+	o.TagKey = "json"
+}
+
+// DecodeJSONTags is a decoder option that reads attribute names and options
+// from struct fields' json tags instead of dynamodbav tags.
+func DecodeJSONTags(o *attributevalue.DecoderOptions) {
+	o.TagKey = "json"
+}
+
+// CreateItem puts a new item in the table. Items larger than MaxItemSize are
+// rejected with an ItemTooLargeError before the request is sent.
 func (q *Queries) CreateItem(ctx context.Context, item any, tableName string) error {
+	_, err := q.createItem(ctx, item, tableName, false)
+	return err
+}
+
+// CreateItemWithMetrics puts a new item in the table and returns the capacity consumed
+// by the write and, for tables with local secondary indexes, the item collection size.
+func (q *Queries) CreateItemWithMetrics(ctx context.Context, item any, tableName string) (*WriteMetrics, error) {
+	return q.createItem(ctx, item, tableName, true)
+}
+
+func (q *Queries) createItem(ctx context.Context, item any, tableName string, withMetrics bool) (*WriteMetrics, error) {
+	if item == nil {
+		return nil, NewNilModelError()
+	}
+
+	// check if table exists
+	t := q.getTable(tableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(tableName)
+	}
+
+	av, err := attributevalue.MarshalMapWithOptions(item, q.encoderOpts...)
+	if err != nil {
+		return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+	}
+	q.timestamps.stampItem(av)
+	if err := checkItemSize(av); err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(tableName),
+	}
+	if withMetrics {
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+		input.ReturnItemCollectionMetrics = types.ReturnItemCollectionMetricsSize
+	}
+
+	result, err := q.svc.PutItem(ctx, input)
+	if err != nil {
+		return nil, handleErr(fmt.Errorf("q.svc.PutItem: %w", err))
+	}
+	if !withMetrics {
+		return nil, nil
+	}
+
+	return newWriteMetrics(result.ConsumedCapacity, result.ItemCollectionMetrics), nil
+}
+
+// CreateItemIfNotExists puts a new item in the table only if no item with the same
+// keyAttr value exists. Returns ConditionCheckFailedError if the item already exists.
+func (q *Queries) CreateItemIfNotExists(ctx context.Context, item any, tableName, keyAttr string) error {
 	if item == nil {
 		return NewNilModelError()
 	}
 
 	// check if table exists
-	t := q.tables[tableName]
+	t := q.getTable(tableName)
+	if t == nil {
+		return NewTableNotFoundError(tableName)
+	}
+
+	av, err := attributevalue.MarshalMapWithOptions(item, q.encoderOpts...)
+	if err != nil {
+		return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+	}
+	q.timestamps.stampItem(av)
+	if err := checkItemSize(av); err != nil {
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:                     av,
+		TableName:                aws.String(tableName),
+		ConditionExpression:      aws.String("attribute_not_exists(#key)"),
+		ExpressionAttributeNames: map[string]string{"#key": keyAttr},
+	}
+	input.ReturnValuesOnConditionCheckFailure = q.returnValuesOnConditionCheckFailure()
+
+	if _, err = q.svc.PutItem(ctx, input); err != nil {
+		return handleErr(fmt.Errorf("q.svc.PutItem: %w", err))
+	}
+
+	return nil
+}
+
+// CreateItemWithTTL puts a new item in the table with expireAt set as the item's
+// time to live in Unix epoch seconds under ttlAttr, overwriting any value the
+// item already has for ttlAttr. ttlAttr must match the table's TTL attribute.
+func (q *Queries) CreateItemWithTTL(ctx context.Context, item any, tableName, ttlAttr string, expireAt time.Time) error {
+	if item == nil || ttlAttr == "" {
+		return NewNilModelError()
+	}
+
+	// check if table exists
+	t := q.getTable(tableName)
 	if t == nil {
 		return NewTableNotFoundError(tableName)
 	}
 
-	av, err := attributevalue.MarshalMap(item)
+	av, err := attributevalue.MarshalMapWithOptions(item, q.encoderOpts...)
 	if err != nil {
-		return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMap: %w", err))
+		return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+	}
+	av[ttlAttr] = &types.AttributeValueMemberN{Value: strconv.FormatInt(expireAt.Unix(), 10)}
+	q.timestamps.stampItem(av)
+	if err := checkItemSize(av); err != nil {
+		return err
 	}
 
 	input := &dynamodb.PutItemInput{
@@ -78,7 +312,147 @@ func (q *Queries) CreateItem(ctx context.Context, item any, tableName string) er
 	}
 
 	if _, err = q.svc.PutItem(ctx, input); err != nil {
-		return goaws.NewInternalError(fmt.Errorf("q.svc.PutItem: %w", err))
+		return handleErr(fmt.Errorf("q.svc.PutItem: %w", err))
+	}
+
+	return nil
+}
+
+// CreateItemWithParams puts a new item in the table only if params.Expression's
+// condition holds. Returns ConditionCheckFailedError if it doesn't. Set
+// params.IdempotencyToken to make retries of the same create safe.
+// ex: replace an order only if the stored version is the one the caller read
+//
+//	cond := NewCondition()
+//	cond.Equal("version", readVersion)
+//	eb := NewExprBuilder()
+//	eb.SetCondition(cond)
+//	expr, err := eb.BuildExpression()
+//	err = q.CreateItemWithParams(ctx, CreateItemParams{Item: order, TableName: "orders", Expression: expr})
+func (q *Queries) CreateItemWithParams(ctx context.Context, params CreateItemParams) error {
+	if params.Item == nil {
+		return NewNilModelError()
+	}
+
+	// check if table exists
+	t := q.getTable(params.TableName)
+	if t == nil {
+		return NewTableNotFoundError(params.TableName)
+	}
+
+	av, err := attributevalue.MarshalMapWithOptions(params.Item, q.encoderOpts...)
+	if err != nil {
+		return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+	}
+	tokenAttr := params.IdempotencyAttribute
+	if tokenAttr == "" {
+		tokenAttr = DefaultIdempotencyAttribute
+	}
+	if params.IdempotencyToken != "" {
+		av[tokenAttr] = &types.AttributeValueMemberS{Value: params.IdempotencyToken}
+	}
+	q.timestamps.stampItem(av)
+	if err := checkItemSize(av); err != nil {
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:                      av,
+		TableName:                 aws.String(params.TableName),
+		ConditionExpression:       params.Expression.Condition(),
+		ExpressionAttributeNames:  params.Expression.Names(),
+		ExpressionAttributeValues: params.Expression.Values(),
+	}
+	input.ReturnValuesOnConditionCheckFailure = q.returnValuesOnConditionCheckFailure()
+	if params.IdempotencyToken != "" {
+		input.ConditionExpression, input.ExpressionAttributeNames = withNotExists(t, input.ConditionExpression, input.ExpressionAttributeNames)
+	}
+
+	if _, err = q.svc.PutItem(ctx, input); err != nil {
+		err = handleErr(fmt.Errorf("q.svc.PutItem: %w", err))
+		var conditionFailed *ConditionCheckFailedError
+		if params.IdempotencyToken != "" && errors.As(err, &conditionFailed) {
+			return q.resolveDuplicate(ctx, t, av, tokenAttr, params, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// withNotExists adds a condition that no item with the table's partition key exists
+// to the given condition expression and names.
+func withNotExists(t *Table, cond *string, names map[string]string) (*string, map[string]string) {
+	merged := make(map[string]string, len(names)+1)
+	maps.Copy(merged, names)
+	merged["#idemKey"] = t.PrimaryKeyName
+
+	if cond == nil {
+		return aws.String("attribute_not_exists(#idemKey)"), merged
+	}
+	return aws.String(fmt.Sprintf("(%s) AND attribute_not_exists(#idemKey)", *cond)), merged
+}
+
+// resolveDuplicate reads the item that blocked an idempotent create. If it holds the
+// same idempotency token, the create is a retry and is resolved per params; otherwise
+// condErr is returned.
+func (q *Queries) resolveDuplicate(ctx context.Context, t *Table, av map[string]types.AttributeValue, tokenAttr string, params CreateItemParams, condErr error) error {
+	result, err := q.svc.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(t.TableName),
+		Key:            tableKey(t, av),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return handleErr(fmt.Errorf("q.svc.GetItem: %w", err))
+	}
+
+	token, ok := result.Item[tokenAttr].(*types.AttributeValueMemberS)
+	if !ok || token.Value != params.IdempotencyToken {
+		return condErr
+	}
+	if params.FailOnDuplicate {
+		return NewDuplicateCreateError(params.IdempotencyToken)
+	}
+	if params.ExistingItemPtr != nil {
+		if err := attributevalue.UnmarshalMapWithOptions(result.Item, params.ExistingItemPtr, q.decoderOpts...); err != nil {
+			return goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// PutRawItem puts an already marshaled item in the table, skipping the struct
+// round-trip of CreateItem. expr's condition, if any, is applied to the put.
+// item is not modified; timestamps set by WithTimestamps are added to a copy.
+func (q *Queries) PutRawItem(ctx context.Context, tableName string, item map[string]types.AttributeValue, expr Expression) error {
+	if len(item) == 0 {
+		return NewNilModelError()
+	}
+
+	// check if table exists
+	t := q.getTable(tableName)
+	if t == nil {
+		return NewTableNotFoundError(tableName)
+	}
+
+	av := maps.Clone(item)
+	q.timestamps.stampItem(av)
+	if err := checkItemSize(av); err != nil {
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:                      av,
+		TableName:                 aws.String(t.TableName),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+	input.ReturnValuesOnConditionCheckFailure = q.returnValuesOnConditionCheckFailure()
+
+	if _, err := q.svc.PutItem(ctx, input); err != nil {
+		return handleErr(fmt.Errorf("q.svc.PutItem: %w", err))
 	}
 
 	return nil
@@ -91,29 +465,32 @@ func (q *Queries) GetItem(ctx context.Context, params GetItemParams) error {
 	}
 
 	// get table
-	t := q.tables[params.TableName]
+	t := q.getTable(params.TableName)
 	if t == nil {
 		return NewTableNotFoundError(params.TableName)
 	}
 
-	key := keyMaker(params.Query, t)
+	key, err := keyMaker(params.Query, t)
+	if err != nil {
+		return err
+	}
 	input := &dynamodb.GetItemInput{
 		TableName:      aws.String(t.TableName),
 		Key:            key,
 		ConsistentRead: aws.Bool(params.ConsistentReads),
 	}
-	if params.Expression.Projection() != nil {
-		input.ExpressionAttributeNames = params.Expression.Names()
-		input.ProjectionExpression = params.Expression.Projection()
+	input.ProjectionExpression, input.ExpressionAttributeNames = q.projection(t, params.Expression)
+	if input.ProjectionExpression == nil {
+		input.ExpressionAttributeNames = nil
 	}
 
-	result, err := q.svc.GetItem(ctx, input)
+	item, err := q.getItem(ctx, t, input)
 	if err != nil {
-		return handleErr(fmt.Errorf("q.svc.GetItem: %w", err))
+		return err
 	}
 
-	if err = attributevalue.UnmarshalMap(result.Item, params.ItemPtr); err != nil {
-		return goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMap: %w", err))
+	if err = attributevalue.UnmarshalMapWithOptions(item, params.ItemPtr, q.decoderOpts...); err != nil {
+		return goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
 	}
 
 	return nil
@@ -122,20 +499,39 @@ func (q *Queries) GetItem(ctx context.Context, params GetItemParams) error {
 // UpdateItem updates the specified item's attribute defined in the
 // Query object with the UpdateValue defined in the Query.
 func (q *Queries) UpdateItem(ctx context.Context, query *Query, tableName string, expr Expression) error {
+	_, err := q.updateItem(ctx, query, tableName, expr, false)
+	return err
+}
+
+// UpdateItemWithMetrics updates the specified item like UpdateItem and returns the capacity consumed
+// by the write and, for tables with local secondary indexes, the item collection size.
+func (q *Queries) UpdateItemWithMetrics(ctx context.Context, query *Query, tableName string, expr Expression) (*WriteMetrics, error) {
+	return q.updateItem(ctx, query, tableName, expr, true)
+}
+
+func (q *Queries) updateItem(ctx context.Context, query *Query, tableName string, expr Expression, withMetrics bool) (*WriteMetrics, error) {
 	// get table
-	t, ok := q.tables[tableName]
-	if !ok {
-		return NewTableNotFoundError(tableName)
+	t := q.getTable(tableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(tableName)
+	}
+	key, err := keyMaker(query, t)
+	if err != nil {
+		return nil, err
 	}
 
 	input := &dynamodb.UpdateItemInput{
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		TableName:                 aws.String(t.TableName),
-		Key:                       keyMaker(query, t),
+		Key:                       key,
 		ReturnValues:              "ALL_NEW",
 		UpdateExpression:          expr.Update(),
 	}
+	input.ReturnValuesOnConditionCheckFailure = q.returnValuesOnConditionCheckFailure()
+	input.UpdateExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues = q.timestamps.stampUpdate(
+		input.UpdateExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues,
+	)
 	if expr.Condition() != nil {
 		input.ConditionExpression = expr.Condition()
 	}
@@ -148,24 +544,65 @@ func (q *Queries) UpdateItem(ctx context.Context, query *Query, tableName string
 	if expr.Projection() != nil {
 		input.ConditionExpression = expr.Projection()
 	}
+	if withMetrics {
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+		input.ReturnItemCollectionMetrics = types.ReturnItemCollectionMetricsSize
+	}
 
-	if _, err := q.svc.UpdateItem(ctx, input); err != nil {
-		return handleErr(fmt.Errorf("q.svc.UpdateItem: %w", err))
+	result, err := q.svc.UpdateItem(ctx, input)
+	if err != nil {
+		return nil, handleErr(fmt.Errorf("q.svc.UpdateItem: %w", err))
+	}
+	if !withMetrics {
+		return nil, nil
 	}
 
-	return nil
+	return newWriteMetrics(result.ConsumedCapacity, result.ItemCollectionMetrics), nil
+}
+
+// RemoveIf removes the attr attribute from the item defined in the Query only if cond holds.
+// Returns ConditionCheckFailedError if it doesn't.
+// ex: release a lock only if it is still held by the caller
+//
+//	cond := NewCondition()
+//	cond.Equal("lock_token", token)
+//	err := q.RemoveIf(ctx, query, "locks", "lock_token", cond)
+func (q *Queries) RemoveIf(ctx context.Context, query *Query, tableName, attr string, cond Conditions) error {
+	if query == nil {
+		return NewNilModelError()
+	}
+	if attr == "" {
+		return goaws.NewClientError(errors.New("empty attribute name"))
+	}
+
+	ud := NewUpdateExpr()
+	ud.Remove(attr)
+	eb := NewExprBuilder()
+	eb.SetUpdate(ud)
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	if err != nil {
+		return goaws.NewClientError(fmt.Errorf("eb.BuildExpression: %w", err))
+	}
+
+	_, err = q.updateItem(ctx, query, tableName, expr, false)
+	return err
 }
 
 // DeleteItem deletes the specified item defined in the Query
 func (q *Queries) DeleteItem(ctx context.Context, query *Query, tableName string) error {
 	// get table
-	t, ok := q.tables[tableName]
-	if !ok {
+	t := q.getTable(tableName)
+	if t == nil {
 		return NewTableNotFoundError(tableName)
 	}
+	key, err := keyMaker(query, t)
+	if err != nil {
+		return err
+	}
 
 	input := &dynamodb.DeleteItemInput{
-		Key:       keyMaker(query, t),
+		Key:       key,
 		TableName: aws.String(t.TableName),
 	}
 
@@ -176,7 +613,11 @@ func (q *Queries) DeleteItem(ctx context.Context, query *Query, tableName string
 	return nil
 }
 
-// BatchWriteCreate writes a list of items to the database.
+// BatchWriteCreate writes a list of items to the database. An ItemTooLargeError is
+// returned before any items are written if an item exceeds MaxItemSize. If items remain unprocessed
+// when the retry budget is exhausted, the returned error wraps an *UnprocessedItemsError
+// holding them. An error matching context.DeadlineExceeded is returned instead of waiting
+// if ctx's deadline would pass before the next retry.
 func (q *Queries) BatchWriteCreate(ctx context.Context, tableName string, items []any) error {
 	if len(items) == 0 {
 		return NewNilModelError()
@@ -186,8 +627,8 @@ func (q *Queries) BatchWriteCreate(ctx context.Context, tableName string, items
 	}
 
 	// get table
-	t, ok := q.tables[tableName]
-	if !ok {
+	t := q.getTable(tableName)
+	if t == nil {
 		return NewTableNotFoundError(tableName)
 	}
 
@@ -202,9 +643,13 @@ func (q *Queries) BatchWriteCreate(ctx context.Context, tableName string, items
 		}
 
 		// marshal each item
-		av, err := attributevalue.MarshalMap(item)
+		av, err := attributevalue.MarshalMapWithOptions(item, q.encoderOpts...)
 		if err != nil {
-			return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMap: %w", err))
+			return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+		}
+		q.timestamps.stampItem(av)
+		if err := checkItemSize(av); err != nil {
+			return err
 		}
 		// create put request, reformat as write request, and add to list
 		pr := &types.PutRequest{Item: av}
@@ -219,56 +664,46 @@ func (q *Queries) BatchWriteCreate(ctx context.Context, tableName string, items
 		RequestItems: reqItems,
 	}
 
-	// batch write and error handling with exponential backoff retries for HTTP 5xx errors
-	var result *dynamodb.BatchWriteItemOutput
-	var err error
-	retries := q.fc.NewRetries()
-	for {
-		result, err = q.batchWriteUtil(ctx, input)
-		if err != nil {
-			var throttled *RateLimitExceededError
-			var awsErr goaws.AwsError
-			switch {
-			case errors.As(err, &throttled):
-				input = &dynamodb.BatchWriteItemInput{
-					RequestItems: result.UnprocessedItems,
-				}
-				if err := retries.ExponentialBackoff(); err != nil { // waits
-					return fmt.Errorf("retries.ExponentialBackoff: %w", err)
-				}
-			case errors.As(err, &awsErr):
-				if awsErr.Retryable() {
-					input = &dynamodb.BatchWriteItemInput{
-						RequestItems: result.UnprocessedItems,
-					}
-					if err := retries.ExponentialBackoff(); err != nil { // waits
-						return fmt.Errorf("retries.ExponentialBackoff: %w", err)
-					}
-				} else {
-					return fmt.Errorf("q.batchWriteUtil: %w", err)
-				}
-			default:
-				return goaws.NewInternalError(fmt.Errorf("q.batchWriteUtil: %w", err))
+	return q.batchWriteRetries(ctx, input, func(reqItems map[string][]types.WriteRequest) error {
+		return NewUnprocessedItemsError(unprocessedPutItems(reqItems))
+	})
+}
+
+// unprocessedPutItems returns the items of the put requests in reqItems.
+func unprocessedPutItems(reqItems map[string][]types.WriteRequest) []map[string]types.AttributeValue {
+	items := make([]map[string]types.AttributeValue, 0)
+	for _, wrs := range reqItems {
+		for _, wr := range wrs {
+			if wr.PutRequest != nil {
+				items = append(items, wr.PutRequest.Item)
 			}
 		}
+	}
+	return items
+}
 
-		if len(result.UnprocessedItems) == 0 {
-			break
+// unprocessedDeleteKeys returns the keys of the delete requests in reqItems.
+func unprocessedDeleteKeys(reqItems map[string][]types.WriteRequest) []map[string]types.AttributeValue {
+	keys := make([]map[string]types.AttributeValue, 0)
+	for _, wrs := range reqItems {
+		for _, wr := range wrs {
+			if wr.DeleteRequest != nil {
+				keys = append(keys, wr.DeleteRequest.Key)
+			}
 		}
-
 	}
-
-	return nil
+	return keys
 }
 
-// BatchWriteDelete deletes a list of items from the database.
+// BatchWriteDelete deletes a list of items from the database. An error matching
+// context.DeadlineExceeded is returned if ctx's deadline would pass before the next retry.
 func (q *Queries) BatchWriteDelete(ctx context.Context, tableName string, queries []*Query) error {
 	if len(queries) > 25 {
 		return NewCollectionSizeExceededError(len(queries))
 	}
 
 	// get table
-	t := q.tables[tableName]
+	t := q.getTable(tableName)
 	if t == nil {
 		return NewTableNotFoundError(tableName)
 	}
@@ -284,7 +719,11 @@ func (q *Queries) BatchWriteDelete(ctx context.Context, tableName string, querie
 		}
 
 		// create put request, reformat as write request, and add to list
-		dr := &types.DeleteRequest{Key: keyMaker(q, t)}
+		key, err := keyMaker(q, t)
+		if err != nil {
+			return err
+		}
+		dr := &types.DeleteRequest{Key: key}
 		wr := types.WriteRequest{DeleteRequest: dr}
 		wrs = append(wrs, wr)
 	}
@@ -296,131 +735,413 @@ func (q *Queries) BatchWriteDelete(ctx context.Context, tableName string, querie
 		RequestItems: reqItems,
 	}
 
-	// batch write and error handling with exponential backoff retries for HTTP 5xx errors
-	var result *dynamodb.BatchWriteItemOutput
-	var err error
+	return q.batchWriteRetries(ctx, input, nil)
+}
+
+// BatchWrite puts the given items and deletes the items matching the given queries
+// in a single BatchWriteItem request. At most 25 puts and deletes combined are
+// allowed, and an item may not be both put and deleted; nil entries are skipped. An
+// ItemTooLargeError is returned before any items are written if an item exceeds MaxItemSize.
+// If requests remain unprocessed when the retry budget is exhausted, the returned error wraps
+// an *UnprocessedItemsError holding them. An error matching context.DeadlineExceeded is
+// returned if ctx's deadline would pass before the next retry.
+func (q *Queries) BatchWrite(ctx context.Context, tableName string, puts []any, deletes []*Query) error {
+	if len(puts) == 0 && len(deletes) == 0 {
+		return NewNilModelError()
+	}
+	if n := len(puts) + len(deletes); n > 25 {
+		return NewCollectionSizeExceededError(n)
+	}
+
+	// get table
+	t := q.getTable(tableName)
+	if t == nil {
+		return NewTableNotFoundError(tableName)
+	}
+
+	wrs := make([]types.WriteRequest, 0, len(puts)+len(deletes))
+	for _, item := range puts {
+		if item == nil {
+			continue
+		}
+		av, err := attributevalue.MarshalMapWithOptions(item, q.encoderOpts...)
+		if err != nil {
+			return goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+		}
+		q.timestamps.stampItem(av)
+		if err := checkItemSize(av); err != nil {
+			return err
+		}
+		wrs = append(wrs, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+	}
+	for _, query := range deletes {
+		if query == nil {
+			continue
+		}
+		key, err := keyMaker(query, t)
+		if err != nil {
+			return err
+		}
+		wrs = append(wrs, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
+	}
+	if len(wrs) == 0 {
+		return nil
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{t.TableName: wrs},
+	}
+
+	return q.batchWriteRetries(ctx, input, func(reqItems map[string][]types.WriteRequest) error {
+		return newUnprocessedWritesError(unprocessedPutItems(reqItems), unprocessedDeleteKeys(reqItems))
+	})
+}
+
+// batchWriteRetries writes input's requests with exponential backoff retries for
+// throttling, HTTP 5xx errors and unprocessed items. If the retry budget is exhausted,
+// exhausted, if set, builds the returned error from the unprocessed requests.
+func (q *Queries) batchWriteRetries(ctx context.Context, input *dynamodb.BatchWriteItemInput, exhausted func(map[string][]types.WriteRequest) error) error {
 	retries := q.fc.NewRetries()
 	for {
-		result, err = q.batchWriteUtil(ctx, input)
+		result, err := q.batchWriteUtil(ctx, input)
 		if err != nil {
 			var throttled *RateLimitExceededError
 			var awsErr goaws.AwsError
 			switch {
 			case errors.As(err, &throttled):
-				input = &dynamodb.BatchWriteItemInput{
-					RequestItems: result.UnprocessedItems,
-				}
-				if err := retries.ExponentialBackoff(); err != nil { // waits
-					return fmt.Errorf("retries.ExponentialBackoff: %w", err)
-				}
+				// retry the same input
 			case errors.As(err, &awsErr):
-				if awsErr.Retryable() {
-					input = &dynamodb.BatchWriteItemInput{
-						RequestItems: result.UnprocessedItems,
-					}
-					if err := retries.ExponentialBackoff(); err != nil { // waits
-						return fmt.Errorf("retries.ExponentialBackoff: %w", err)
-					}
-				} else {
+				if !awsErr.Retryable() {
 					return fmt.Errorf("q.batchWriteUtil: %w", err)
 				}
 			default:
 				return goaws.NewInternalError(fmt.Errorf("q.batchWriteUtil: %w", err))
 			}
+		} else {
+			if len(result.UnprocessedItems) == 0 {
+				return nil
+			}
+			input = &dynamodb.BatchWriteItemInput{
+				RequestItems: result.UnprocessedItems,
+			}
 		}
 
-		if len(result.UnprocessedItems) == 0 {
-			break
+		if err := retries.ExponentialBackoffContext(ctx); err != nil { // waits
+			if exhausted != nil && !errors.Is(err, ErrDeadlineExceeded) {
+				err = exhausted(input.RequestItems)
+			}
+			return fmt.Errorf("retries.ExponentialBackoffContext: %w", err)
 		}
-
 	}
-
-	return nil
 }
 
-// BatchGet retrieves a list of items from the database
-// refObjs must be non-nil pointers of the same type,
-// 1 for each query/object returneq.
-//   - Returns err if len(queries) != len(refObjs).
-func (q *Queries) BatchGet(ctx context.Context, tableName string, queries []*Query, expr Expression) ([]QueryRow, error) {
-	if len(queries) > 100 {
-		return nil, NewCollectionSizeExceededError(len(queries))
-	}
-
+// ConditionalDeleteAll deletes a list of items from the database. BatchWriteItem doesn't support
+// conditions, so queries with Conditional set are deleted individually with DeleteItem using the
+// condition in expr, while the rest are deleted with BatchWriteDelete in batches of 25.
+// The queries that were skipped because their condition was not met are returned, and
+// their current attributes are set on queries if WithReturnValuesOnConditionCheckFailure is set.
+func (q *Queries) ConditionalDeleteAll(ctx context.Context, tableName string, queries []ConditionalDelete, expr Expression) ([]*Query, error) {
 	// get table
-	t := q.tables[tableName]
+	t := q.getTable(tableName)
 	if t == nil {
 		return nil, NewTableNotFoundError(tableName)
 	}
 
-	items := make([]QueryRow, 0)
-
-	// create map of RequestItems
-	reqItems := make(map[string]types.KeysAndAttributes)
-	keys := []map[string]types.AttributeValue{}
-
-	// create Get requests for each query
-	for _, q := range queries {
-		if q == nil {
+	skipped := make([]*Query, 0)
+	batch := make([]*Query, 0, len(queries))
+	for i, cd := range queries {
+		if cd.Query == nil {
+			continue
+		}
+		if !cd.Conditional {
+			batch = append(batch, cd.Query)
 			continue
 		}
 
-		item := keyMaker(q, t)
+		key, err := keyMaker(cd.Query, t)
+		if err != nil {
+			return nil, err
+		}
+		input := &dynamodb.DeleteItemInput{
+			Key:                       key,
+			TableName:                 aws.String(t.TableName),
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		}
+		input.ReturnValuesOnConditionCheckFailure = q.returnValuesOnConditionCheckFailure()
+		if _, err := q.svc.DeleteItem(ctx, input); err != nil {
+			err = handleErr(fmt.Errorf("q.svc.DeleteItem: %w", err))
+			var conditionFailed *ConditionCheckFailedError
+			if errors.As(err, &conditionFailed) {
+				queries[i].Item = conditionFailed.Item
+				skipped = append(skipped, cd.Query)
+				continue
+			}
+			return skipped, err
+		}
+	}
+
+	for i := 0; i < len(batch); i += 25 {
+		if err := q.BatchWriteDelete(ctx, tableName, batch[i:min(i+25, len(batch))]); err != nil {
+			return skipped, err
+		}
+	}
+
+	return skipped, nil
+}
+
+// BatchGet retrieves a list of items from the database
+// refObjs must be non-nil pointers of the same type,
+// 1 for each query/object returneq.
+//   - Items are matched to the queries by key and returned in query order, as
+//     DynamoDB doesn't preserve it; queries with no matching item are omitted.
+//   - Returns err if len(queries) != len(refObjs).
+//   - Returns the items retrieved so far and an error matching context.DeadlineExceeded
+//     if ctx's deadline would pass before the next retry.
+func (q *Queries) BatchGet(ctx context.Context, tableName string, queries []*Query, expr Expression) ([]QueryRow, error) {
+	return q.BatchGetWithParams(ctx, BatchGetParams{TableName: tableName, Queries: queries, Expression: expr})
+}
+
+// BatchGetWithParams retrieves a list of items from the database like BatchGet,
+// using strongly consistent reads when params.ConsistentReads is set.
+func (q *Queries) BatchGetWithParams(ctx context.Context, params BatchGetParams) ([]QueryRow, error) {
+	if len(params.Queries) > batchGetLimit {
+		return nil, NewCollectionSizeExceededError(len(params.Queries))
+	}
+
+	// get table
+	t := q.getTable(params.TableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(params.TableName)
+	}
+
+	found, deadlineErr := q.batchGetFound(ctx, t, params.Queries, readOptions(params.ConsistentReads))
+	if deadlineErr != nil && !errors.Is(deadlineErr, ErrDeadlineExceeded) {
+		return nil, deadlineErr
+	}
+
+	items, err := q.rowsInQueryOrder(t, params.Queries, found)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, deadlineErr
+}
+
+// BatchGetAll retrieves a list of items of any length from the database by splitting the
+// queries into batches of 100 keys. Items are returned in the same order as the queries
+// they match; queries with no matching item are omitted from the results.
+// If ctx's deadline would pass before the next retry, the items retrieved so far are
+// returned with an error matching context.DeadlineExceeded.
+func (q *Queries) BatchGetAll(ctx context.Context, tableName string, queries []*Query, expr Expression) ([]QueryRow, error) {
+	// get table
+	t := q.getTable(tableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(tableName)
+	}
+
+	found, deadlineErr := q.batchGetFound(ctx, t, queries, readOptions(false))
+	if deadlineErr != nil && !errors.Is(deadlineErr, ErrDeadlineExceeded) {
+		return nil, deadlineErr
+	}
+
+	items, err := q.rowsInQueryOrder(t, queries, found)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, deadlineErr
+}
+
+// rowsInQueryOrder unmarshals the items in found, keyed by itemKey, in the order of
+// the queries they match. Queries with no matching item are omitted.
+func (q *Queries) rowsInQueryOrder(t *Table, queries []*Query, found map[string]map[string]types.AttributeValue) ([]QueryRow, error) {
+	items := make([]QueryRow, 0, len(found))
+	for _, query := range queries {
+		if query == nil {
+			continue
+		}
+		key, err := keyMaker(query, t)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := found[itemKey(key, t)]
+		if !ok {
+			continue
+		}
+		var item = make(QueryRow)
+		if err := attributevalue.UnmarshalMapWithOptions(r, &item, q.decoderOpts...); err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// BatchGetAligned retrieves a list of items of any length from the database like BatchGetAll,
+// but returns one result per query, in the same order, with Found unset for queries with
+// no matching item, so callers can tell which keys are missing. Nil queries are not found,
+// and duplicate queries are fetched once and share the item. The projection in
+// params.Expression is applied with the table's key attributes added, as items are
+// matched to the queries by key. If ctx's deadline would pass before the next retry, the results are returned with an
+// error matching context.DeadlineExceeded, and Found is unset for the queries not yet retrieved.
+func (q *Queries) BatchGetAligned(ctx context.Context, params BatchGetParams) ([]BatchGetResult, error) {
+	// get table
+	t := q.getTable(params.TableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(params.TableName)
+	}
+
+	// results are matched to the queries by key, so the projection must include it
+	ka := readOptions(params.ConsistentReads)
+	proj, names := q.projection(t, params.Expression)
+	ka.ProjectionExpression, ka.ExpressionAttributeNames = withKeyAttributes(t, proj, names)
+	found, deadlineErr := q.batchGetFound(ctx, t, params.Queries, ka)
+	if deadlineErr != nil && !errors.Is(deadlineErr, ErrDeadlineExceeded) {
+		return nil, deadlineErr
+	}
+
+	results := make([]BatchGetResult, len(params.Queries))
+	for i, query := range params.Queries {
+		results[i].Query = query
+		if query == nil {
+			continue
+		}
+		key, err := keyMaker(query, t)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := found[itemKey(key, t)]
+		if !ok {
+			continue
+		}
+		var item = make(QueryRow)
+		if err := attributevalue.UnmarshalMapWithOptions(r, &item, q.decoderOpts...); err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+		}
+		results[i].Item = item
+		results[i].Found = true
+	}
+
+	return results, deadlineErr
+}
+
+// readOptions returns the KeysAndAttributes read options for BatchGetItem,
+// using strongly consistent reads if consistent is set.
+func readOptions(consistent bool) types.KeysAndAttributes {
+	var ka types.KeysAndAttributes
+	if consistent {
+		ka.ConsistentRead = aws.Bool(true)
+	}
+	return ka
+}
+
+// batchGetFound retrieves the items matching the given queries in batches of 100 keys,
+// keyed by itemKey. Duplicate queries are only requested once, as BatchGetItem rejects
+// duplicate keys. ka sets the read options of each batch; its Keys are ignored.
+// If ctx's deadline would pass before the next retry, the items retrieved so far
+// are returned with an error matching ErrDeadlineExceeded.
+func (q *Queries) batchGetFound(ctx context.Context, t *Table, queries []*Query, ka types.KeysAndAttributes) (map[string]map[string]types.AttributeValue, error) {
+	unique := make([]*Query, 0, len(queries))
+	seen := make(map[string]bool, len(queries))
+	for _, query := range queries {
+		if query == nil {
+			continue
+		}
+		key, err := keyMaker(query, t)
+		if err != nil {
+			return nil, err
+		}
+		if k := itemKey(key, t); !seen[k] {
+			seen[k] = true
+			unique = append(unique, query)
+		}
+	}
+
+	found := make(map[string]map[string]types.AttributeValue, len(unique))
+	for start := 0; start < len(unique); start += batchGetLimit {
+		end := min(start+batchGetLimit, len(unique))
+		responses, err := q.batchGetItems(ctx, t, unique[start:end], ka)
+		if err != nil && !errors.Is(err, ErrDeadlineExceeded) {
+			return nil, err
+		}
+		for _, r := range responses {
+			found[itemKey(r, t)] = r
+		}
+		if err != nil {
+			return found, err
+		}
+	}
+
+	return found, nil
+}
+
+// batchGetItems retrieves the items matching the given queries (max 100) with the
+// read options in ka, retrying unprocessed keys with exponential backoff.
+func (q *Queries) batchGetItems(ctx context.Context, t *Table, queries []*Query, ka types.KeysAndAttributes) ([]map[string]types.AttributeValue, error) {
+	items := make([]map[string]types.AttributeValue, 0)
+
+	// create map of RequestItems
+	reqItems := make(map[string]types.KeysAndAttributes)
+	keys := []map[string]types.AttributeValue{}
+
+	// create Get requests for each query
+	for _, q := range queries {
+		if q == nil {
+			continue
+		}
+
+		item, err := keyMaker(q, t)
+		if err != nil {
+			return nil, err
+		}
 		keys = append(keys, item)
 	}
 	// populate reqItems map
-	reqItems[t.TableName] = types.KeysAndAttributes{Keys: keys}
+	ka.Keys = keys
+	reqItems[t.TableName] = ka
 
 	// generate input from reqItems map
 	input := &dynamodb.BatchGetItemInput{
 		RequestItems: reqItems,
 	}
 
-	// batch write and error handling with exponential backoff retries for HTTP 5xx errors
-	var result *dynamodb.BatchGetItemOutput
-	var err error
+	// batch get and error handling with exponential backoff retries for HTTP 5xx errors
+	// and unprocessed keys
 	retries := q.fc.NewRetries()
 	for {
-		result, err = q.batchGetUtil(ctx, input)
+		result, err := q.batchGetUtil(ctx, input)
 		if err != nil {
 			var throttled *RateLimitExceededError
 			var awsErr goaws.AwsError
 			switch {
 			case errors.As(err, &throttled):
-				input = &dynamodb.BatchGetItemInput{
-					RequestItems: result.UnprocessedKeys,
-				}
-				if err := retries.ExponentialBackoff(); err != nil { // waits
-					return nil, fmt.Errorf("retries.ExponentialBackoff: %w", err)
-				}
+				// retry the same input
 			case errors.As(err, &awsErr):
-				if awsErr.Retryable() {
-					input = &dynamodb.BatchGetItemInput{
-						RequestItems: result.UnprocessedKeys,
-					}
-					if err := retries.ExponentialBackoff(); err != nil { // waits
-						return nil, fmt.Errorf("retries.ExponentialBackoff: %w", err)
-					}
-				} else {
+				if !awsErr.Retryable() {
 					return nil, fmt.Errorf("q.batchGetUtil: %w", err)
 				}
 			default:
 				return nil, goaws.NewInternalError(fmt.Errorf("q.batchGetUtil: %w", err))
 			}
-		}
-
-		for _, r := range result.Responses[t.TableName] {
-			var item = make(QueryRow)
-			if err := attributevalue.UnmarshalMap(r, &item); err != nil {
-				return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMap, %w", err))
+		} else {
+			items = append(items, result.Responses[t.TableName]...)
+			if len(result.UnprocessedKeys) == 0 {
+				break
+			}
+			input = &dynamodb.BatchGetItemInput{
+				RequestItems: result.UnprocessedKeys,
 			}
-			items = append(items, item)
 		}
 
-		if len(result.UnprocessedKeys) == 0 {
-			break
+		if err := retries.ExponentialBackoffContext(ctx); err != nil { // waits
+			if errors.Is(err, ErrDeadlineExceeded) {
+				// return the items retrieved before the deadline
+				return items, fmt.Errorf("retries.ExponentialBackoffContext: %w", err)
+			}
+			return nil, fmt.Errorf("retries.ExponentialBackoffContext: %w", err)
 		}
-
 	}
 
 	return items, nil
@@ -429,31 +1150,16 @@ func (q *Queries) BatchGet(ctx context.Context, tableName string, queries []*Que
 // ScanItems scans the given Table for items matching the given expression parameters.
 func (q *Queries) ScanItems(ctx context.Context, params QueryItemsParams) (*ScanResults, error) {
 	// get table
-	t := q.tables[params.TableName]
+	t := q.getTable(params.TableName)
 	if t == nil {
 		return nil, NewTableNotFoundError(params.TableName)
 	}
 
 	items := make([]QueryRow, 0)
 
-	// Build the query input parameters
-	expr := params.Expression
-	input := &dynamodb.ScanInput{
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
-		TableName:                 aws.String(t.TableName),
-		Limit:                     params.PerPage,
-		ConsistentRead:            aws.Bool(params.ConsistentReads),
-	}
-
-	if params.StartKey != nil {
-		av, err := attributevalue.MarshalMap(params.StartKey)
-		if err != nil {
-			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMap: %w", err))
-		}
-		input.ExclusiveStartKey = av
+	input, err := q.scanInput(t, params)
+	if err != nil {
+		return nil, err
 	}
 
 	// Make the DynamoDB Query API call
@@ -465,15 +1171,17 @@ func (q *Queries) ScanItems(ctx context.Context, params QueryItemsParams) (*Scan
 	// get results
 	for _, res := range result.Items {
 		item := QueryRow{}
-		if err = attributevalue.UnmarshalMap(res, &item); err != nil {
-			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMap: %w", err))
+		if err = attributevalue.UnmarshalMapWithOptions(res, &item, q.decoderOpts...); err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
 		}
 		items = append(items, item)
 	}
 
 	scanResult := &ScanResults{
 		Rows:    items,
+		Count:   result.Count,
 		LastKey: result.LastEvaluatedKey,
+		HasMore: len(result.LastEvaluatedKey) > 0,
 	}
 
 	if params.PerPage != nil {
@@ -482,35 +1190,142 @@ func (q *Queries) ScanItems(ctx context.Context, params QueryItemsParams) (*Scan
 	return scanResult, nil
 }
 
-// QueryItems queries the given Table for items matching the given expression parameters.
-func (q *Queries) QueryItems(ctx context.Context, params QueryItemsParams) (*QueryResults, error) {
-	// get table
-	t := q.tables[params.TableName]
-	if t == nil {
-		return nil, NewTableNotFoundError(params.TableName)
+// startKey returns the ExclusiveStartKey for key: a map[string]types.AttributeValue
+// (e.g. a prior result's LastKey) is used as is, any other model is marshaled.
+// An empty key starts from the beginning.
+func (q *Queries) startKey(key any) (map[string]types.AttributeValue, error) {
+	if key == nil {
+		return nil, nil
+	}
+	if av, ok := key.(map[string]types.AttributeValue); ok {
+		if len(av) == 0 {
+			return nil, nil
+		}
+		return av, nil
 	}
 
-	items := make([]QueryRow, 0)
+	av, err := attributevalue.MarshalMapWithOptions(key, q.encoderOpts...)
+	if err != nil {
+		return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMapWithOptions: %w", err))
+	}
+	return av, nil
+}
 
-	// Build the query input parameters
+// readProjection returns the projection expression and attribute names for a query
+// or scan. Counts return no attributes, so only the caller's own projection, which
+// selectFor rejects, applies to them and the table's ProjectionFields are skipped.
+func (q *Queries) readProjection(t *Table, params QueryItemsParams) (*string, map[string]string) {
+	if params.Count {
+		return params.Expression.Projection(), params.Expression.Names()
+	}
+	return q.projection(t, params.Expression)
+}
+
+// selectFor returns the Select value for the given parameters and projection
+// expression from readProjection: COUNT when counting, SPECIFIC_ATTRIBUTES when a
+// projection is set and ALL_PROJECTED_ATTRIBUTES for index reads without one. Table
+// reads without a projection leave Select unset, which defaults to ALL_ATTRIBUTES.
+func selectFor(params QueryItemsParams, proj *string) (types.Select, error) {
+	switch {
+	case params.Count && proj != nil:
+		return "", goaws.NewClientError(errors.New("count can't be combined with a projection"))
+	case params.Count:
+		return types.SelectCount, nil
+	case proj != nil:
+		return types.SelectSpecificAttributes, nil
+	case params.IndexName != "":
+		return types.SelectAllProjectedAttributes, nil
+	default:
+		return "", nil
+	}
+}
+
+// scanInput builds the ScanInput for the given Table and expression parameters.
+func (q *Queries) scanInput(t *Table, params QueryItemsParams) (*dynamodb.ScanInput, error) {
+	// Build the scan input parameters
 	expr := params.Expression
-	input := &dynamodb.QueryInput{
-		KeyConditionExpression:    expr.KeyCondition(),
-		ExpressionAttributeNames:  expr.Names(),
+	input := &dynamodb.ScanInput{
 		ExpressionAttributeValues: expr.Values(),
 		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
 		TableName:                 aws.String(t.TableName),
 		Limit:                     params.PerPage,
 		ConsistentRead:            aws.Bool(params.ConsistentReads),
 	}
+	if params.IndexName != "" {
+		input.IndexName = aws.String(params.IndexName)
+	}
+	input.ProjectionExpression, input.ExpressionAttributeNames = q.readProjection(t, params)
+	sel, err := selectFor(params, input.ProjectionExpression)
+	if err != nil {
+		return nil, err
+	}
+	input.Select = sel
+
+	startKey, err := q.startKey(params.StartKey)
+	if err != nil {
+		return nil, err
+	}
+	input.ExclusiveStartKey = startKey
+
+	return input, nil
+}
+
+// ScanMissingAttribute scans every page of the given Table for items without the attr
+// attribute, e.g. to backfill a newly added field, and unmarshals them into model,
+// which must be a pointer to a slice.
+// ex: var users []User; err := q.ScanMissingAttribute(ctx, "users", "created_at", &users)
+func (q *Queries) ScanMissingAttribute(ctx context.Context, tableName, attr string, model any) error {
+	if model == nil {
+		return NewNilModelError()
+	}
+	if attr == "" {
+		return goaws.NewClientError(errors.New("empty attribute name"))
+	}
 
-	if params.StartKey != nil {
-		av, err := attributevalue.MarshalMap(params.StartKey)
+	// get table
+	t := q.getTable(tableName)
+	if t == nil {
+		return NewTableNotFoundError(tableName)
+	}
+
+	input := &dynamodb.ScanInput{
+		TableName:                aws.String(t.TableName),
+		FilterExpression:         aws.String("attribute_not_exists(#attr)"),
+		ExpressionAttributeNames: map[string]string{"#attr": attr},
+	}
+
+	rows := make([]map[string]types.AttributeValue, 0)
+	for {
+		result, err := q.svc.Scan(ctx, input)
 		if err != nil {
-			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalMap: %w", err))
+			return handleErr(fmt.Errorf("q.svc.Scan: %w", err))
 		}
-		input.ExclusiveStartKey = av
+		rows = append(rows, result.Items...)
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	if err := attributevalue.UnmarshalListOfMapsWithOptions(rows, model, q.decoderOpts...); err != nil {
+		return goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalListOfMapsWithOptions: %w", err))
+	}
+
+	return nil
+}
+
+// QueryItems queries the given Table for items matching the given expression parameters.
+func (q *Queries) QueryItems(ctx context.Context, params QueryItemsParams) (*QueryResults, error) {
+	// get table
+	t := q.getTable(params.TableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(params.TableName)
+	}
+
+	input, err := q.queryInput(t, params)
+	if err != nil {
+		return nil, err
 	}
 
 	// Make the DynamoDB Query API call
@@ -520,17 +1335,16 @@ func (q *Queries) QueryItems(ctx context.Context, params QueryItemsParams) (*Que
 	}
 
 	// get results
-	for _, res := range result.Items {
-		item := QueryRow{}
-		if err = attributevalue.UnmarshalMap(res, &item); err != nil {
-			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMap: %w", err))
-		}
-		items = append(items, item)
+	items, err := q.unmarshalRows(result.Items)
+	if err != nil {
+		return nil, err
 	}
 
 	queryResult := &QueryResults{
 		Rows:    items,
+		Count:   result.Count,
 		LastKey: result.LastEvaluatedKey,
+		HasMore: len(result.LastEvaluatedKey) > 0,
 	}
 
 	if params.PerPage != nil {
@@ -540,6 +1354,246 @@ func (q *Queries) QueryItems(ctx context.Context, params QueryItemsParams) (*Que
 	return queryResult, nil
 }
 
+// QueryItemsUntilLimit queries the given Table like QueryItems, but keeps reading pages until
+// limit rows have matched the filter expression or the query is exhausted. This avoids short
+// or empty pages when the filter discards most items, as Limit is applied before filtering.
+// LastKey is set to the key of the last returned row if more items may remain; the key
+// attributes are added to any projection so the key can be built. Querying an index
+// requires its key schema in the Table's Indexes.
+func (q *Queries) QueryItemsUntilLimit(ctx context.Context, params QueryItemsParams, limit int) (*QueryResults, error) {
+	if limit < 1 {
+		return nil, goaws.NewClientError(fmt.Errorf("invalid limit: %d", limit))
+	}
+
+	// get table
+	t := q.getTable(params.TableName)
+	if t == nil {
+		return nil, NewTableNotFoundError(params.TableName)
+	}
+	keys := []string{t.PrimaryKeyName, t.SortKeyName}
+	if params.IndexName != "" {
+		idx, ok := t.Indexes[params.IndexName]
+		if !ok {
+			return nil, goaws.NewClientError(fmt.Errorf("index key schema not found: %s", params.IndexName))
+		}
+		keys = append(keys, idx.PrimaryKeyName, idx.SortKeyName)
+	}
+
+	input, err := q.queryInput(t, params)
+	if err != nil {
+		return nil, err
+	}
+	input.ProjectionExpression, input.ExpressionAttributeNames = withAttributes(input.ProjectionExpression, input.ExpressionAttributeNames, keys)
+
+	raw := make([]map[string]types.AttributeValue, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		result, err := q.svc.Query(ctx, input)
+		if err != nil {
+			return nil, handleErr(fmt.Errorf("q.svc.Query: %w", err))
+		}
+
+		lastKey = result.LastEvaluatedKey
+		if n := limit - len(raw); len(result.Items) >= n {
+			raw = append(raw, result.Items[:n]...)
+			if len(result.Items) > n {
+				// resume after the last row returned rather than the end of the page
+				lastKey = resumeKey(raw[len(raw)-1], keys)
+			}
+			break
+		}
+		raw = append(raw, result.Items...)
+
+		if len(lastKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = lastKey
+	}
+
+	items, err := q.unmarshalRows(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := &QueryResults{
+		Rows:    items,
+		LastKey: lastKey,
+		HasMore: len(lastKey) > 0,
+	}
+
+	if params.PerPage != nil {
+		queryResult.PerPage = *params.PerPage
+	}
+
+	return queryResult, nil
+}
+
+func (q *Queries) queryInput(t *Table, params QueryItemsParams) (*dynamodb.QueryInput, error) {
+	// Build the query input parameters
+	expr := params.Expression
+	input := &dynamodb.QueryInput{
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(t.TableName),
+		Limit:                     params.PerPage,
+		ConsistentRead:            aws.Bool(params.ConsistentReads),
+	}
+	if params.IndexName != "" {
+		input.IndexName = aws.String(params.IndexName)
+	}
+	input.ProjectionExpression, input.ExpressionAttributeNames = q.readProjection(t, params)
+	sel, err := selectFor(params, input.ProjectionExpression)
+	if err != nil {
+		return nil, err
+	}
+	input.Select = sel
+
+	startKey, err := q.startKey(params.StartKey)
+	if err != nil {
+		return nil, err
+	}
+	input.ExclusiveStartKey = startKey
+
+	return input, nil
+}
+
+func (q *Queries) unmarshalRows(rows []map[string]types.AttributeValue) ([]QueryRow, error) {
+	items := make([]QueryRow, 0, len(rows))
+	for _, res := range rows {
+		item := QueryRow{}
+		if err := attributevalue.UnmarshalMapWithOptions(res, &item, q.decoderOpts...); err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// tableKey returns the primary key attributes of the given item.
+func tableKey(t *Table, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	key := map[string]types.AttributeValue{t.PrimaryKeyName: item[t.PrimaryKeyName]}
+	if t.SortKeyName != "" {
+		key[t.SortKeyName] = item[t.SortKeyName]
+	}
+	return key
+}
+
+// resumeKey returns the given key attributes of item, as needed to resume a query
+// after it. Empty attribute names are ignored.
+func resumeKey(item map[string]types.AttributeValue, keys []string) map[string]types.AttributeValue {
+	key := make(map[string]types.AttributeValue, len(keys))
+	for _, k := range keys {
+		if k != "" {
+			key[k] = item[k]
+		}
+	}
+	return key
+}
+
+// ExecuteStatement runs a PartiQL statement with the given positional parameters
+// and returns every row produced by the statement, following NextToken until all
+// pages have been read.
+// ex: q.ExecuteStatement(ctx, `SELECT * FROM "my_table" WHERE id = ?`, []any{"1"})
+func (q *Queries) ExecuteStatement(ctx context.Context, statement string, params []any) (*QueryResults, error) {
+	if statement == "" {
+		return nil, NewNilModelError()
+	}
+
+	avs, err := marshalParams(params, q.encoderOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.ExecuteStatementInput{
+		Statement: aws.String(statement),
+	}
+	if len(avs) > 0 {
+		input.Parameters = avs
+	}
+
+	items := make([]QueryRow, 0)
+	for {
+		result, err := q.svc.ExecuteStatement(ctx, input)
+		if err != nil {
+			return nil, handleErr(fmt.Errorf("q.svc.ExecuteStatement: %w", err))
+		}
+
+		for _, res := range result.Items {
+			item := QueryRow{}
+			if err = attributevalue.UnmarshalMapWithOptions(res, &item, q.decoderOpts...); err != nil {
+				return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+			}
+			items = append(items, item)
+		}
+
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	return &QueryResults{Rows: items}, nil
+}
+
+// BatchExecuteStatement runs up to 25 PartiQL statements in a single request.
+// Statements may mix reads and writes. One result is returned for each statement,
+// in the order given; a failed statement sets the Err field of its result
+// rather than failing the entire batch.
+func (q *Queries) BatchExecuteStatement(ctx context.Context, statements []BatchStatement) ([]BatchStatementResult, error) {
+	if len(statements) == 0 {
+		return nil, NewNilModelError()
+	}
+	if len(statements) > 25 {
+		return nil, NewCollectionSizeExceededError(len(statements))
+	}
+
+	reqs := make([]types.BatchStatementRequest, 0, len(statements))
+	for _, st := range statements {
+		avs, err := marshalParams(st.Params, q.encoderOpts...)
+		if err != nil {
+			return nil, err
+		}
+		req := types.BatchStatementRequest{
+			Statement: aws.String(st.Statement),
+		}
+		if len(avs) > 0 {
+			req.Parameters = avs
+		}
+		if st.ConsistentRead {
+			req.ConsistentRead = aws.Bool(true)
+		}
+		reqs = append(reqs, req)
+	}
+
+	input := &dynamodb.BatchExecuteStatementInput{
+		Statements: reqs,
+	}
+
+	result, err := q.svc.BatchExecuteStatement(ctx, input)
+	if err != nil {
+		return nil, handleErr(fmt.Errorf("q.svc.BatchExecuteStatement: %w", err))
+	}
+
+	results := make([]BatchStatementResult, 0, len(result.Responses))
+	for _, res := range result.Responses {
+		r := BatchStatementResult{}
+		if res.Error != nil {
+			r.Err = batchStatementErr(res.Error)
+		}
+		if res.Item != nil {
+			item := QueryRow{}
+			if err = attributevalue.UnmarshalMapWithOptions(res.Item, &item, q.decoderOpts...); err != nil {
+				return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.UnmarshalMapWithOptions: %w", err))
+			}
+			r.Item = item
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
 func (q *Queries) batchWriteUtil(ctx context.Context, input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
 	result, err := q.svc.BatchWriteItem(ctx, input)
 	if err != nil {
@@ -575,7 +1629,11 @@ func handleErr(err error) error {
 		case errors.As(err, &requestLimitExceeded):
 			return NewRateLimitExceededError()
 		case errors.As(err, &conditionalCheckFailed):
-			return NewConditionCheckFailedError(conditionalCheckFailed.ErrorMessage())
+			e := NewConditionCheckFailedError(conditionalCheckFailed.ErrorMessage())
+			e.Item = conditionalCheckFailed.Item
+			return e
+		case goaws.IsAccessDenied(err):
+			return goaws.NewAccessDeniedError(err)
 		default:
 			return goaws.NewInternalError(err)
 		}
@@ -583,6 +1641,69 @@ func handleErr(err error) error {
 	return nil
 }
 
+// batchStatementErr converts a per-statement error returned by BatchExecuteStatement
+// to the corresponding error type.
+func batchStatementErr(e *types.BatchStatementError) error {
+	msg := aws.ToString(e.Message)
+	switch e.Code {
+	case types.BatchStatementErrorCodeEnumConditionalCheckFailed:
+		return NewConditionCheckFailedError(msg)
+	case types.BatchStatementErrorCodeEnumResourceNotFound:
+		return NewResourceNotFoundError(msg)
+	case types.BatchStatementErrorCodeEnumItemCollectionSizeLimitExceeded:
+		return NewCollectionSizeExceededError(0)
+	case types.BatchStatementErrorCodeEnumProvisionedThroughputExceeded,
+		types.BatchStatementErrorCodeEnumRequestLimitExceeded,
+		types.BatchStatementErrorCodeEnumThrottlingError:
+		return NewRateLimitExceededError()
+	case types.BatchStatementErrorCodeEnumTransactionConflict:
+		return NewTxConflictError()
+	case types.BatchStatementErrorCodeEnumInternalServerError:
+		return goaws.NewRetryableInternalError(fmt.Errorf("%s: %s", e.Code, msg))
+	default:
+		return goaws.NewClientError(fmt.Errorf("%s: %s", e.Code, msg))
+	}
+}
+
+// marshalParams marshals a list of PartiQL positional parameters.
+func marshalParams(params []any, optFns ...func(*attributevalue.EncoderOptions)) ([]types.AttributeValue, error) {
+	avs := make([]types.AttributeValue, 0, len(params))
+	for _, p := range params {
+		av, err := attributevalue.MarshalWithOptions(p, optFns...)
+		if err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("attributevalue.MarshalWithOptions: %w", err))
+		}
+		avs = append(avs, av)
+	}
+	return avs, nil
+}
+
+// itemKey returns a string identifying the item by its primary and sort key values.
+// Each value is prefixed with its length, so distinct keys can't produce the same string.
+func itemKey(item map[string]types.AttributeValue, t *Table) string {
+	pk := avString(item[t.PrimaryKeyName])
+	key := strconv.Itoa(len(pk)) + ":" + pk
+	if t.SortKeyName != "" {
+		sk := avString(item[t.SortKeyName])
+		key += strconv.Itoa(len(sk)) + ":" + sk
+	}
+	return key
+}
+
+// avString returns a string representation of a key attribute value.
+func avString(av types.AttributeValue) string {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S:" + v.Value
+	case *types.AttributeValueMemberN:
+		return "N:" + v.Value
+	case *types.AttributeValueMemberB:
+		return "B:" + string(v.Value)
+	default:
+		return fmt.Sprintf("%T:%v", av, av)
+	}
+}
+
 // marshalMap marshals an interface object into an AttributeValue map
 func marshalMap(input any) (map[string]types.AttributeValue, error) {
 	marshal, err := attributevalue.MarshalMap(input)
@@ -41,6 +41,26 @@ func (m *MockDynamoDBQueriesClientAPI) EXPECT() *MockDynamoDBQueriesClientAPIMoc
 	return m.recorder
 }
 
+// BatchExecuteStatement mocks base method.
+func (m *MockDynamoDBQueriesClientAPI) BatchExecuteStatement(ctx context.Context, params *dynamodb.BatchExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchExecuteStatementOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchExecuteStatement", varargs...)
+	ret0, _ := ret[0].(*dynamodb.BatchExecuteStatementOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchExecuteStatement indicates an expected call of BatchExecuteStatement.
+func (mr *MockDynamoDBQueriesClientAPIMockRecorder) BatchExecuteStatement(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchExecuteStatement", reflect.TypeOf((*MockDynamoDBQueriesClientAPI)(nil).BatchExecuteStatement), varargs...)
+}
+
 // BatchGetItem mocks base method.
 func (m *MockDynamoDBQueriesClientAPI) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +121,26 @@ func (mr *MockDynamoDBQueriesClientAPIMockRecorder) DeleteItem(ctx, params any,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockDynamoDBQueriesClientAPI)(nil).DeleteItem), varargs...)
 }
 
+// ExecuteStatement mocks base method.
+func (m *MockDynamoDBQueriesClientAPI) ExecuteStatement(ctx context.Context, params *dynamodb.ExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecuteStatement", varargs...)
+	ret0, _ := ret[0].(*dynamodb.ExecuteStatementOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteStatement indicates an expected call of ExecuteStatement.
+func (mr *MockDynamoDBQueriesClientAPIMockRecorder) ExecuteStatement(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteStatement", reflect.TypeOf((*MockDynamoDBQueriesClientAPI)(nil).ExecuteStatement), varargs...)
+}
+
 // GetItem mocks base method.
 func (m *MockDynamoDBQueriesClientAPI) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
 	m.ctrl.T.Helper()
@@ -2,11 +2,24 @@ package godynamo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,67 +128,59 @@ func TestQueries_CreateItem(t *testing.T) {
 	}
 }
 
-func TestQueries_GetItem(t *testing.T) {
-	type TestItem struct {
-		ID   string `json:"id"`
-		Data string `json:"data"`
-	}
-
+func TestQueries_CreateItemIfNotExists(t *testing.T) {
 	tests := []struct {
 		name          string
 		tableName     string
-		query         *Query
+		item          any
 		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
-		expectedItem  *TestItem
 		expectedError error
 	}{
 		{
 			name:      "Success",
 			tableName: "test-table",
-			query:     CreateNewQueryObj("1", nil),
+			item:      map[string]interface{}{"id": "1", "data": "value"},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{
-					Item: map[string]types.AttributeValue{
-						"id":   &types.AttributeValueMemberS{Value: "1"},
-						"data": &types.AttributeValueMemberS{Value: "value"},
-					},
-				}, nil).Times(1)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, "attribute_not_exists(#key)", aws.ToString(in.ConditionExpression))
+						assert.Equal(t, map[string]string{"#key": "id"}, in.ExpressionAttributeNames)
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
 				return m
 			},
-			expectedItem:  &TestItem{ID: "1", Data: "value"},
 			expectedError: nil,
 		},
 		{
-			name:      "TableNotFound",
-			tableName: "missing-table",
-			query:     CreateNewQueryObj("1", nil),
+			name:      "ItemExists",
+			tableName: "test-table",
+			item:      map[string]interface{}{"id": "1", "data": "value"},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				return NewMockDynamoDBQueriesClientAPI(ctrl)
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ConditionalCheckFailedException{
+					Message: aws.String("The conditional request failed"),
+				}).Times(1)
+				return m
 			},
-			expectedItem:  &TestItem{},
-			expectedError: NewTableNotFoundError("missing-table"),
+			expectedError: NewConditionCheckFailedError("The conditional request failed"),
 		},
 		{
-			name:      "Error",
-			tableName: "test-table",
-			query:     CreateNewQueryObj("1", nil),
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			item:      map[string]interface{}{"id": "1"},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("get error")).Times(1)
-				return m
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
-			expectedItem:  &TestItem{},
-			expectedError: goaws.NewInternalError(errors.New("q.svc.GetItem: get error")),
+			expectedError: NewTableNotFoundError("missing-table"),
 		},
 		{
-			name:      "NilQuery",
+			name:      "NilItem",
 			tableName: "test-table",
-			query:     nil,
+			item:      nil,
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
-			expectedItem:  &TestItem{},
 			expectedError: NewNilModelError(),
 		},
 	}
@@ -191,22 +196,12 @@ func TestQueries_GetItem(t *testing.T) {
 			// Setup tables map
 			tables := map[string]*Table{}
 			if tt.tableName == "test-table" {
-				tables["test-table"] = &Table{
-					TableName:      "test-table",
-					PrimaryKeyName: "id",
-					PrimaryKeyType: "S",
-				}
+				tables["test-table"] = &Table{TableName: "test-table", PrimaryKeyName: "id"}
 			}
 
 			q := NewQueries(mockSvc, tables, nil)
 
-			item := &TestItem{}
-			err := q.GetItem(context.Background(), GetItemParams{
-				Query:      tt.query,
-				TableName:  tt.tableName,
-				ItemPtr:    item,
-				Expression: NewExpression(),
-			})
+			err := q.CreateItemIfNotExists(context.Background(), tt.item, tt.tableName, "id")
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -214,54 +209,100 @@ func TestQueries_GetItem(t *testing.T) {
 				assert.Implements(t, (*goaws.AwsError)(nil), err)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, tt.expectedItem, item)
 			}
 		})
 	}
 }
 
-func TestQueries_UpdateItem(t *testing.T) {
+func TestQueries_CreateItemWithParams(t *testing.T) {
+	cond := NewCondition()
+	cond.Equal("version", 1)
+	eb := NewExprBuilder()
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
 	tests := []struct {
 		name          string
-		tableName     string
-		query         *Query
-		expr          Expression
+		params        CreateItemParams
 		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
 		expectedError error
 	}{
 		{
-			name:      "Success",
-			tableName: "test-table",
-			query:     CreateNewQueryObj("1", nil),
-			expr:      NewExpression(),
+			name: "Success",
+			params: CreateItemParams{
+				Item:       map[string]interface{}{"id": "1", "version": 2},
+				TableName:  "test-table",
+				Expression: expr,
+			},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, nil).Times(1)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, "#0 = :0", aws.ToString(in.ConditionExpression))
+						assert.Equal(t, map[string]string{"#0": "version"}, in.ExpressionAttributeNames)
+						assert.Equal(t, &types.AttributeValueMemberN{Value: "1"}, in.ExpressionAttributeValues[":0"])
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
 				return m
 			},
 			expectedError: nil,
 		},
 		{
-			name:      "TableNotFound",
-			tableName: "missing-table",
-			query:     CreateNewQueryObj("1", nil),
-			expr:      NewExpression(),
+			name: "NoExpression",
+			params: CreateItemParams{
+				Item:      map[string]interface{}{"id": "1"},
+				TableName: "test-table",
+			},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				return NewMockDynamoDBQueriesClientAPI(ctrl)
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Nil(t, in.ConditionExpression)
+						assert.Nil(t, in.ExpressionAttributeNames)
+						assert.Nil(t, in.ExpressionAttributeValues)
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
+				return m
 			},
-			expectedError: NewTableNotFoundError("missing-table"),
+			expectedError: nil,
 		},
 		{
-			name:      "Error",
-			tableName: "test-table",
-			query:     CreateNewQueryObj("1", nil),
-			expr:      NewExpression(),
+			name: "ConditionCheckFailed",
+			params: CreateItemParams{
+				Item:       map[string]interface{}{"id": "1", "version": 2},
+				TableName:  "test-table",
+				Expression: expr,
+			},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("update error")).Times(1)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ConditionalCheckFailedException{
+					Message: aws.String("The conditional request failed"),
+				}).Times(1)
 				return m
 			},
-			expectedError: goaws.NewInternalError(errors.New("q.svc.UpdateItem: update error")),
+			expectedError: NewConditionCheckFailedError("The conditional request failed"),
+		},
+		{
+			name: "TableNotFound",
+			params: CreateItemParams{
+				Item:      map[string]interface{}{"id": "1"},
+				TableName: "missing-table",
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name: "NilItem",
+			params: CreateItemParams{
+				TableName: "test-table",
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewNilModelError(),
 		},
 	}
 
@@ -271,21 +312,12 @@ func TestQueries_UpdateItem(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockSvc := tt.mockSetup(ctrl)
-
-			// Setup tables map
-			tables := map[string]*Table{}
-			if tt.tableName == "test-table" {
-				tables["test-table"] = &Table{
-					TableName:      "test-table",
-					PrimaryKeyName: "id",
-					PrimaryKeyType: "S",
-				}
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
 			}
+			q := NewQueries(tt.mockSetup(ctrl), tables, nil)
 
-			q := NewQueries(mockSvc, tables, nil)
-
-			err := q.UpdateItem(context.Background(), tt.query, tt.tableName, tt.expr)
+			err := q.CreateItemWithParams(context.Background(), tt.params)
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -298,44 +330,112 @@ func TestQueries_UpdateItem(t *testing.T) {
 	}
 }
 
-func TestQueries_DeleteItem(t *testing.T) {
+func TestQueries_CreateItemWithParams_Idempotency(t *testing.T) {
+	cond := NewCondition()
+	cond.Equal("version", 1)
+	eb := NewExprBuilder()
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	item := map[string]interface{}{"id": "1", "status": "new"}
+	stored := func(attr, token string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"id":     &types.AttributeValueMemberS{Value: "1"},
+			"status": &types.AttributeValueMemberS{Value: "stored"},
+			attr:     &types.AttributeValueMemberS{Value: token},
+		}
+	}
+	conditionFailed := &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+	expectGetItem := func(m *MockDynamoDBQueriesClientAPI, out map[string]types.AttributeValue) {
+		m.EXPECT().GetItem(gomock.Any(), &dynamodb.GetItemInput{
+			TableName:      aws.String("test-table"),
+			Key:            map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}},
+			ConsistentRead: aws.Bool(true),
+		}, gomock.Any()).Return(&dynamodb.GetItemOutput{Item: out}, nil).Times(1)
+	}
+
 	tests := []struct {
-		name          string
-		tableName     string
-		query         *Query
-		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
-		expectedError error
+		name             string
+		params           CreateItemParams
+		mockSetup        func(m *MockDynamoDBQueriesClientAPI)
+		expectedExisting map[string]any
+		expectedError    error
 	}{
 		{
-			name:      "Success",
-			tableName: "test-table",
-			query:     CreateNewQueryObj("1", nil),
-			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil).Times(1)
-				return m
+			name:   "FirstWrite",
+			params: CreateItemParams{Item: item, TableName: "test-table", IdempotencyToken: "token-1"},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "token-1"}, in.Item[DefaultIdempotencyAttribute])
+						assert.Equal(t, "attribute_not_exists(#idemKey)", aws.ToString(in.ConditionExpression))
+						assert.Equal(t, map[string]string{"#idemKey": "id"}, in.ExpressionAttributeNames)
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
 			},
-			expectedError: nil,
+			expectedExisting: map[string]any{},
 		},
 		{
-			name:      "TableNotFound",
-			tableName: "missing-table",
-			query:     CreateNewQueryObj("1", nil),
-			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			name:   "FirstWriteWithCondition",
+			params: CreateItemParams{Item: item, TableName: "test-table", Expression: expr, IdempotencyToken: "token-1", IdempotencyAttribute: "request_id"},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "token-1"}, in.Item["request_id"])
+						assert.NotContains(t, in.Item, DefaultIdempotencyAttribute)
+						assert.Equal(t, "(#0 = :0) AND attribute_not_exists(#idemKey)", aws.ToString(in.ConditionExpression))
+						assert.Equal(t, map[string]string{"#0": "version", "#idemKey": "id"}, in.ExpressionAttributeNames)
+						assert.Equal(t, &types.AttributeValueMemberN{Value: "1"}, in.ExpressionAttributeValues[":0"])
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
 			},
-			expectedError: NewTableNotFoundError("missing-table"),
+			expectedExisting: map[string]any{},
 		},
 		{
-			name:      "Error",
-			tableName: "test-table",
-			query:     CreateNewQueryObj("1", nil),
-			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("delete error")).Times(1)
-				return m
+			name:   "DuplicateRetry",
+			params: CreateItemParams{Item: item, TableName: "test-table", IdempotencyToken: "token-1"},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, conditionFailed).Times(1)
+				expectGetItem(m, stored(DefaultIdempotencyAttribute, "token-1"))
 			},
-			expectedError: goaws.NewInternalError(errors.New("q.svc.DeleteItem: delete error")),
+			expectedExisting: map[string]any{"id": "1", "status": "stored", DefaultIdempotencyAttribute: "token-1"},
+		},
+		{
+			name:   "DuplicateRetryFailOnDuplicate",
+			params: CreateItemParams{Item: item, TableName: "test-table", IdempotencyToken: "token-1", FailOnDuplicate: true},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, conditionFailed).Times(1)
+				expectGetItem(m, stored(DefaultIdempotencyAttribute, "token-1"))
+			},
+			expectedError: NewDuplicateCreateError("token-1"),
+		},
+		{
+			name:   "DifferentToken",
+			params: CreateItemParams{Item: item, TableName: "test-table", IdempotencyToken: "token-2"},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, conditionFailed).Times(1)
+				expectGetItem(m, stored(DefaultIdempotencyAttribute, "token-1"))
+			},
+			expectedError: NewConditionCheckFailedError("The conditional request failed"),
+		},
+		{
+			name:   "ConditionFailedItemMissing",
+			params: CreateItemParams{Item: item, TableName: "test-table", Expression: expr, IdempotencyToken: "token-1"},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, conditionFailed).Times(1)
+				expectGetItem(m, nil)
+			},
+			expectedError: NewConditionCheckFailedError("The conditional request failed"),
+		},
+		{
+			name:   "GetItemError",
+			params: CreateItemParams{Item: item, TableName: "test-table", IdempotencyToken: "token-1"},
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, conditionFailed).Times(1)
+				m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("get fail")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.GetItem: get fail")),
 		},
 	}
 
@@ -345,70 +445,87 @@ func TestQueries_DeleteItem(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockSvc := tt.mockSetup(ctrl)
-
-			// Setup tables map
-			tables := map[string]*Table{}
-			if tt.tableName == "test-table" {
-				tables["test-table"] = &Table{
-					TableName:      "test-table",
-					PrimaryKeyName: "id",
-					PrimaryKeyType: "S",
-				}
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(m)
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
 			}
+			q := NewQueries(m, tables, nil)
 
-			q := NewQueries(mockSvc, tables, nil)
-
-			err := q.DeleteItem(context.Background(), tt.query, tt.tableName)
+			existing := map[string]any{}
+			params := tt.params
+			params.ExistingItemPtr = &existing
+			err := q.CreateItemWithParams(context.Background(), params)
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
 				assert.EqualError(t, err, tt.expectedError.Error())
 				assert.Implements(t, (*goaws.AwsError)(nil), err)
-			} else {
-				require.NoError(t, err)
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedExisting, existing)
 		})
 	}
 }
 
-func TestQueries_BatchWriteCreate(t *testing.T) {
-	type TestItem struct {
-		ID   string `json:"id"`
-		Data string `json:"data"`
-	}
+func TestQueries_CreateItemWithTTL(t *testing.T) {
+	expireAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name          string
 		tableName     string
-		items         []any
+		item          any
+		ttlAttr       string
 		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
 		expectedError error
 	}{
 		{
 			name:      "Success",
 			tableName: "test-table",
-			items:     []any{TestItem{ID: "1", Data: "a"}},
+			item:      map[string]any{"id": "1", "data": "value"},
+			ttlAttr:   "expires_at",
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{}, nil).Times(1)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, &types.AttributeValueMemberN{Value: "1893456000"}, in.Item["expires_at"])
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "1"}, in.Item["id"])
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "value"}, in.Item["data"])
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
 				return m
 			},
-			expectedError: nil,
 		},
 		{
-			name:      "TooManyItems",
+			name:      "OverwritesExistingAttr",
 			tableName: "test-table",
-			items:     make([]any, 26),
+			item:      map[string]any{"id": "1", "expires_at": "soon"},
+			ttlAttr:   "expires_at",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, &types.AttributeValueMemberN{Value: "1893456000"}, in.Item["expires_at"])
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
+				return m
+			},
+		},
+		{
+			name:      "MissingTTLAttr",
+			tableName: "test-table",
+			item:      map[string]any{"id": "1"},
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
-			expectedError: NewCollectionSizeExceededError(26),
+			expectedError: NewNilModelError(),
 		},
 		{
 			name:      "TableNotFound",
 			tableName: "missing-table",
-			items:     []any{TestItem{ID: "1", Data: "a"}},
+			item:      map[string]any{"id": "1"},
+			ttlAttr:   "expires_at",
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
@@ -417,22 +534,14 @@ func TestQueries_BatchWriteCreate(t *testing.T) {
 		{
 			name:      "Error",
 			tableName: "test-table",
-			items:     []any{TestItem{ID: "1", Data: "a"}},
+			item:      map[string]any{"id": "1"},
+			ttlAttr:   "expires_at",
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("batch error")).Times(1)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("put error")).Times(1)
 				return m
 			},
-			expectedError: goaws.NewInternalError(errors.New("q.batchWriteUtil: q.svc.BatchWriteItem: batch error")),
-		},
-		{
-			name:      "NilItems",
-			tableName: "test-table",
-			items:     nil,
-			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				return NewMockDynamoDBQueriesClientAPI(ctrl)
-			},
-			expectedError: NewNilModelError(),
+			expectedError: errors.New("q.svc.PutItem: put error"),
 		},
 	}
 
@@ -442,28 +551,17 @@ func TestQueries_BatchWriteCreate(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockSvc := tt.mockSetup(ctrl)
-
-			// Setup tables map
-			tables := map[string]*Table{}
-			if tt.tableName == "test-table" {
-				tables["test-table"] = &Table{
-					TableName:      "test-table",
-					PrimaryKeyName: "id",
-					PrimaryKeyType: "S",
-				}
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
 			}
+			q := NewQueries(tt.mockSetup(ctrl), tables, nil)
 
-			q := NewQueries(mockSvc, tables, nil)
-
-			err := q.BatchWriteCreate(context.Background(), tt.tableName, tt.items)
+			err := q.CreateItemWithTTL(context.Background(), tt.item, tt.tableName, tt.ttlAttr, expireAt)
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
 				assert.EqualError(t, err, tt.expectedError.Error())
-
-				var awsErr goaws.AwsError
-				assert.Equal(t, true, errors.As(err, &awsErr))
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
 			} else {
 				require.NoError(t, err)
 			}
@@ -471,55 +569,91 @@ func TestQueries_BatchWriteCreate(t *testing.T) {
 	}
 }
 
-func TestQueries_ScanItems(t *testing.T) {
+func TestQueries_PutRawItem(t *testing.T) {
+	cond := NewCondition()
+	cond.Equal("version", 1)
+	eb := NewExprBuilder()
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	item := map[string]types.AttributeValue{
+		"id":      &types.AttributeValueMemberS{Value: "1"},
+		"version": &types.AttributeValueMemberN{Value: "2"},
+		"tags":    &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+	}
+
 	tests := []struct {
 		name          string
-		params        QueryItemsParams
+		tableName     string
+		item          map[string]types.AttributeValue
+		expr          Expression
 		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
 		expectedError error
 	}{
 		{
-			name: "Success",
-			params: QueryItemsParams{
-				TableName:  "test-table",
-				Expression: NewExpression(),
+			name:      "Success",
+			tableName: "test-table",
+			item:      item,
+			expr:      expr,
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, "test-table", aws.ToString(in.TableName))
+						assert.Equal(t, item, in.Item)
+						assert.Equal(t, "#0 = :0", aws.ToString(in.ConditionExpression))
+						assert.Equal(t, map[string]string{"#0": "version"}, in.ExpressionAttributeNames)
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
+				return m
 			},
+		},
+		{
+			name:      "NoExpression",
+			tableName: "test-table",
+			item:      item,
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.ScanOutput{
-					Items: []map[string]types.AttributeValue{
-						{
-							"id": &types.AttributeValueMemberS{Value: "1"},
-						},
-					},
-				}, nil).Times(1)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Nil(t, in.ConditionExpression)
+						assert.Nil(t, in.ExpressionAttributeNames)
+						return &dynamodb.PutItemOutput{}, nil
+					}).Times(1)
 				return m
 			},
-			expectedError: nil,
 		},
 		{
-			name: "TableNotFound",
-			params: QueryItemsParams{
-				TableName:  "missing-table",
-				Expression: NewExpression(),
+			name:      "ConditionCheckFailed",
+			tableName: "test-table",
+			item:      item,
+			expr:      expr,
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ConditionalCheckFailedException{
+					Message: aws.String("The conditional request failed"),
+				}).Times(1)
+				return m
 			},
+			expectedError: NewConditionCheckFailedError("The conditional request failed"),
+		},
+		{
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			item:      item,
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
 			expectedError: NewTableNotFoundError("missing-table"),
 		},
 		{
-			name: "Error",
-			params: QueryItemsParams{
-				TableName:  "test-table",
-				Expression: NewExpression(),
-			},
+			name:      "EmptyItem",
+			tableName: "test-table",
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
-				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("scan error")).Times(1)
-				return m
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
-			expectedError: goaws.NewInternalError(errors.New("q.svc.Scan: scan error")),
+			expectedError: NewNilModelError(),
 		},
 	}
 
@@ -529,86 +663,86 @@ func TestQueries_ScanItems(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockSvc := tt.mockSetup(ctrl)
-
-			// Setup tables map
-			tables := map[string]*Table{}
-			if tt.params.TableName == "test-table" {
-				tables["test-table"] = &Table{
-					TableName:      "test-table",
-					PrimaryKeyName: "id",
-					PrimaryKeyType: "S",
-				}
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
 			}
+			q := NewQueries(tt.mockSetup(ctrl), tables, nil)
 
-			q := NewQueries(mockSvc, tables, nil)
-
-			res, err := q.ScanItems(context.Background(), tt.params)
+			err := q.PutRawItem(context.Background(), tt.tableName, tt.item, tt.expr)
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
 				assert.EqualError(t, err, tt.expectedError.Error())
 				assert.Implements(t, (*goaws.AwsError)(nil), err)
-				assert.Nil(t, res)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, res)
-				assert.Len(t, res.Rows, 1)
-				assert.Equal(t, "1", res.Rows[0]["id"])
 			}
 		})
 	}
 }
 
-func TestQueries_QueryItems(t *testing.T) {
+func TestQueries_GetItem(t *testing.T) {
+	type TestItem struct {
+		ID   string `json:"id"`
+		Data string `json:"data"`
+	}
+
 	tests := []struct {
 		name          string
-		params        QueryItemsParams
+		tableName     string
+		query         *Query
 		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedItem  *TestItem
 		expectedError error
 	}{
 		{
-			name: "Success",
-			params: QueryItemsParams{
-				TableName:  "test-table",
-				Expression: NewExpression(),
-			},
+			name:      "Success",
+			tableName: "test-table",
+			query:     CreateNewQueryObj("1", nil),
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
-					Items: []map[string]types.AttributeValue{
-						{
-							"id": &types.AttributeValueMemberS{Value: "1"},
-						},
+				m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{
+					Item: map[string]types.AttributeValue{
+						"id":   &types.AttributeValueMemberS{Value: "1"},
+						"data": &types.AttributeValueMemberS{Value: "value"},
 					},
 				}, nil).Times(1)
 				return m
 			},
+			expectedItem:  &TestItem{ID: "1", Data: "value"},
 			expectedError: nil,
 		},
 		{
-			name: "TableNotFound",
-			params: QueryItemsParams{
-				TableName:  "missing-table",
-				Expression: NewExpression(),
-			},
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			query:     CreateNewQueryObj("1", nil),
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				return NewMockDynamoDBQueriesClientAPI(ctrl)
 			},
+			expectedItem:  &TestItem{},
 			expectedError: NewTableNotFoundError("missing-table"),
 		},
 		{
-			name: "Error",
-			params: QueryItemsParams{
-				TableName:  "test-table",
-				Expression: NewExpression(),
-			},
+			name:      "Error",
+			tableName: "test-table",
+			query:     CreateNewQueryObj("1", nil),
 			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
 				m := NewMockDynamoDBQueriesClientAPI(ctrl)
-				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("query error")).Times(1)
+				m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("get error")).Times(1)
 				return m
 			},
-			expectedError: goaws.NewInternalError(errors.New("q.svc.Query: query error")),
+			expectedItem:  &TestItem{},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.GetItem: get error")),
+		},
+		{
+			name:      "NilQuery",
+			tableName: "test-table",
+			query:     nil,
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedItem:  &TestItem{},
+			expectedError: NewNilModelError(),
 		},
 	}
 
@@ -622,7 +756,7 @@ func TestQueries_QueryItems(t *testing.T) {
 
 			// Setup tables map
 			tables := map[string]*Table{}
-			if tt.params.TableName == "test-table" {
+			if tt.tableName == "test-table" {
 				tables["test-table"] = &Table{
 					TableName:      "test-table",
 					PrimaryKeyName: "id",
@@ -632,19 +766,2730 @@ func TestQueries_QueryItems(t *testing.T) {
 
 			q := NewQueries(mockSvc, tables, nil)
 
-			res, err := q.QueryItems(context.Background(), tt.params)
+			item := &TestItem{}
+			err := q.GetItem(context.Background(), GetItemParams{
+				Query:      tt.query,
+				TableName:  tt.tableName,
+				ItemPtr:    item,
+				Expression: NewExpression(),
+			})
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
 				assert.EqualError(t, err, tt.expectedError.Error())
 				assert.Implements(t, (*goaws.AwsError)(nil), err)
-				assert.Nil(t, res)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, res)
-				assert.Len(t, res.Rows, 1)
-				assert.Equal(t, "1", res.Rows[0]["id"])
+				assert.Equal(t, tt.expectedItem, item)
 			}
 		})
 	}
 }
+
+func TestQueries_DefaultProjection(t *testing.T) {
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S", ProjectionFields: []string{"id", "name"}},
+	}
+
+	eb := NewExprBuilder()
+	eb.SetProjection([]string{"status"})
+	override, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	defaultProjection := aws.String("#proj0, #proj1")
+	defaultNames := map[string]string{"#proj0": "id", "#proj1": "name"}
+
+	tests := []struct {
+		name               string
+		expr               Expression
+		expectedProjection *string
+		expectedNames      map[string]string
+	}{
+		{
+			name:               "Default",
+			expr:               NewExpression(),
+			expectedProjection: defaultProjection,
+			expectedNames:      defaultNames,
+		},
+		{
+			name:               "Override",
+			expr:               override,
+			expectedProjection: override.Projection(),
+			expectedNames:      override.Names(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+					assert.Equal(t, tt.expectedProjection, in.ProjectionExpression)
+					assert.Equal(t, tt.expectedNames, in.ExpressionAttributeNames)
+					return &dynamodb.GetItemOutput{}, nil
+				}).Times(1)
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+					assert.Equal(t, tt.expectedProjection, in.ProjectionExpression)
+					assert.Equal(t, tt.expectedNames, in.ExpressionAttributeNames)
+					return &dynamodb.QueryOutput{}, nil
+				}).Times(1)
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+					assert.Equal(t, tt.expectedProjection, in.ProjectionExpression)
+					assert.Equal(t, tt.expectedNames, in.ExpressionAttributeNames)
+					return &dynamodb.ScanOutput{}, nil
+				}).Times(1)
+
+			q := NewQueries(m, tables, nil)
+
+			var item map[string]any
+			require.NoError(t, q.GetItem(context.Background(), GetItemParams{
+				Query:      CreateNewQueryObj("1", nil),
+				TableName:  "test-table",
+				ItemPtr:    &item,
+				Expression: tt.expr,
+			}))
+			_, err := q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table", Expression: tt.expr})
+			require.NoError(t, err)
+			_, err = q.ScanItems(context.Background(), QueryItemsParams{TableName: "test-table", Expression: tt.expr})
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("KeepsExpressionNames", func(t *testing.T) {
+		t.Parallel()
+		eb := NewExprBuilder()
+		eb.SetFilter("status", "active")
+		filter, err := eb.BuildExpression()
+		require.NoError(t, err)
+
+		proj, names := projection(tables["test-table"], filter)
+		assert.Equal(t, defaultProjection, proj)
+		assert.Equal(t, map[string]string{"#0": "status", "#proj0": "id", "#proj1": "name"}, names)
+	})
+}
+
+func TestQueries_EmptyExpression(t *testing.T) {
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	tests := []struct {
+		name string
+		expr Expression
+	}{
+		{name: "NewExpression", expr: NewExpression()},
+		{name: "ZeroValue", expr: Expression{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+					assert.Nil(t, in.ProjectionExpression)
+					assert.Nil(t, in.ExpressionAttributeNames)
+					return &dynamodb.GetItemOutput{}, nil
+				}).Times(1)
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+					assert.Nil(t, in.KeyConditionExpression)
+					assert.Nil(t, in.FilterExpression)
+					assert.Nil(t, in.ProjectionExpression)
+					assert.Nil(t, in.ExpressionAttributeNames)
+					assert.Nil(t, in.ExpressionAttributeValues)
+					return &dynamodb.QueryOutput{}, nil
+				}).Times(1)
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+					assert.Nil(t, in.FilterExpression)
+					assert.Nil(t, in.ProjectionExpression)
+					assert.Nil(t, in.ExpressionAttributeNames)
+					assert.Nil(t, in.ExpressionAttributeValues)
+					return &dynamodb.ScanOutput{}, nil
+				}).Times(1)
+
+			q := NewQueries(m, tables, nil)
+
+			var item map[string]any
+			require.NoError(t, q.GetItem(context.Background(), GetItemParams{
+				Query:      CreateNewQueryObj("1", nil),
+				TableName:  "test-table",
+				ItemPtr:    &item,
+				Expression: tt.expr,
+			}))
+			_, err := q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table", Expression: tt.expr})
+			require.NoError(t, err)
+			_, err = q.ScanItems(context.Background(), QueryItemsParams{TableName: "test-table", Expression: tt.expr})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestQueries_WithProjectedKeys(t *testing.T) {
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S", SortKeyName: "created", SortKeyType: "N"},
+	}
+
+	build := func(names ...string) Expression {
+		eb := NewExprBuilder()
+		eb.SetProjection(names)
+		expr, err := eb.BuildExpression()
+		require.NoError(t, err)
+		return expr
+	}
+
+	tests := []struct {
+		name               string
+		expr               Expression
+		expectedProjection *string
+		expectedNames      map[string]string
+	}{
+		{
+			name:               "OmitsKeys",
+			expr:               build("status"),
+			expectedProjection: aws.String("#0, #projKey0, #projKey1"),
+			expectedNames:      map[string]string{"#0": "status", "#projKey0": "id", "#projKey1": "created"},
+		},
+		{
+			name:               "OmitsSortKey",
+			expr:               build("id", "status"),
+			expectedProjection: aws.String("#0, #1, #projKey0"),
+			expectedNames:      map[string]string{"#0": "id", "#1": "status", "#projKey0": "created"},
+		},
+		{
+			name:               "IncludesKeys",
+			expr:               build("created", "id"),
+			expectedProjection: aws.String("#0, #1"),
+			expectedNames:      map[string]string{"#0": "created", "#1": "id"},
+		},
+		{
+			name:               "NoProjection",
+			expr:               NewExpression(),
+			expectedProjection: nil,
+			expectedNames:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+					assert.Equal(t, tt.expectedProjection, in.ProjectionExpression)
+					assert.Equal(t, tt.expectedNames, in.ExpressionAttributeNames)
+					return &dynamodb.QueryOutput{}, nil
+				}).Times(1)
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+					assert.Equal(t, tt.expectedProjection, in.ProjectionExpression)
+					assert.Equal(t, tt.expectedNames, in.ExpressionAttributeNames)
+					return &dynamodb.ScanOutput{}, nil
+				}).Times(1)
+
+			q := NewQueries(m, tables, nil).WithProjectedKeys()
+
+			_, err := q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table", Expression: tt.expr})
+			require.NoError(t, err)
+			_, err = q.ScanItems(context.Background(), QueryItemsParams{TableName: "test-table", Expression: tt.expr})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestQueries_AccessDenied(t *testing.T) {
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	tests := []struct {
+		name      string
+		mockSetup func(m *MockDynamoDBQueriesClientAPI)
+		call      func(q *Queries) error
+	}{
+		{
+			name: "GetItem",
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDeniedException"}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.GetItem(context.Background(), GetItemParams{
+					Query:      CreateNewQueryObj("1", nil),
+					TableName:  "test-table",
+					ItemPtr:    &map[string]any{},
+					Expression: NewExpression(),
+				})
+			},
+		},
+		{
+			name: "CreateItem",
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &awshttp.ResponseError{
+					ResponseError: &smithyhttp.ResponseError{
+						Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusForbidden}},
+						Err:      errors.New("forbidden"),
+					},
+				}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.CreateItem(context.Background(), map[string]string{"id": "1"}, "test-table")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(m)
+			q := NewQueries(m, tables, nil)
+
+			err := tt.call(q)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, goaws.ErrAccessDenied)
+			assert.Implements(t, (*goaws.AwsError)(nil), err)
+			assert.True(t, err.(goaws.AwsError).ClientError())
+		})
+	}
+}
+
+func TestQueries_UpdateItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		tableName     string
+		query         *Query
+		expr          Expression
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedError error
+	}{
+		{
+			name:      "Success",
+			tableName: "test-table",
+			query:     CreateNewQueryObj("1", nil),
+			expr:      NewExpression(),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, nil).Times(1)
+				return m
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			query:     CreateNewQueryObj("1", nil),
+			expr:      NewExpression(),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name:      "Error",
+			tableName: "test-table",
+			query:     CreateNewQueryObj("1", nil),
+			expr:      NewExpression(),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("update error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.UpdateItem: update error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+
+			// Setup tables map
+			tables := map[string]*Table{}
+			if tt.tableName == "test-table" {
+				tables["test-table"] = &Table{
+					TableName:      "test-table",
+					PrimaryKeyName: "id",
+					PrimaryKeyType: "S",
+				}
+			}
+
+			q := NewQueries(mockSvc, tables, nil)
+
+			err := q.UpdateItem(context.Background(), tt.query, tt.tableName, tt.expr)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueries_WithReturnValuesOnConditionCheckFailure(t *testing.T) {
+	cond := NewCondition()
+	cond.Equal("version", 1)
+	eb := NewExprBuilder()
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	current := map[string]types.AttributeValue{
+		"id":      &types.AttributeValueMemberS{Value: "1"},
+		"version": &types.AttributeValueMemberN{Value: "3"},
+	}
+	condFailed := &types.ConditionalCheckFailedException{
+		Message: aws.String("The conditional request failed"),
+		Item:    current,
+	}
+
+	tests := []struct {
+		name         string
+		returnOld    bool
+		mockSetup    func(m *MockDynamoDBQueriesClientAPI)
+		call         func(q *Queries) error
+		expectedItem map[string]types.AttributeValue
+	}{
+		{
+			name:      "UpdateItem",
+			returnOld: true,
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+						assert.Equal(t, types.ReturnValuesOnConditionCheckFailureAllOld, in.ReturnValuesOnConditionCheckFailure)
+						return nil, condFailed
+					}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.UpdateItem(context.Background(), CreateNewQueryObj("1", nil), "test-table", expr)
+			},
+			expectedItem: current,
+		},
+		{
+			name:      "CreateItemWithParams",
+			returnOld: true,
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, types.ReturnValuesOnConditionCheckFailureAllOld, in.ReturnValuesOnConditionCheckFailure)
+						return nil, condFailed
+					}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.CreateItemWithParams(context.Background(), CreateItemParams{
+					Item:       map[string]interface{}{"id": "1", "version": 2},
+					TableName:  "test-table",
+					Expression: expr,
+				})
+			},
+			expectedItem: current,
+		},
+		{
+			name:      "CreateItemIfNotExists",
+			returnOld: true,
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, types.ReturnValuesOnConditionCheckFailureAllOld, in.ReturnValuesOnConditionCheckFailure)
+						return nil, condFailed
+					}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.CreateItemIfNotExists(context.Background(), map[string]interface{}{"id": "1"}, "test-table", "id")
+			},
+			expectedItem: current,
+		},
+		{
+			name:      "PutRawItem",
+			returnOld: true,
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, types.ReturnValuesOnConditionCheckFailureAllOld, in.ReturnValuesOnConditionCheckFailure)
+						return nil, condFailed
+					}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.PutRawItem(context.Background(), "test-table", map[string]types.AttributeValue{
+					"id": &types.AttributeValueMemberS{Value: "1"},
+				}, expr)
+			},
+			expectedItem: current,
+		},
+		{
+			name:      "NotRequested",
+			returnOld: false,
+			mockSetup: func(m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+						assert.Empty(t, in.ReturnValuesOnConditionCheckFailure)
+						return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+					}).Times(1)
+			},
+			call: func(q *Queries) error {
+				return q.UpdateItem(context.Background(), CreateNewQueryObj("1", nil), "test-table", expr)
+			},
+			expectedItem: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(m)
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+			}
+			q := NewQueries(m, tables, nil)
+			if tt.returnOld {
+				q.WithReturnValuesOnConditionCheckFailure()
+			}
+
+			err := tt.call(q)
+
+			var condErr *ConditionCheckFailedError
+			require.ErrorAs(t, err, &condErr)
+			assert.EqualError(t, err, NewConditionCheckFailedError("The conditional request failed").Error())
+			assert.Equal(t, tt.expectedItem, condErr.Item)
+		})
+	}
+}
+
+func TestQueries_RemoveIf(t *testing.T) {
+	tables := map[string]*Table{
+		"locks": {TableName: "locks", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	cond := NewCondition()
+	cond.Equal("lock_token", "token-1")
+
+	tests := []struct {
+		name          string
+		attr          string
+		cond          Conditions
+		mockSetup     func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedError error
+	}{
+		{
+			name: "ConditionPasses",
+			attr: "lock_token",
+			cond: cond,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+						assert.Equal(t, map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "lock-1"}}, in.Key)
+						require.NotNil(t, in.UpdateExpression)
+						require.NotNil(t, in.ConditionExpression)
+						assert.True(t, strings.HasPrefix(*in.UpdateExpression, "REMOVE "))
+						assert.Contains(t, *in.ConditionExpression, " = ")
+						assert.ElementsMatch(t, []string{"lock_token"}, slices.Collect(maps.Values(in.ExpressionAttributeNames)))
+						assert.ElementsMatch(t, []types.AttributeValue{&types.AttributeValueMemberS{Value: "token-1"}}, slices.Collect(maps.Values(in.ExpressionAttributeValues)))
+						return &dynamodb.UpdateItemOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name: "ConditionFails",
+			attr: "lock_token",
+			cond: cond,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ConditionalCheckFailedException{
+					Message: aws.String("The conditional request failed"),
+				}).Times(1)
+			},
+			expectedError: NewConditionCheckFailedError("The conditional request failed"),
+		},
+		{
+			name:          "EmptyAttribute",
+			cond:          cond,
+			mockSetup:     func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New("empty attribute name")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			q := NewQueries(m, tables, nil)
+
+			err := q.RemoveIf(context.Background(), CreateNewQueryObj("lock-1", nil), "locks", tt.attr, tt.cond)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				if tt.name == "ConditionFails" {
+					assert.ErrorIs(t, err, ErrConditionCheckFailed)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+func TestQueries_WithMetrics(t *testing.T) {
+	collectionKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}
+	expected := &WriteMetrics{
+		ConsumedCapacityUnits: 2,
+		ItemCollectionKey:     collectionKey,
+		SizeEstimateRangeGB:   []float64{9.5, 10},
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		call          func(q *Queries) (*WriteMetrics, error)
+		expected      *WriteMetrics
+		expectedError error
+	}{
+		{
+			name: "CreateItem",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+						assert.Equal(t, types.ReturnConsumedCapacityTotal, in.ReturnConsumedCapacity)
+						assert.Equal(t, types.ReturnItemCollectionMetricsSize, in.ReturnItemCollectionMetrics)
+						return &dynamodb.PutItemOutput{
+							ConsumedCapacity: &types.ConsumedCapacity{CapacityUnits: aws.Float64(2)},
+							ItemCollectionMetrics: &types.ItemCollectionMetrics{
+								ItemCollectionKey:   collectionKey,
+								SizeEstimateRangeGB: []float64{9.5, 10},
+							},
+						}, nil
+					}).Times(1)
+				return m
+			},
+			call: func(q *Queries) (*WriteMetrics, error) {
+				return q.CreateItemWithMetrics(context.Background(), map[string]any{"id": "1"}, "test-table")
+			},
+			expected: expected,
+		},
+		{
+			name: "UpdateItem",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+						assert.Equal(t, types.ReturnConsumedCapacityTotal, in.ReturnConsumedCapacity)
+						assert.Equal(t, types.ReturnItemCollectionMetricsSize, in.ReturnItemCollectionMetrics)
+						return &dynamodb.UpdateItemOutput{
+							ConsumedCapacity: &types.ConsumedCapacity{CapacityUnits: aws.Float64(2)},
+							ItemCollectionMetrics: &types.ItemCollectionMetrics{
+								ItemCollectionKey:   collectionKey,
+								SizeEstimateRangeGB: []float64{9.5, 10},
+							},
+						}, nil
+					}).Times(1)
+				return m
+			},
+			call: func(q *Queries) (*WriteMetrics, error) {
+				return q.UpdateItemWithMetrics(context.Background(), CreateNewQueryObj("1", nil), "test-table", NewExpression())
+			},
+			expected: expected,
+		},
+		{
+			name: "NoItemCollection",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{
+					ConsumedCapacity: &types.ConsumedCapacity{CapacityUnits: aws.Float64(1)},
+				}, nil).Times(1)
+				return m
+			},
+			call: func(q *Queries) (*WriteMetrics, error) {
+				return q.CreateItemWithMetrics(context.Background(), map[string]any{"id": "1"}, "test-table")
+			},
+			expected: &WriteMetrics{ConsumedCapacityUnits: 1},
+		},
+		{
+			name: "WithoutMetrics",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+						assert.Empty(t, in.ReturnConsumedCapacity)
+						assert.Empty(t, in.ReturnItemCollectionMetrics)
+						return &dynamodb.UpdateItemOutput{}, nil
+					}).Times(1)
+				return m
+			},
+			call: func(q *Queries) (*WriteMetrics, error) {
+				err := q.UpdateItem(context.Background(), CreateNewQueryObj("1", nil), "test-table", NewExpression())
+				return nil, err
+			},
+			expected: nil,
+		},
+		{
+			name: "Error",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("update error")).Times(1)
+				return m
+			},
+			call: func(q *Queries) (*WriteMetrics, error) {
+				return q.UpdateItemWithMetrics(context.Background(), CreateNewQueryObj("1", nil), "test-table", NewExpression())
+			},
+			expectedError: errors.New("q.svc.UpdateItem: update error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
+			}
+			q := NewQueries(tt.mockSetup(ctrl), tables, nil)
+
+			metrics, err := tt.call(q)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, metrics)
+			}
+		})
+	}
+}
+
+func TestQueries_DeleteItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		tableName     string
+		query         *Query
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedError error
+	}{
+		{
+			name:      "Success",
+			tableName: "test-table",
+			query:     CreateNewQueryObj("1", nil),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil).Times(1)
+				return m
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			query:     CreateNewQueryObj("1", nil),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name:      "Error",
+			tableName: "test-table",
+			query:     CreateNewQueryObj("1", nil),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("delete error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.DeleteItem: delete error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+
+			// Setup tables map
+			tables := map[string]*Table{}
+			if tt.tableName == "test-table" {
+				tables["test-table"] = &Table{
+					TableName:      "test-table",
+					PrimaryKeyName: "id",
+					PrimaryKeyType: "S",
+				}
+			}
+
+			q := NewQueries(mockSvc, tables, nil)
+
+			err := q.DeleteItem(context.Background(), tt.query, tt.tableName)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueries_BatchWriteCreate(t *testing.T) {
+	type TestItem struct {
+		ID   string `json:"id"`
+		Data string `json:"data"`
+	}
+
+	tests := []struct {
+		name          string
+		tableName     string
+		items         []any
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedError error
+	}{
+		{
+			name:      "Success",
+			tableName: "test-table",
+			items:     []any{TestItem{ID: "1", Data: "a"}},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{}, nil).Times(1)
+				return m
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "TooManyItems",
+			tableName: "test-table",
+			items:     make([]any, 26),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewCollectionSizeExceededError(26),
+		},
+		{
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			items:     []any{TestItem{ID: "1", Data: "a"}},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name:      "Error",
+			tableName: "test-table",
+			items:     []any{TestItem{ID: "1", Data: "a"}},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("batch error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.batchWriteUtil: q.svc.BatchWriteItem: batch error")),
+		},
+		{
+			name:      "NilItems",
+			tableName: "test-table",
+			items:     nil,
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewNilModelError(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+
+			// Setup tables map
+			tables := map[string]*Table{}
+			if tt.tableName == "test-table" {
+				tables["test-table"] = &Table{
+					TableName:      "test-table",
+					PrimaryKeyName: "id",
+					PrimaryKeyType: "S",
+				}
+			}
+
+			q := NewQueries(mockSvc, tables, nil)
+
+			err := q.BatchWriteCreate(context.Background(), tt.tableName, tt.items)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+
+				var awsErr goaws.AwsError
+				assert.Equal(t, true, errors.As(err, &awsErr))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueries_BatchWriteCreateUnprocessedItems(t *testing.T) {
+	type TestItem struct {
+		ID   string `json:"id"`
+		Data string `json:"data"`
+	}
+
+	items := []any{TestItem{ID: "1", Data: "a"}, TestItem{ID: "2", Data: "b"}}
+	unprocessed := map[string][]types.WriteRequest{
+		"test-table": {{PutRequest: &types.PutRequest{Item: map[string]types.AttributeValue{
+			"ID":   &types.AttributeValueMemberS{Value: "2"},
+			"Data": &types.AttributeValueMemberS{Value: "b"},
+		}}}},
+	}
+
+	tests := []struct {
+		name                string
+		mockSetup           func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedUnprocessed []map[string]types.AttributeValue
+		expectedError       error
+	}{
+		{
+			name: "success after retry",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{UnprocessedItems: unprocessed}, nil),
+					m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+							assert.Equal(t, unprocessed, in.RequestItems)
+							return &dynamodb.BatchWriteItemOutput{}, nil
+						}),
+				)
+			},
+		},
+		{
+			name: "success after throttling",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ProvisionedThroughputExceededException{}),
+					m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+							assert.Len(t, in.RequestItems["test-table"], 2)
+							return &dynamodb.BatchWriteItemOutput{}, nil
+						}),
+				)
+			},
+		},
+		{
+			name: "max retries exceeded",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{UnprocessedItems: unprocessed}, nil).Times(3)
+			},
+			expectedUnprocessed: []map[string]types.AttributeValue{unprocessed["test-table"][0].PutRequest.Item},
+			expectedError:       errors.New("retries.ExponentialBackoffContext: max retries exceeded: 1 unprocessed items"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, mockSvc)
+
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+			}
+			clock := goaws.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			// retries wait 2ms, then the remaining 3ms until the 5ms cap
+			q := NewQueries(mockSvc, tables, NewFailConfig(1, 5, 1).WithClock(clock))
+
+			err := q.BatchWriteCreate(context.Background(), "test-table", items)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrMaxRetriesExceeded)
+
+				var unprocessedErr *UnprocessedItemsError
+				require.True(t, errors.As(err, &unprocessedErr))
+				assert.Equal(t, tt.expectedUnprocessed, unprocessedErr.Items)
+				assert.Empty(t, unprocessedErr.Keys)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueries_BatchWrite(t *testing.T) {
+	puts := []any{
+		map[string]any{"id": "1", "data": "a"},
+		map[string]any{"id": "2", "data": "b"},
+	}
+	deletes := []*Query{CreateNewQueryObj("3", nil), CreateNewQueryObj("4", nil)}
+	deleteKey := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+	unprocessed := map[string][]types.WriteRequest{
+		"test-table": {{DeleteRequest: &types.DeleteRequest{Key: deleteKey("4")}}},
+	}
+
+	tests := []struct {
+		name          string
+		tableName     string
+		puts          []any
+		deletes       []*Query
+		mockSetup     func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedError error
+	}{
+		{
+			name:      "Mixed",
+			tableName: "test-table",
+			puts:      puts,
+			deletes:   deletes,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+						wrs := in.RequestItems["test-table"]
+						require.Len(t, wrs, 4)
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "1"}, wrs[0].PutRequest.Item["id"])
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "2"}, wrs[1].PutRequest.Item["id"])
+						assert.Equal(t, deleteKey("3"), wrs[2].DeleteRequest.Key)
+						assert.Equal(t, deleteKey("4"), wrs[3].DeleteRequest.Key)
+						return &dynamodb.BatchWriteItemOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name:      "PutsOnly",
+			tableName: "test-table",
+			puts:      puts,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+						assert.Len(t, in.RequestItems["test-table"], 2)
+						return &dynamodb.BatchWriteItemOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name:      "RetriesUnprocessed",
+			tableName: "test-table",
+			puts:      puts,
+			deletes:   deletes,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{UnprocessedItems: unprocessed}, nil),
+					m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+							assert.Equal(t, unprocessed, in.RequestItems)
+							return &dynamodb.BatchWriteItemOutput{}, nil
+						}),
+				)
+			},
+		},
+		{
+			name:          "TooManyItems",
+			tableName:     "test-table",
+			puts:          make([]any, 20),
+			deletes:       make([]*Query, 6),
+			mockSetup:     func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+			expectedError: NewCollectionSizeExceededError(26),
+		},
+		{
+			name:          "Empty",
+			tableName:     "test-table",
+			mockSetup:     func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+			expectedError: NewNilModelError(),
+		},
+		{
+			name:          "TableNotFound",
+			tableName:     "missing-table",
+			puts:          puts,
+			mockSetup:     func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name:      "Error",
+			tableName: "test-table",
+			puts:      puts,
+			deletes:   deletes,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("batch error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.batchWriteUtil: q.svc.BatchWriteItem: batch error")),
+		},
+		{
+			name:      "MaxRetriesExceeded",
+			tableName: "test-table",
+			puts:      puts,
+			deletes:   deletes,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				mixed := map[string][]types.WriteRequest{
+					"test-table": {
+						{PutRequest: &types.PutRequest{Item: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "2"}}}},
+						{DeleteRequest: &types.DeleteRequest{Key: deleteKey("4")}},
+					},
+				}
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{UnprocessedItems: mixed}, nil).Times(3)
+			},
+			expectedError: errors.New("retries.ExponentialBackoffContext: max retries exceeded: 2 unprocessed items"),
+		},
+		{
+			name:      "AllNil",
+			tableName: "test-table",
+			puts:      []any{nil},
+			deletes:   []*Query{nil},
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, mockSvc)
+
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+			}
+			clock := goaws.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			q := NewQueries(mockSvc, tables, NewFailConfig(1, 5, 1).WithClock(clock))
+
+			err := q.BatchWrite(context.Background(), tt.tableName, tt.puts, tt.deletes)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				var awsErr goaws.AwsError
+				assert.ErrorAs(t, err, &awsErr)
+
+				var unprocessedErr *UnprocessedItemsError
+				if errors.As(err, &unprocessedErr) {
+					assert.Len(t, unprocessedErr.Items, 1)
+					assert.Equal(t, []map[string]types.AttributeValue{deleteKey("4")}, unprocessedErr.Keys)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueries_ConditionalDeleteAll(t *testing.T) {
+	cond := NewCondition()
+	cond.Equal("status", "archived")
+	eb := NewExprBuilder()
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		tableName       string
+		queries         []ConditionalDelete
+		mockSetup       func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedSkipped []*Query
+		expectedError   error
+	}{
+		{
+			name:      "Mixed",
+			tableName: "test-table",
+			queries: []ConditionalDelete{
+				{Query: CreateNewQueryObj("1", nil)},
+				{Query: CreateNewQueryObj("2", nil), Conditional: true},
+				{Query: CreateNewQueryObj("3", nil)},
+				{Query: CreateNewQueryObj("4", nil), Conditional: true},
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+						assert.Equal(t, expr.Condition(), in.ConditionExpression)
+						assert.Equal(t, expr.Names(), in.ExpressionAttributeNames)
+						assert.Equal(t, expr.Values(), in.ExpressionAttributeValues)
+						if in.Key["id"].(*types.AttributeValueMemberS).Value == "4" {
+							return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+						}
+						return &dynamodb.DeleteItemOutput{}, nil
+					}).Times(2)
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+						wrs := in.RequestItems["test-table"]
+						require.Len(t, wrs, 2)
+						assert.Equal(t, "1", wrs[0].DeleteRequest.Key["id"].(*types.AttributeValueMemberS).Value)
+						assert.Equal(t, "3", wrs[1].DeleteRequest.Key["id"].(*types.AttributeValueMemberS).Value)
+						return &dynamodb.BatchWriteItemOutput{}, nil
+					}).Times(1)
+				return m
+			},
+			expectedSkipped: []*Query{CreateNewQueryObj("4", nil)},
+		},
+		{
+			name:      "UnconditionalOnly",
+			tableName: "test-table",
+			queries: []ConditionalDelete{
+				{Query: CreateNewQueryObj("1", nil)},
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchWriteItemOutput{}, nil).Times(1)
+				return m
+			},
+			expectedSkipped: []*Query{},
+		},
+		{
+			name:      "DeleteError",
+			tableName: "test-table",
+			queries: []ConditionalDelete{
+				{Query: CreateNewQueryObj("1", nil)},
+				{Query: CreateNewQueryObj("2", nil), Conditional: true},
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("delete error")).Times(1)
+				return m
+			},
+			expectedError: errors.New("q.svc.DeleteItem: delete error"),
+		},
+		{
+			name:      "TableNotFound",
+			tableName: "missing-table",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
+			}
+			q := NewQueries(tt.mockSetup(ctrl), tables, nil)
+
+			skipped, err := q.ConditionalDeleteAll(context.Background(), tt.tableName, tt.queries, expr)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedSkipped, skipped)
+			}
+		})
+	}
+}
+
+func TestQueries_ConditionalDeleteAll_ReturnValuesOnConditionCheckFailure(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cond := NewCondition()
+	cond.Equal("status", "archived")
+	eb := NewExprBuilder()
+	eb.SetCondition(cond)
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	current := map[string]types.AttributeValue{
+		"id":     &types.AttributeValueMemberS{Value: "1"},
+		"status": &types.AttributeValueMemberS{Value: "active"},
+	}
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+			assert.Equal(t, types.ReturnValuesOnConditionCheckFailureAllOld, in.ReturnValuesOnConditionCheckFailure)
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed"), Item: current}
+		}).Times(1)
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id"},
+	}
+	q := NewQueries(m, tables, nil).WithReturnValuesOnConditionCheckFailure()
+
+	queries := []ConditionalDelete{{Query: CreateNewQueryObj("1", nil), Conditional: true}}
+	skipped, err := q.ConditionalDeleteAll(context.Background(), "test-table", queries, expr)
+	require.NoError(t, err)
+	assert.Equal(t, []*Query{CreateNewQueryObj("1", nil)}, skipped)
+	assert.Equal(t, current, queries[0].Item)
+}
+
+func TestQueries_ScanItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        QueryItemsParams
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedError error
+	}{
+		{
+			name: "Success",
+			params: QueryItemsParams{
+				TableName:  "test-table",
+				Expression: NewExpression(),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.ScanOutput{
+					Items: []map[string]types.AttributeValue{
+						{
+							"id": &types.AttributeValueMemberS{Value: "1"},
+						},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expectedError: nil,
+		},
+		{
+			name: "TableNotFound",
+			params: QueryItemsParams{
+				TableName:  "missing-table",
+				Expression: NewExpression(),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name: "Error",
+			params: QueryItemsParams{
+				TableName:  "test-table",
+				Expression: NewExpression(),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("scan error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.Scan: scan error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+
+			// Setup tables map
+			tables := map[string]*Table{}
+			if tt.params.TableName == "test-table" {
+				tables["test-table"] = &Table{
+					TableName:      "test-table",
+					PrimaryKeyName: "id",
+					PrimaryKeyType: "S",
+				}
+			}
+
+			q := NewQueries(mockSvc, tables, nil)
+
+			res, err := q.ScanItems(context.Background(), tt.params)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, res)
+				assert.Len(t, res.Rows, 1)
+				assert.Equal(t, "1", res.Rows[0]["id"])
+			}
+		})
+	}
+}
+
+func TestQueries_ScanMissingAttribute(t *testing.T) {
+	type TestItem struct {
+		ID string `dynamodbav:"id"`
+	}
+
+	tests := []struct {
+		name          string
+		tableName     string
+		attr          string
+		mockSetup     func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedItems []TestItem
+		expectedError error
+	}{
+		{
+			name:      "Success",
+			tableName: "test-table",
+			attr:      "created_at",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}
+				assertInput := func(in *dynamodb.ScanInput) {
+					assert.Equal(t, "test-table", aws.ToString(in.TableName))
+					assert.Equal(t, "attribute_not_exists(#attr)", aws.ToString(in.FilterExpression))
+					assert.Equal(t, map[string]string{"#attr": "created_at"}, in.ExpressionAttributeNames)
+					assert.Nil(t, in.ExpressionAttributeValues)
+				}
+				gomock.InOrder(
+					m.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+							assertInput(in)
+							assert.Nil(t, in.ExclusiveStartKey)
+							return &dynamodb.ScanOutput{
+								Items:            []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "1"}}},
+								LastEvaluatedKey: lastKey,
+							}, nil
+						}),
+					m.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+							assertInput(in)
+							assert.Equal(t, lastKey, in.ExclusiveStartKey)
+							return &dynamodb.ScanOutput{
+								Items: []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "2"}}},
+							}, nil
+						}),
+				)
+			},
+			expectedItems: []TestItem{{ID: "1"}, {ID: "2"}},
+		},
+		{
+			name:          "EmptyAttribute",
+			tableName:     "test-table",
+			mockSetup:     func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New("empty attribute name")),
+		},
+		{
+			name:          "TableNotFound",
+			tableName:     "missing-table",
+			attr:          "created_at",
+			mockSetup:     func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name:      "ScanError",
+			tableName: "test-table",
+			attr:      "created_at",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(nil, errors.New("scan error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.Scan: scan error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+			}
+			q := NewQueries(m, tables, nil)
+
+			var items []TestItem
+			err := q.ScanMissingAttribute(context.Background(), tt.tableName, tt.attr, &items)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedItems, items)
+			}
+		})
+	}
+}
+
+func TestQueries_QueryItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        QueryItemsParams
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedError error
+	}{
+		{
+			name: "Success",
+			params: QueryItemsParams{
+				TableName:  "test-table",
+				Expression: NewExpression(),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{
+						{
+							"id": &types.AttributeValueMemberS{Value: "1"},
+						},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expectedError: nil,
+		},
+		{
+			name: "TableNotFound",
+			params: QueryItemsParams{
+				TableName:  "missing-table",
+				Expression: NewExpression(),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewTableNotFoundError("missing-table"),
+		},
+		{
+			name: "Error",
+			params: QueryItemsParams{
+				TableName:  "test-table",
+				Expression: NewExpression(),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("query error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.Query: query error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+
+			// Setup tables map
+			tables := map[string]*Table{}
+			if tt.params.TableName == "test-table" {
+				tables["test-table"] = &Table{
+					TableName:      "test-table",
+					PrimaryKeyName: "id",
+					PrimaryKeyType: "S",
+				}
+			}
+
+			q := NewQueries(mockSvc, tables, nil)
+
+			res, err := q.QueryItems(context.Background(), tt.params)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, res)
+				assert.Len(t, res.Rows, 1)
+				assert.Equal(t, "1", res.Rows[0]["id"])
+			}
+		})
+	}
+}
+
+func TestQueries_StartKey(t *testing.T) {
+	type keyModel struct {
+		ID string `dynamodbav:"id"`
+		SK int    `dynamodbav:"sk"`
+	}
+	lastKey := map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "1"},
+		"sk": &types.AttributeValueMemberN{Value: "2"},
+	}
+
+	tests := []struct {
+		name     string
+		startKey any
+		expected map[string]types.AttributeValue
+	}{
+		{name: "None", startKey: nil, expected: nil},
+		{name: "Model", startKey: keyModel{ID: "1", SK: 2}, expected: lastKey},
+		{name: "LastKey", startKey: lastKey, expected: lastKey},
+		{name: "EmptyLastKey", startKey: map[string]types.AttributeValue{}, expected: nil},
+		{name: "NilLastKey", startKey: map[string]types.AttributeValue(nil), expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+					assert.Equal(t, tt.expected, in.ExclusiveStartKey)
+					return &dynamodb.QueryOutput{}, nil
+				}).Times(1)
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+					assert.Equal(t, tt.expected, in.ExclusiveStartKey)
+					return &dynamodb.ScanOutput{}, nil
+				}).Times(1)
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S", SortKeyName: "sk", SortKeyType: "N"},
+			}
+			q := NewQueries(m, tables, nil)
+
+			params := QueryItemsParams{TableName: "test-table", StartKey: tt.startKey}
+			_, err := q.QueryItems(context.Background(), params)
+			require.NoError(t, err)
+			_, err = q.ScanItems(context.Background(), params)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("PriorResult", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		gomock.InOrder(
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+					assert.Nil(t, in.ExclusiveStartKey)
+					return &dynamodb.QueryOutput{
+						Items:            []map[string]types.AttributeValue{lastKey},
+						LastEvaluatedKey: lastKey,
+					}, nil
+				}).Times(1),
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+					assert.Equal(t, lastKey, in.ExclusiveStartKey)
+					return &dynamodb.QueryOutput{}, nil
+				}).Times(2),
+		)
+		tables := map[string]*Table{
+			"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S", SortKeyName: "sk", SortKeyType: "N"},
+		}
+		q := NewQueries(m, tables, nil)
+
+		first, err := q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table"})
+		require.NoError(t, err)
+
+		// pass the LastKey straight back in
+		_, err = q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table", StartKey: first.LastKey})
+		require.NoError(t, err)
+
+		// and after a round trip through a cursor
+		cursor, err := EncodeCursor(first.LastKey)
+		require.NoError(t, err)
+		decoded, err := DecodeCursor(cursor)
+		require.NoError(t, err)
+		_, err = q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table", StartKey: decoded})
+		require.NoError(t, err)
+	})
+}
+
+func TestQueries_HasMore(t *testing.T) {
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}
+
+	tests := []struct {
+		name     string
+		lastKey  map[string]types.AttributeValue
+		expected bool
+	}{
+		{name: "LastEvaluatedKey", lastKey: lastKey, expected: true},
+		{name: "LastPage", lastKey: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+				Items:            []map[string]types.AttributeValue{lastKey},
+				LastEvaluatedKey: tt.lastKey,
+			}, nil).Times(1)
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.ScanOutput{
+				Items:            []map[string]types.AttributeValue{lastKey},
+				LastEvaluatedKey: tt.lastKey,
+			}, nil).Times(1)
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+			}
+			q := NewQueries(m, tables, nil)
+
+			queryRes, err := q.QueryItems(context.Background(), QueryItemsParams{TableName: "test-table"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, queryRes.HasMore)
+
+			scanRes, err := q.ScanItems(context.Background(), QueryItemsParams{TableName: "test-table"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, scanRes.HasMore)
+		})
+	}
+}
+
+func TestQueries_Select(t *testing.T) {
+	eb := NewExprBuilder()
+	eb.SetProjection([]string{"status"})
+	projected, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		params         QueryItemsParams
+		expectedSelect types.Select
+		expectedIndex  *string
+		expectedError  error
+	}{
+		{
+			name:           "Table",
+			params:         QueryItemsParams{TableName: "test-table"},
+			expectedSelect: "",
+		},
+		{
+			name:           "Projection",
+			params:         QueryItemsParams{TableName: "test-table", Expression: projected},
+			expectedSelect: types.SelectSpecificAttributes,
+		},
+		{
+			name:           "Count",
+			params:         QueryItemsParams{TableName: "test-table", Count: true},
+			expectedSelect: types.SelectCount,
+		},
+		{
+			name:           "Index",
+			params:         QueryItemsParams{TableName: "test-table", IndexName: "status-index"},
+			expectedSelect: types.SelectAllProjectedAttributes,
+			expectedIndex:  aws.String("status-index"),
+		},
+		{
+			name:           "IndexProjection",
+			params:         QueryItemsParams{TableName: "test-table", IndexName: "status-index", Expression: projected},
+			expectedSelect: types.SelectSpecificAttributes,
+			expectedIndex:  aws.String("status-index"),
+		},
+		{
+			name:           "IndexCount",
+			params:         QueryItemsParams{TableName: "test-table", IndexName: "status-index", Count: true},
+			expectedSelect: types.SelectCount,
+			expectedIndex:  aws.String("status-index"),
+		},
+		{
+			name:          "CountWithProjection",
+			params:        QueryItemsParams{TableName: "test-table", Expression: projected, Count: true},
+			expectedError: goaws.NewClientError(errors.New("count can't be combined with a projection")),
+		},
+		{
+			name:           "DefaultProjection",
+			params:         QueryItemsParams{TableName: "projected-table"},
+			expectedSelect: types.SelectSpecificAttributes,
+		},
+		{
+			name:           "CountWithDefaultProjection",
+			params:         QueryItemsParams{TableName: "projected-table", Count: true},
+			expectedSelect: types.SelectCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			if tt.expectedError == nil {
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, tt.expectedSelect, in.Select)
+						assert.Equal(t, tt.expectedIndex, in.IndexName)
+						if tt.params.Count {
+							assert.Nil(t, in.ProjectionExpression)
+						}
+						return &dynamodb.QueryOutput{Count: 3}, nil
+					}).Times(1)
+				m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+						assert.Equal(t, tt.expectedSelect, in.Select)
+						assert.Equal(t, tt.expectedIndex, in.IndexName)
+						if tt.params.Count {
+							assert.Nil(t, in.ProjectionExpression)
+						}
+						return &dynamodb.ScanOutput{Count: 3}, nil
+					}).Times(1)
+			}
+			tables := map[string]*Table{
+				"test-table":      {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+				"projected-table": {TableName: "projected-table", PrimaryKeyName: "id", PrimaryKeyType: "S", ProjectionFields: []string{"id", "status"}},
+			}
+			q := NewQueries(m, tables, nil)
+
+			queryRes, queryErr := q.QueryItems(context.Background(), tt.params)
+			scanRes, scanErr := q.ScanItems(context.Background(), tt.params)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, queryErr, tt.expectedError.Error())
+				assert.EqualError(t, scanErr, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), queryErr)
+				return
+			}
+			require.NoError(t, queryErr)
+			require.NoError(t, scanErr)
+			assert.Equal(t, int32(3), queryRes.Count)
+			assert.Equal(t, int32(3), scanRes.Count)
+		})
+	}
+}
+
+func TestQueries_QueryItemsUntilLimit(t *testing.T) {
+	row := func(id, sk, status string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"id":     &types.AttributeValueMemberS{Value: id},
+			"sk":     &types.AttributeValueMemberS{Value: sk},
+			"status": &types.AttributeValueMemberS{Value: status},
+		}
+	}
+	key := func(id, sk string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+			"sk": &types.AttributeValueMemberS{Value: sk},
+		}
+	}
+
+	indexRow := func(id, sk, status, created string) map[string]types.AttributeValue {
+		r := row(id, sk, status)
+		r["created"] = &types.AttributeValueMemberS{Value: created}
+		return r
+	}
+
+	tables := map[string]*Table{
+		"test-table": {
+			TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S", SortKeyName: "sk", SortKeyType: "S",
+			Indexes: map[string]Index{"status-index": {PrimaryKeyName: "status", SortKeyName: "created"}},
+		},
+	}
+
+	eb := NewExprBuilder()
+	eb.SetProjection([]string{"sk"})
+	projected, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		limit           int
+		indexName       string
+		expr            Expression
+		mockSetup       func(t *testing.T, m *MockDynamoDBQueriesClientAPI)
+		expectedSortKey []string
+		expectedLastKey map[string]types.AttributeValue
+		expectedError   error
+	}{
+		{
+			name:  "FirstPageFiltered",
+			limit: 5,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							assert.Nil(t, in.ExclusiveStartKey)
+							// every item on the first page was discarded by the filter
+							return &dynamodb.QueryOutput{LastEvaluatedKey: key("1", "c")}, nil
+						}).Times(1),
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							assert.Equal(t, key("1", "c"), in.ExclusiveStartKey)
+							return &dynamodb.QueryOutput{
+								Items:            []map[string]types.AttributeValue{row("1", "d", "active"), row("1", "e", "active")},
+								LastEvaluatedKey: key("1", "f"),
+							}, nil
+						}).Times(1),
+					m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							assert.Equal(t, key("1", "f"), in.ExclusiveStartKey)
+							return &dynamodb.QueryOutput{
+								Items: []map[string]types.AttributeValue{row("1", "g", "active")},
+							}, nil
+						}).Times(1),
+				)
+			},
+			expectedSortKey: []string{"d", "e", "g"},
+			expectedLastKey: nil,
+		},
+		{
+			name:  "LimitReached",
+			limit: 2,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{row("1", "a", "active"), row("1", "b", "active"), row("1", "c", "active")},
+					LastEvaluatedKey: key("1", "z"),
+				}, nil).Times(1)
+			},
+			expectedSortKey: []string{"a", "b"},
+			expectedLastKey: key("1", "b"),
+		},
+		{
+			name:  "ExactLimit",
+			limit: 2,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{row("1", "a", "active"), row("1", "b", "active")},
+					LastEvaluatedKey: key("1", "z"),
+				}, nil).Times(1)
+			},
+			expectedSortKey: []string{"a", "b"},
+			expectedLastKey: key("1", "z"),
+		},
+		{
+			name:      "IndexLimitReached",
+			limit:     1,
+			indexName: "status-index",
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, aws.String("status-index"), in.IndexName)
+						return &dynamodb.QueryOutput{
+							Items: []map[string]types.AttributeValue{indexRow("1", "a", "active", "t1"), indexRow("2", "b", "active", "t2")},
+						}, nil
+					}).Times(1)
+			},
+			expectedSortKey: []string{"a"},
+			expectedLastKey: map[string]types.AttributeValue{
+				"id":      &types.AttributeValueMemberS{Value: "1"},
+				"sk":      &types.AttributeValueMemberS{Value: "a"},
+				"status":  &types.AttributeValueMemberS{Value: "active"},
+				"created": &types.AttributeValueMemberS{Value: "t1"},
+			},
+		},
+		{
+			name:  "ProjectionAddsKeys",
+			limit: 1,
+			expr:  projected,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, aws.String("#0, #projKey0"), in.ProjectionExpression)
+						assert.Equal(t, map[string]string{"#0": "sk", "#projKey0": "id"}, in.ExpressionAttributeNames)
+						return &dynamodb.QueryOutput{
+							Items: []map[string]types.AttributeValue{key("1", "a"), key("1", "b")},
+						}, nil
+					}).Times(1)
+			},
+			expectedSortKey: []string{"a"},
+			expectedLastKey: key("1", "a"),
+		},
+		{
+			name:          "UnknownIndex",
+			limit:         1,
+			indexName:     "missing-index",
+			mockSetup:     func(_ *testing.T, _ *MockDynamoDBQueriesClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New("index key schema not found: missing-index")),
+		},
+		{
+			name:          "InvalidLimit",
+			limit:         0,
+			mockSetup:     func(_ *testing.T, _ *MockDynamoDBQueriesClientAPI) {},
+			expectedError: goaws.NewClientError(errors.New("invalid limit: 0")),
+		},
+		{
+			name:  "Error",
+			limit: 5,
+			mockSetup: func(t *testing.T, m *MockDynamoDBQueriesClientAPI) {
+				m.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("query error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.Query: query error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			q := NewQueries(m, tables, nil)
+
+			res, err := q.QueryItemsUntilLimit(context.Background(), QueryItemsParams{
+				TableName:  "test-table",
+				IndexName:  tt.indexName,
+				Expression: tt.expr,
+			}, tt.limit)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			sortKeys := make([]string, 0, len(res.Rows))
+			for _, r := range res.Rows {
+				sortKeys = append(sortKeys, r["sk"].(string))
+			}
+			assert.Equal(t, tt.expectedSortKey, sortKeys)
+			assert.Equal(t, tt.expectedLastKey, res.LastKey)
+			assert.Equal(t, tt.expectedLastKey != nil, res.HasMore)
+		})
+	}
+}
+
+func TestQueries_ExecuteStatement(t *testing.T) {
+	tests := []struct {
+		name          string
+		statement     string
+		params        []any
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedRows  int
+		expectedError error
+	}{
+		{
+			name:      "Success",
+			statement: `SELECT * FROM "test-table" WHERE id = ?`,
+			params:    []any{"1"},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().ExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.ExecuteStatementInput, _ ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+						require.Len(t, in.Parameters, 1)
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "1"}, in.Parameters[0])
+						return &dynamodb.ExecuteStatementOutput{
+							Items: []map[string]types.AttributeValue{
+								{"id": &types.AttributeValueMemberS{Value: "1"}},
+							},
+						}, nil
+					}).Times(1)
+				return m
+			},
+			expectedRows:  1,
+			expectedError: nil,
+		},
+		{
+			name:      "Pagination",
+			statement: `SELECT * FROM "test-table"`,
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				gomock.InOrder(
+					m.EXPECT().ExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.ExecuteStatementInput, _ ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+							assert.Nil(t, in.NextToken)
+							return &dynamodb.ExecuteStatementOutput{
+								Items: []map[string]types.AttributeValue{
+									{"id": &types.AttributeValueMemberS{Value: "1"}},
+								},
+								NextToken: aws.String("page-2"),
+							}, nil
+						}),
+					m.EXPECT().ExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *dynamodb.ExecuteStatementInput, _ ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+							assert.Equal(t, "page-2", aws.ToString(in.NextToken))
+							return &dynamodb.ExecuteStatementOutput{
+								Items: []map[string]types.AttributeValue{
+									{"id": &types.AttributeValueMemberS{Value: "2"}},
+								},
+							}, nil
+						}),
+				)
+				return m
+			},
+			expectedRows:  2,
+			expectedError: nil,
+		},
+		{
+			name:      "EmptyStatement",
+			statement: "",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewNilModelError(),
+		},
+		{
+			name:      "Error",
+			statement: `SELECT * FROM "test-table"`,
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().ExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("statement error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.ExecuteStatement: statement error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+			q := NewQueries(mockSvc, map[string]*Table{}, nil)
+
+			res, err := q.ExecuteStatement(context.Background(), tt.statement, tt.params)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				assert.Len(t, res.Rows, tt.expectedRows)
+				assert.Equal(t, "1", res.Rows[0]["id"])
+			}
+		})
+	}
+}
+
+func TestQueries_BatchExecuteStatement(t *testing.T) {
+	tests := []struct {
+		name          string
+		statements    []BatchStatement
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI
+		expectedErrs  []error
+		expectedError error
+	}{
+		{
+			name: "Success",
+			statements: []BatchStatement{
+				{Statement: `SELECT * FROM "test-table" WHERE id = ?`, Params: []any{"1"}},
+				{Statement: `UPDATE "test-table" SET val = ? WHERE id = ?`, Params: []any{10, "2"}},
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().BatchExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchExecuteStatementOutput{
+					Responses: []types.BatchStatementResponse{
+						{Item: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}},
+						{},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expectedErrs:  []error{nil, nil},
+			expectedError: nil,
+		},
+		{
+			name: "StatementError",
+			statements: []BatchStatement{
+				{Statement: `SELECT * FROM "test-table" WHERE id = ?`, Params: []any{"1"}},
+				{Statement: `UPDATE "test-table" SET val = ? WHERE id = ?`, Params: []any{10, "2"}},
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().BatchExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.BatchExecuteStatementOutput{
+					Responses: []types.BatchStatementResponse{
+						{Item: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}},
+						{Error: &types.BatchStatementError{
+							Code:    types.BatchStatementErrorCodeEnumConditionalCheckFailed,
+							Message: aws.String("item changed"),
+						}},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expectedErrs:  []error{nil, NewConditionCheckFailedError("item changed")},
+			expectedError: nil,
+		},
+		{
+			name:       "TooManyStatements",
+			statements: make([]BatchStatement, 26),
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				return NewMockDynamoDBQueriesClientAPI(ctrl)
+			},
+			expectedError: NewCollectionSizeExceededError(26),
+		},
+		{
+			name:       "Error",
+			statements: []BatchStatement{{Statement: `SELECT * FROM "test-table"`}},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBQueriesClientAPI {
+				m := NewMockDynamoDBQueriesClientAPI(ctrl)
+				m.EXPECT().BatchExecuteStatement(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("batch error")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("q.svc.BatchExecuteStatement: batch error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := tt.mockSetup(ctrl)
+			q := NewQueries(mockSvc, map[string]*Table{}, nil)
+
+			res, err := q.BatchExecuteStatement(context.Background(), tt.statements)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, len(tt.expectedErrs))
+				assert.Equal(t, "1", res[0].Item["id"])
+				for i, expected := range tt.expectedErrs {
+					if expected == nil {
+						assert.NoError(t, res[i].Err)
+						continue
+					}
+					assert.EqualError(t, res[i].Err, expected.Error())
+					assert.ErrorIs(t, res[i].Err, ErrConditionCheckFailed)
+				}
+			}
+		})
+	}
+}
+
+func TestQueries_WithDecoderOptions(t *testing.T) {
+	const amount = "12345678901234567890.123456789"
+
+	item, err := attributevalue.MarshalMap(struct {
+		ID     string      `dynamodbav:"id"`
+		Amount json.Number `dynamodbav:"amount"`
+	}{ID: "1", Amount: json.Number(amount)})
+	require.NoError(t, err)
+	require.Equal(t, &types.AttributeValueMemberN{Value: amount}, item["amount"])
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []func(*attributevalue.DecoderOptions)
+		expected any
+	}{
+		{
+			name:     "UseNumber",
+			opts:     []func(*attributevalue.DecoderOptions){UseNumber},
+			expected: attributevalue.Number(amount),
+		},
+		{
+			name:     "Default",
+			opts:     nil,
+			expected: 12345678901234567890.123456789,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.ScanOutput{
+				Items: []map[string]types.AttributeValue{item},
+			}, nil).Times(1)
+
+			q := NewQueries(m, tables, nil).WithDecoderOptions(tt.opts...)
+
+			res, err := q.ScanItems(context.Background(), QueryItemsParams{
+				TableName:  "test-table",
+				Expression: NewExpression(),
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Rows, 1)
+			assert.Equal(t, tt.expected, res.Rows[0]["amount"])
+		})
+	}
+}
+
+func TestQueries_JSONTags(t *testing.T) {
+	type jsonItem struct {
+		ID       string `json:"id"`
+		Name     string `json:"name,omitempty"`
+		Internal string `json:"-"`
+	}
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	t.Run("Encode", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+				assert.Equal(t, map[string]types.AttributeValue{
+					"id": &types.AttributeValueMemberS{Value: "1"},
+				}, in.Item)
+				return &dynamodb.PutItemOutput{}, nil
+			}).Times(1)
+
+		q := NewQueries(m, tables, nil).WithEncoderOptions(EncodeJSONTags)
+
+		err := q.CreateItem(context.Background(), jsonItem{ID: "1", Internal: "secret"}, "test-table")
+		require.NoError(t, err)
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		m.EXPECT().GetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{
+			Item: map[string]types.AttributeValue{
+				"id":   &types.AttributeValueMemberS{Value: "1"},
+				"name": &types.AttributeValueMemberS{Value: "test"},
+			},
+		}, nil).Times(1)
+
+		q := NewQueries(m, tables, nil).WithDecoderOptions(DecodeJSONTags)
+
+		var item jsonItem
+		err := q.GetItem(context.Background(), GetItemParams{
+			Query:      CreateNewQueryObj("1", nil),
+			TableName:  "test-table",
+			ItemPtr:    &item,
+			Expression: NewExpression(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, jsonItem{ID: "1", Name: "test"}, item)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+				// json tags are ignored without EncodeJSONTags
+				assert.Contains(t, in.Item, "ID")
+				assert.Contains(t, in.Item, "Internal")
+				assert.NotContains(t, in.Item, "id")
+				return &dynamodb.PutItemOutput{}, nil
+			}).Times(1)
+
+		q := NewQueries(m, tables, nil)
+
+		err := q.CreateItem(context.Background(), jsonItem{ID: "1", Internal: "secret"}, "test-table")
+		require.NoError(t, err)
+	})
+}
+
+func TestQueries_BatchGetWithParams(t *testing.T) {
+	tests := []struct {
+		name            string
+		consistentReads bool
+		expected        *bool
+	}{
+		{name: "Default", consistentReads: false, expected: nil},
+		{name: "ConsistentReads", consistentReads: true, expected: aws.Bool(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+					ka := in.RequestItems["test-table"]
+					assert.Equal(t, tt.expected, ka.ConsistentRead)
+					// respond out of order
+					return &dynamodb.BatchGetItemOutput{
+						Responses: map[string][]map[string]types.AttributeValue{"test-table": {ka.Keys[1], ka.Keys[0]}},
+					}, nil
+				}).Times(1)
+
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "N"},
+			}
+			q := NewQueries(m, tables, nil)
+
+			res, err := q.BatchGetWithParams(context.Background(), BatchGetParams{
+				TableName:       "test-table",
+				Queries:         []*Query{CreateNewQueryObj(1, nil), CreateNewQueryObj(2, nil)},
+				Expression:      NewExpression(),
+				ConsistentReads: tt.consistentReads,
+			})
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+			assert.Equal(t, []QueryRow{{"id": float64(1)}, {"id": float64(2)}}, res)
+		})
+	}
+}
+
+func TestQueries_BatchGetAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		keys          int
+		expectedCalls int
+	}{
+		{name: "100Keys", keys: 100, expectedCalls: 1},
+		{name: "101Keys", keys: 101, expectedCalls: 2},
+		{name: "250Keys", keys: 250, expectedCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// echo the requested keys back in reverse order
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+					keys := in.RequestItems["test-table"].Keys
+					if len(keys) > 100 {
+						return nil, errors.New("too many keys")
+					}
+					items := make([]map[string]types.AttributeValue, 0, len(keys))
+					for i := len(keys) - 1; i >= 0; i-- {
+						items = append(items, keys[i])
+					}
+					return &dynamodb.BatchGetItemOutput{
+						Responses: map[string][]map[string]types.AttributeValue{"test-table": items},
+					}, nil
+				}).Times(tt.expectedCalls)
+
+			tables := map[string]*Table{
+				"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "N"},
+			}
+			q := NewQueries(m, tables, nil)
+
+			queries := make([]*Query, 0, tt.keys)
+			for i := 0; i < tt.keys; i++ {
+				queries = append(queries, CreateNewQueryObj(i, nil))
+			}
+
+			res, err := q.BatchGetAll(context.Background(), "test-table", queries, NewExpression())
+			require.NoError(t, err)
+			require.Len(t, res, tt.keys)
+			for i, row := range res {
+				assert.Equal(t, float64(i), row["id"])
+			}
+		})
+	}
+}
+
+func TestQueries_BatchGetAligned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// only even keys exist; they're returned in reverse order
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			assert.True(t, aws.ToBool(in.RequestItems["test-table"].ConsistentRead))
+			keys := in.RequestItems["test-table"].Keys
+			items := make([]map[string]types.AttributeValue, 0, len(keys))
+			for i := len(keys) - 1; i >= 0; i-- {
+				if n := keys[i]["id"].(*types.AttributeValueMemberN).Value; n == "0" || n == "2" || n == "4" {
+					items = append(items, keys[i])
+				}
+			}
+			return &dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]types.AttributeValue{"test-table": items},
+			}, nil
+		}).Times(1)
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "N"},
+	}
+	q := NewQueries(m, tables, nil)
+
+	queries := []*Query{
+		CreateNewQueryObj(0, nil),
+		CreateNewQueryObj(1, nil),
+		CreateNewQueryObj(2, nil),
+		nil,
+		CreateNewQueryObj(3, nil),
+		CreateNewQueryObj(4, nil),
+	}
+
+	res, err := q.BatchGetAligned(context.Background(), BatchGetParams{TableName: "test-table", Queries: queries, ConsistentReads: true})
+	require.NoError(t, err)
+	require.Len(t, res, len(queries))
+
+	expectedFound := []bool{true, false, true, false, false, true}
+	for i, r := range res {
+		assert.Same(t, queries[i], r.Query)
+		assert.Equal(t, expectedFound[i], r.Found, "query %d", i)
+		if r.Found {
+			assert.Equal(t, queries[i].PrimaryValue, int(r.Item["id"].(float64)))
+		} else {
+			assert.Nil(t, r.Item)
+		}
+	}
+
+	t.Run("TableNotFound", func(t *testing.T) {
+		_, err := q.BatchGetAligned(context.Background(), BatchGetParams{TableName: "missing-table", Queries: queries})
+		assert.EqualError(t, err, NewTableNotFoundError("missing-table").Error())
+	})
+}
+
+func TestQueries_BatchGetAligned_Duplicates(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eb := NewExprBuilder()
+	eb.SetProjection([]string{"name"})
+	expr, err := eb.BuildExpression()
+	require.NoError(t, err)
+
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			ka := in.RequestItems["test-table"]
+			// each key is requested once and the key attribute is projected
+			require.Len(t, ka.Keys, 2)
+			assert.Equal(t, aws.String("#0, #projKey0"), ka.ProjectionExpression)
+			assert.Equal(t, map[string]string{"#0": "name", "#projKey0": "id"}, ka.ExpressionAttributeNames)
+			items := make([]map[string]types.AttributeValue, 0, len(ka.Keys))
+			for _, key := range ka.Keys {
+				id := key["id"].(*types.AttributeValueMemberN).Value
+				items = append(items, map[string]types.AttributeValue{
+					"id":   key["id"],
+					"name": &types.AttributeValueMemberS{Value: "name-" + id},
+				})
+			}
+			return &dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]types.AttributeValue{"test-table": items},
+			}, nil
+		}).Times(1)
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "N"},
+	}
+	q := NewQueries(m, tables, nil)
+
+	queries := []*Query{
+		CreateNewQueryObj(1, nil),
+		CreateNewQueryObj(2, nil),
+		CreateNewQueryObj(1, nil),
+	}
+	res, err := q.BatchGetAligned(context.Background(), BatchGetParams{TableName: "test-table", Queries: queries, Expression: expr})
+	require.NoError(t, err)
+	require.Len(t, res, len(queries))
+
+	expectedNames := []string{"name-1", "name-2", "name-1"}
+	for i, r := range res {
+		assert.Same(t, queries[i], r.Query)
+		assert.True(t, r.Found, "query %d", i)
+		assert.Equal(t, expectedNames[i], r.Item["name"])
+	}
+}
+
+func TestQueries_BatchGetAligned_SeparatorInKey(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			ka := in.RequestItems["test-table"]
+			// the keys don't collide, so both are requested
+			require.Len(t, ka.Keys, 2)
+			items := make([]map[string]types.AttributeValue, 0, len(ka.Keys))
+			for _, key := range ka.Keys {
+				pk := key["pk"].(*types.AttributeValueMemberS).Value
+				items = append(items, map[string]types.AttributeValue{
+					"pk":   key["pk"],
+					"sk":   key["sk"],
+					"name": &types.AttributeValueMemberS{Value: "name-" + pk},
+				})
+			}
+			return &dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]types.AttributeValue{"test-table": items},
+			}, nil
+		}).Times(1)
+
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "pk", PrimaryKeyType: "S", SortKeyName: "sk", SortKeyType: "S"},
+	}
+	q := NewQueries(m, tables, nil)
+
+	// joined with a separator, both keys would read S:x|S:y|S:z
+	queries := []*Query{
+		CreateNewQueryObj("x|S:y", "z"),
+		CreateNewQueryObj("x", "y|S:z"),
+	}
+	res, err := q.BatchGetAligned(context.Background(), BatchGetParams{TableName: "test-table", Queries: queries})
+	require.NoError(t, err)
+	require.Len(t, res, len(queries))
+
+	expectedNames := []string{"name-x|S:y", "name-x"}
+	for i, r := range res {
+		assert.True(t, r.Found, "query %d", i)
+		assert.Equal(t, expectedNames[i], r.Item["name"])
+	}
+}
+
+func TestQueries_BatchDeadline(t *testing.T) {
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "N"},
+	}
+
+	t.Run("BatchGetAll", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// the first batch succeeds and the second is throttled
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		gomock.InOrder(
+			m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+					return &dynamodb.BatchGetItemOutput{
+						Responses: map[string][]map[string]types.AttributeValue{"test-table": in.RequestItems["test-table"].Keys},
+					}, nil
+				}),
+			m.EXPECT().BatchGetItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ProvisionedThroughputExceededException{}),
+		)
+
+		// the first retry waits at least 2s, which exceeds the deadline
+		now := time.Now()
+		q := NewQueries(m, tables, NewFailConfig(1000, 60000, 1).WithClock(goaws.NewFakeClock(now)))
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(100*time.Millisecond))
+		defer cancel()
+
+		queries := make([]*Query, 0, 150)
+		for i := 0; i < 150; i++ {
+			queries = append(queries, CreateNewQueryObj(i, nil))
+		}
+
+		res, err := q.BatchGetAll(ctx, "test-table", queries, NewExpression())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, err, ErrDeadlineExceeded)
+		assert.Implements(t, (*goaws.AwsError)(nil), errors.Unwrap(err))
+		require.Len(t, res, 100)
+		for i, row := range res {
+			assert.Equal(t, float64(i), row["id"])
+		}
+	})
+
+	t.Run("BatchWriteCreate", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ProvisionedThroughputExceededException{}).Times(1)
+
+		now := time.Now()
+		q := NewQueries(m, tables, NewFailConfig(1000, 60000, 1).WithClock(goaws.NewFakeClock(now)))
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(100*time.Millisecond))
+		defer cancel()
+
+		err := q.BatchWriteCreate(ctx, "test-table", []any{map[string]any{"id": 1}})
+		require.Error(t, err)
+		assert.EqualError(t, err, "retries.ExponentialBackoffContext: context deadline exceeded before next retry")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestQueries_RegisterTable(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil).Times(1)
+
+	q := NewQueries(m, nil, nil)
+	query := CreateNewQueryObj("1", nil)
+
+	err := q.DeleteItem(context.Background(), query, "new-table")
+	assert.EqualError(t, err, NewTableNotFoundError("new-table").Error())
+
+	q.RegisterTable(&Table{TableName: "new-table", PrimaryKeyName: "id", PrimaryKeyType: "S"})
+	err = q.DeleteItem(context.Background(), query, "new-table")
+	require.NoError(t, err)
+
+	q.UnregisterTable("new-table")
+	err = q.DeleteItem(context.Background(), query, "new-table")
+	assert.EqualError(t, err, NewTableNotFoundError("new-table").Error())
+}
+
+// TestQueries_Concurrent registers tables while querying from multiple goroutines;
+// run with -race to detect unsynchronized access to the tables map.
+func TestQueries_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const workers = 20
+
+	m := NewMockDynamoDBQueriesClientAPI(ctrl)
+	m.EXPECT().DeleteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil).Times(workers)
+
+	q := NewQueries(m, map[string]*Table{}, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("table-%d", i)
+			q.RegisterTable(&Table{TableName: name, PrimaryKeyName: "id", PrimaryKeyType: "S"})
+			assert.NoError(t, q.DeleteItem(context.Background(), CreateNewQueryObj("1", nil), name))
+			q.UnregisterTable(name)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Empty(t, q.tables)
+}
@@ -0,0 +1,83 @@
+package godynamo
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxItemSize is the maximum size in bytes of a DynamoDB item,
+// including attribute names and values.
+const MaxItemSize = 400 * 1024
+
+// checkItemSize returns an ItemTooLargeError if item exceeds MaxItemSize.
+func checkItemSize(item map[string]types.AttributeValue) error {
+	if size := itemSize(item); size > MaxItemSize {
+		return NewItemTooLargeError(size)
+	}
+	return nil
+}
+
+// itemSize returns the size of item in bytes per the DynamoDB item size rules:
+// https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/CapacityUnitCalculations.html
+func itemSize(item map[string]types.AttributeValue) int {
+	size := 0
+	for name, av := range item {
+		size += len(name) + attributeSize(av)
+	}
+	return size
+}
+
+// attributeSize returns the size of av in bytes. Maps and lists are
+// charged 3 bytes plus 1 byte per element in addition to their contents.
+func attributeSize(av types.AttributeValue) int {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return len(v.Value)
+	case *types.AttributeValueMemberN:
+		return numberSize(v.Value)
+	case *types.AttributeValueMemberB:
+		return len(v.Value)
+	case *types.AttributeValueMemberBOOL, *types.AttributeValueMemberNULL:
+		return 1
+	case *types.AttributeValueMemberSS:
+		size := 0
+		for _, s := range v.Value {
+			size += len(s)
+		}
+		return size
+	case *types.AttributeValueMemberNS:
+		size := 0
+		for _, n := range v.Value {
+			size += numberSize(n)
+		}
+		return size
+	case *types.AttributeValueMemberBS:
+		size := 0
+		for _, b := range v.Value {
+			size += len(b)
+		}
+		return size
+	case *types.AttributeValueMemberM:
+		return 3 + len(v.Value) + itemSize(v.Value)
+	case *types.AttributeValueMemberL:
+		size := 3 + len(v.Value)
+		for _, e := range v.Value {
+			size += attributeSize(e)
+		}
+		return size
+	default:
+		return 0
+	}
+}
+
+// numberSize returns the size of the number n in bytes: 1 byte per
+// 2 significant digits plus 1 byte.
+func numberSize(n string) int {
+	digits := strings.TrimLeft(strings.NewReplacer("-", "", "+", "", ".", "").Replace(n), "0")
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		digits = digits[:i]
+	}
+	digits = strings.TrimRight(digits, "0")
+	return (len(digits)+1)/2 + 1
+}
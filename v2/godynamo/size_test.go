@@ -0,0 +1,84 @@
+package godynamo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestItemSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     map[string]types.AttributeValue
+		expected int
+	}{
+		{name: "string", item: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "abc"}}, expected: 5},
+		{name: "number", item: map[string]types.AttributeValue{"n": &types.AttributeValueMemberN{Value: "-123.45"}}, expected: 5},
+		{name: "number trailing zeros", item: map[string]types.AttributeValue{"n": &types.AttributeValueMemberN{Value: "1000"}}, expected: 3},
+		{name: "binary", item: map[string]types.AttributeValue{"b": &types.AttributeValueMemberB{Value: []byte{1, 2}}}, expected: 3},
+		{name: "bool and null", item: map[string]types.AttributeValue{
+			"t": &types.AttributeValueMemberBOOL{Value: true},
+			"n": &types.AttributeValueMemberNULL{Value: true},
+		}, expected: 4},
+		{name: "string set", item: map[string]types.AttributeValue{"ss": &types.AttributeValueMemberSS{Value: []string{"a", "bc"}}}, expected: 5},
+		{name: "map", item: map[string]types.AttributeValue{"m": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"k": &types.AttributeValueMemberS{Value: "v"},
+		}}}, expected: 7},
+		{name: "list", item: map[string]types.AttributeValue{"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "ab"},
+			&types.AttributeValueMemberBOOL{Value: false},
+		}}}, expected: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, itemSize(tt.item))
+		})
+	}
+}
+
+func TestQueries_CreateItemSizeLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		dataLen       int
+		expectedCalls int
+		expectedError error
+	}{
+		{name: "at limit", dataLen: MaxItemSize - len("id") - 1 - len("data"), expectedCalls: 1},
+		{name: "over limit", dataLen: MaxItemSize - len("id") - 1 - len("data") + 1, expectedError: NewItemTooLargeError(MaxItemSize + 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDynamoDBQueriesClientAPI(ctrl)
+			m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(tt.expectedCalls)
+			m.EXPECT().BatchWriteItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(0)
+
+			tables := map[string]*Table{"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"}}
+			q := NewQueries(m, tables, nil)
+			item := map[string]string{"id": "1", "data": strings.Repeat("a", tt.dataLen)}
+
+			err := q.CreateItem(context.Background(), item, "test-table")
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrItemTooLarge)
+
+				err = q.BatchWriteCreate(context.Background(), "test-table", []any{item})
+				assert.ErrorIs(t, err, ErrItemTooLarge)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
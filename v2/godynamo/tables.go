@@ -3,6 +3,7 @@ package godynamo
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -16,6 +17,11 @@ type TablesLogic interface {
 	ListTables(ctx context.Context, params ListTableParams) ([]string, int, error)
 	CreateTable(ctx context.Context, table *Table) error
 	DeleteTable(ctx context.Context, tableName string) error
+	RegisterTable(table *Table)
+	UnregisterTable(tableName string)
+	ExportToS3(ctx context.Context, tableArn, s3Bucket, s3Prefix string) (*ExportResponse, error)
+	DescribeExport(ctx context.Context, exportArn string) (*ExportResponse, error)
+	DescribeTimeToLive(ctx context.Context, tableName string) (*TTLStatus, error)
 }
 
 // DynamoDBTablesClientAPI defines the interface for the AWS DynamoDB client methods used by this package.
@@ -25,11 +31,15 @@ type DynamoDBTablesClientAPI interface {
 	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
 	CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
 	DeleteTable(ctx context.Context, params *dynamodb.DeleteTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error)
+	ExportTableToPointInTime(ctx context.Context, params *dynamodb.ExportTableToPointInTimeInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExportTableToPointInTimeOutput, error)
+	DescribeExport(ctx context.Context, params *dynamodb.DescribeExportInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeExportOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
 }
 
 type Tables struct {
 	svc    DynamoDBTablesClientAPI
 	tables map[string]*Table
+	mu     sync.RWMutex
 }
 
 func NewTables(svc DynamoDBTablesClientAPI, tables map[string]*Table) *Tables {
@@ -101,7 +111,9 @@ func (t *Tables) CreateTable(ctx context.Context, table *Table) error {
 		return handleErr(fmt.Errorf("t.svc.CreateTable: %w", err))
 	}
 
+	t.mu.Lock()
 	t.tables[table.TableName] = table
+	t.mu.Unlock()
 
 	return nil
 }
@@ -109,7 +121,9 @@ func (t *Tables) CreateTable(ctx context.Context, table *Table) error {
 // DeleteTable deletes the selected table.
 func (t *Tables) DeleteTable(ctx context.Context, tableName string) error {
 	// get table
+	t.mu.RLock()
 	table, ok := t.tables[tableName]
+	t.mu.RUnlock()
 	if !ok {
 		return NewTableNotFoundError(tableName)
 	}
@@ -121,7 +135,88 @@ func (t *Tables) DeleteTable(ctx context.Context, tableName string) error {
 		return handleErr(fmt.Errorf("t.svc.DeleteTable: %w", err))
 	}
 
+	t.mu.Lock()
 	delete(t.tables, tableName)
+	t.mu.Unlock()
 
 	return nil
 }
+
+// RegisterTable adds an existing table to the set of tables managed by t.
+func (t *Tables) RegisterTable(table *Table) {
+	if table == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tables[table.TableName] = table
+}
+
+// UnregisterTable removes the given table from the set of tables managed by t.
+// The table itself is not deleted.
+func (t *Tables) UnregisterTable(tableName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.tables, tableName)
+}
+
+// ExportToS3 starts an export of the table's point in time recovery data to the given S3 bucket and prefix.
+// Point in time recovery must be enabled on the table. Use DescribeExport with the returned ExportArn
+// to poll the status of the export.
+func (t *Tables) ExportToS3(ctx context.Context, tableArn, s3Bucket, s3Prefix string) (*ExportResponse, error) {
+	if tableArn == "" || s3Bucket == "" {
+		return nil, NewNilModelError()
+	}
+
+	input := &dynamodb.ExportTableToPointInTimeInput{
+		TableArn:     aws.String(tableArn),
+		S3Bucket:     aws.String(s3Bucket),
+		ExportFormat: types.ExportFormatDynamodbJson,
+	}
+	if s3Prefix != "" {
+		input.S3Prefix = aws.String(s3Prefix)
+	}
+
+	result, err := t.svc.ExportTableToPointInTime(ctx, input)
+	if err != nil {
+		return nil, handleErr(fmt.Errorf("t.svc.ExportTableToPointInTime: %w", err))
+	}
+
+	return newExportResponse(result.ExportDescription), nil
+}
+
+// DescribeExport returns the current status of the export with the given ARN.
+func (t *Tables) DescribeExport(ctx context.Context, exportArn string) (*ExportResponse, error) {
+	if exportArn == "" {
+		return nil, NewNilModelError()
+	}
+
+	input := &dynamodb.DescribeExportInput{
+		ExportArn: aws.String(exportArn),
+	}
+	result, err := t.svc.DescribeExport(ctx, input)
+	if err != nil {
+		return nil, handleErr(fmt.Errorf("t.svc.DescribeExport: %w", err))
+	}
+
+	return newExportResponse(result.ExportDescription), nil
+}
+
+// DescribeTimeToLive returns the time to live setting of the given table. Use
+// TTLStatus.EnabledOn to confirm TTL is enabled on the expected attribute before
+// relying on items expiring.
+func (t *Tables) DescribeTimeToLive(ctx context.Context, tableName string) (*TTLStatus, error) {
+	if tableName == "" {
+		return nil, NewNilModelError()
+	}
+
+	input := &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(tableName),
+	}
+	result, err := t.svc.DescribeTimeToLive(ctx, input)
+	if err != nil {
+		return nil, handleErr(fmt.Errorf("t.svc.DescribeTimeToLive: %w", err))
+	}
+
+	return newTTLStatus(result.TimeToLiveDescription), nil
+}
@@ -3,10 +3,14 @@ package godynamo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -252,3 +256,337 @@ func TestTables_DeleteTable(t *testing.T) {
 		})
 	}
 }
+
+func TestTables_ExportToS3(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name          string
+		tableArn      string
+		bucket        string
+		prefix        string
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBTablesClientAPI
+		expected      *ExportResponse
+		expectedError error
+	}{
+		{
+			name:     "Success",
+			tableArn: "arn:aws:dynamodb:us-east-1:123456789012:table/test-table",
+			bucket:   "test-bucket",
+			prefix:   "exports/",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().ExportTableToPointInTime(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *dynamodb.ExportTableToPointInTimeInput, _ ...func(*dynamodb.Options)) (*dynamodb.ExportTableToPointInTimeOutput, error) {
+						assert.Equal(t, "arn:aws:dynamodb:us-east-1:123456789012:table/test-table", aws.ToString(in.TableArn))
+						assert.Equal(t, "test-bucket", aws.ToString(in.S3Bucket))
+						assert.Equal(t, "exports/", aws.ToString(in.S3Prefix))
+						assert.Equal(t, types.ExportFormatDynamodbJson, in.ExportFormat)
+						return &dynamodb.ExportTableToPointInTimeOutput{
+							ExportDescription: &types.ExportDescription{
+								ExportArn:    aws.String("arn:export"),
+								ExportStatus: types.ExportStatusInProgress,
+								S3Bucket:     in.S3Bucket,
+								S3Prefix:     in.S3Prefix,
+								StartTime:    aws.Time(start),
+							},
+						}, nil
+					}).Times(1)
+				return m
+			},
+			expected: &ExportResponse{
+				ExportArn: "arn:export",
+				Status:    "IN_PROGRESS",
+				S3Bucket:  "test-bucket",
+				S3Prefix:  "exports/",
+				StartTime: start,
+			},
+		},
+		{
+			name:   "MissingTableArn",
+			bucket: "test-bucket",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				return NewMockDynamoDBTablesClientAPI(ctrl)
+			},
+			expectedError: NewNilModelError(),
+		},
+		{
+			name:     "Error",
+			tableArn: "arn:aws:dynamodb:us-east-1:123456789012:table/test-table",
+			bucket:   "test-bucket",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().ExportTableToPointInTime(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("export error")).Times(1)
+				return m
+			},
+			expectedError: errors.New("t.svc.ExportTableToPointInTime: export error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &Tables{svc: tt.mockSetup(ctrl), tables: make(map[string]*Table)}
+
+			resp, err := s.ExportToS3(context.Background(), tt.tableArn, tt.bucket, tt.prefix)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, resp)
+			}
+		})
+	}
+}
+
+func TestTables_DescribeExport(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	tests := []struct {
+		name          string
+		exportArn     string
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBTablesClientAPI
+		expected      *ExportResponse
+		expectedError error
+	}{
+		{
+			name:      "Completed",
+			exportArn: "arn:export",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeExport(gomock.Any(), &dynamodb.DescribeExportInput{ExportArn: aws.String("arn:export")}, gomock.Any()).Return(&dynamodb.DescribeExportOutput{
+					ExportDescription: &types.ExportDescription{
+						ExportArn:      aws.String("arn:export"),
+						ExportStatus:   types.ExportStatusCompleted,
+						S3Bucket:       aws.String("test-bucket"),
+						ExportManifest: aws.String("exports/manifest-summary.json"),
+						ItemCount:      aws.Int64(42),
+						StartTime:      aws.Time(start),
+						EndTime:        aws.Time(end),
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected: &ExportResponse{
+				ExportArn:      "arn:export",
+				Status:         "COMPLETED",
+				S3Bucket:       "test-bucket",
+				ExportManifest: "exports/manifest-summary.json",
+				ItemCount:      42,
+				StartTime:      start,
+				EndTime:        end,
+			},
+		},
+		{
+			name:      "Failed",
+			exportArn: "arn:export",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeExport(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DescribeExportOutput{
+					ExportDescription: &types.ExportDescription{
+						ExportArn:      aws.String("arn:export"),
+						ExportStatus:   types.ExportStatusFailed,
+						FailureMessage: aws.String("access denied"),
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected: &ExportResponse{
+				ExportArn:      "arn:export",
+				Status:         "FAILED",
+				FailureMessage: "access denied",
+			},
+		},
+		{
+			name: "MissingExportArn",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				return NewMockDynamoDBTablesClientAPI(ctrl)
+			},
+			expectedError: NewNilModelError(),
+		},
+		{
+			name:      "Error",
+			exportArn: "arn:export",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeExport(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("describe error")).Times(1)
+				return m
+			},
+			expectedError: errors.New("t.svc.DescribeExport: describe error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &Tables{svc: tt.mockSetup(ctrl), tables: make(map[string]*Table)}
+
+			resp, err := s.DescribeExport(context.Background(), tt.exportArn)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, resp)
+			}
+		})
+	}
+}
+
+func TestTables_DescribeTimeToLive(t *testing.T) {
+	tests := []struct {
+		name          string
+		tableName     string
+		mockSetup     func(ctrl *gomock.Controller) DynamoDBTablesClientAPI
+		expected      *TTLStatus
+		enabledOn     string
+		expectedError error
+	}{
+		{
+			name:      "Enabled",
+			tableName: "test-table",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeTimeToLive(gomock.Any(), &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("test-table")}, gomock.Any()).Return(&dynamodb.DescribeTimeToLiveOutput{
+					TimeToLiveDescription: &types.TimeToLiveDescription{
+						AttributeName:    aws.String("expires_at"),
+						TimeToLiveStatus: types.TimeToLiveStatusEnabled,
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected:  &TTLStatus{Status: "ENABLED", AttributeName: "expires_at"},
+			enabledOn: "expires_at",
+		},
+		{
+			name:      "Enabling",
+			tableName: "test-table",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeTimeToLive(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DescribeTimeToLiveOutput{
+					TimeToLiveDescription: &types.TimeToLiveDescription{
+						AttributeName:    aws.String("expires_at"),
+						TimeToLiveStatus: types.TimeToLiveStatusEnabling,
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected: &TTLStatus{Status: "ENABLING", AttributeName: "expires_at"},
+		},
+		{
+			name:      "Disabled",
+			tableName: "test-table",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeTimeToLive(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DescribeTimeToLiveOutput{
+					TimeToLiveDescription: &types.TimeToLiveDescription{
+						TimeToLiveStatus: types.TimeToLiveStatusDisabled,
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected: &TTLStatus{Status: "DISABLED"},
+		},
+		{
+			name: "MissingTableName",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				return NewMockDynamoDBTablesClientAPI(ctrl)
+			},
+			expectedError: NewNilModelError(),
+		},
+		{
+			name:      "Error",
+			tableName: "test-table",
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTablesClientAPI {
+				m := NewMockDynamoDBTablesClientAPI(ctrl)
+				m.EXPECT().DescribeTimeToLive(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.ResourceNotFoundException{
+					Message: aws.String("table not found"),
+				}).Times(1)
+				return m
+			},
+			expectedError: NewResourceNotFoundError("table not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &Tables{svc: tt.mockSetup(ctrl), tables: make(map[string]*Table)}
+
+			resp, err := s.DescribeTimeToLive(context.Background(), tt.tableName)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, resp)
+				assert.Equal(t, tt.enabledOn != "", resp.EnabledOn("expires_at"))
+				assert.False(t, resp.EnabledOn("other_attr"))
+			}
+		})
+	}
+}
+
+func TestTables_RegisterTable(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDynamoDBTablesClientAPI(ctrl)
+	m.EXPECT().DeleteTable(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteTableOutput{}, nil).Times(1)
+
+	tables := NewTables(m, nil)
+	tables.RegisterTable(&Table{TableName: "new-table", PrimaryKeyName: "id", PrimaryKeyType: "S"})
+	tables.UnregisterTable("other-table")
+
+	err := tables.DeleteTable(context.Background(), "new-table")
+	require.NoError(t, err)
+
+	err = tables.DeleteTable(context.Background(), "new-table")
+	assert.EqualError(t, err, NewTableNotFoundError("new-table").Error())
+}
+
+// TestTables_Concurrent creates and deletes tables from multiple goroutines;
+// run with -race to detect unsynchronized access to the tables map.
+func TestTables_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const workers = 20
+
+	m := NewMockDynamoDBTablesClientAPI(ctrl)
+	m.EXPECT().CreateTable(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.CreateTableOutput{}, nil).Times(workers)
+	m.EXPECT().DeleteTable(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteTableOutput{}, nil).Times(workers)
+
+	tables := NewTables(m, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			table := CreateNewTableObj(fmt.Sprintf("table-%d", i), "id", "string", "", "")
+			assert.NoError(t, tables.CreateTable(context.Background(), table))
+			assert.NoError(t, tables.DeleteTable(context.Background(), table.TableName))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Empty(t, tables.tables)
+}
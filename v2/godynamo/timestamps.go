@@ -0,0 +1,118 @@
+package godynamo
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+)
+
+// Default attribute names for WithTimestamps.
+const (
+	DefaultCreatedAtAttribute = "created_at"
+	DefaultUpdatedAtAttribute = "updated_at"
+)
+
+// timestamps holds the names of the attributes set to the current time on writes.
+type timestamps struct {
+	createdAt string
+	updatedAt string
+	clock     goaws.Clock
+}
+
+// setClause matches the SET clause of an update expression.
+var setClause = regexp.MustCompile(`(?m)^SET `)
+
+// namePlaceholder matches the attribute name placeholders of an expression.
+var namePlaceholder = regexp.MustCompile(`#\w+`)
+
+// WithTimestamps makes the create methods set the createdAttr and updatedAttr attributes,
+// and UpdateItem set updatedAttr (and createdAttr if the item doesn't exist), to the
+// current time in RFC 3339 format, and returns q for chaining. UpdateItem leaves either
+// attribute alone if the update already sets it. An empty name disables that attribute.
+// clock defaults to goaws.RealClock if nil.
+// ex: q := NewQueries(svc, tables, nil).WithTimestamps(DefaultCreatedAtAttribute, DefaultUpdatedAtAttribute, nil)
+func (q *Queries) WithTimestamps(createdAttr, updatedAttr string, clock goaws.Clock) *Queries {
+	if clock == nil {
+		clock = goaws.RealClock{}
+	}
+	q.timestamps = &timestamps{createdAt: createdAttr, updatedAt: updatedAttr, clock: clock}
+	return q
+}
+
+func (ts *timestamps) now() *types.AttributeValueMemberS {
+	return &types.AttributeValueMemberS{Value: ts.clock.Now().UTC().Format(time.RFC3339Nano)}
+}
+
+// stampItem sets the timestamp attributes of an item to be created.
+func (ts *timestamps) stampItem(item map[string]types.AttributeValue) {
+	if ts == nil {
+		return
+	}
+	now := ts.now()
+	if ts.createdAt != "" {
+		item[ts.createdAt] = now
+	}
+	if ts.updatedAt != "" {
+		item[ts.updatedAt] = now
+	}
+}
+
+// stampUpdate adds the timestamp attributes to an update expression and returns the
+// expression with its merged attribute names and values. createdAt is only set if
+// the item doesn't have it yet. Attributes the update already targets are left to
+// the caller, as DynamoDB rejects overlapping paths.
+func (ts *timestamps) stampUpdate(update *string, names map[string]string, values map[string]types.AttributeValue) (*string, map[string]string, map[string]types.AttributeValue) {
+	if ts == nil {
+		return update, names, values
+	}
+
+	targeted := make(map[string]bool)
+	for _, placeholder := range namePlaceholder.FindAllString(aws.ToString(update), -1) {
+		targeted[names[placeholder]] = true
+	}
+	updatedAt, createdAt := ts.updatedAt, ts.createdAt
+	if targeted[updatedAt] {
+		updatedAt = ""
+	}
+	if targeted[createdAt] {
+		createdAt = ""
+	}
+	if createdAt == "" && updatedAt == "" {
+		return update, names, values
+	}
+
+	mergedNames := make(map[string]string, len(names)+2)
+	for k, v := range names {
+		mergedNames[k] = v
+	}
+	mergedValues := make(map[string]types.AttributeValue, len(values)+1)
+	for k, v := range values {
+		mergedValues[k] = v
+	}
+	mergedValues[":tsNow"] = ts.now()
+
+	actions := ""
+	if updatedAt != "" {
+		mergedNames["#tsUpdatedAt"] = updatedAt
+		actions = "#tsUpdatedAt = :tsNow"
+	}
+	if createdAt != "" {
+		mergedNames["#tsCreatedAt"] = createdAt
+		if actions != "" {
+			actions += ", "
+		}
+		actions += "#tsCreatedAt = if_not_exists(#tsCreatedAt, :tsNow)"
+	}
+
+	expr := aws.ToString(update)
+	if loc := setClause.FindStringIndex(expr); loc != nil {
+		expr = expr[:loc[1]] + actions + ", " + expr[loc[1]:]
+	} else {
+		expr = "SET " + actions + "\n" + expr
+	}
+
+	return aws.String(expr), mergedNames, mergedValues
+}
@@ -0,0 +1,108 @@
+package godynamo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestQueries_WithTimestamps(t *testing.T) {
+	t.Parallel()
+	clock := goaws.NewFakeClock(time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC))
+	now := &types.AttributeValueMemberS{Value: "2024-05-01T12:30:00Z"}
+	tables := map[string]*Table{
+		"test-table": {TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"},
+	}
+
+	t.Run("CreateItem", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		m.EXPECT().PutItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+				assert.Equal(t, now, in.Item[DefaultCreatedAtAttribute])
+				assert.Equal(t, now, in.Item[DefaultUpdatedAtAttribute])
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "1"}, in.Item["id"])
+				return &dynamodb.PutItemOutput{}, nil
+			}).Times(1)
+
+		q := NewQueries(m, tables, nil).WithTimestamps(DefaultCreatedAtAttribute, DefaultUpdatedAtAttribute, clock)
+		err := q.CreateItem(context.Background(), map[string]any{"id": "1"}, "test-table")
+		require.NoError(t, err)
+	})
+
+	t.Run("UpdateItem", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m := NewMockDynamoDBQueriesClientAPI(ctrl)
+		m.EXPECT().UpdateItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+				assert.Equal(t, "SET #tsUpdatedAt = :tsNow, #tsCreatedAt = if_not_exists(#tsCreatedAt, :tsNow), #0 = :0\n", aws.ToString(in.UpdateExpression))
+				assert.Equal(t, "modified", in.ExpressionAttributeNames["#tsUpdatedAt"])
+				assert.Equal(t, "created", in.ExpressionAttributeNames["#tsCreatedAt"])
+				assert.Equal(t, "name", in.ExpressionAttributeNames["#0"])
+				assert.Equal(t, now, in.ExpressionAttributeValues[":tsNow"])
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "bob"}, in.ExpressionAttributeValues[":0"])
+				return &dynamodb.UpdateItemOutput{}, nil
+			}).Times(1)
+
+		ud := NewUpdateExpr()
+		ud.Set("name", "bob")
+		eb := NewExprBuilder()
+		eb.SetUpdate(ud)
+		expr, err := eb.BuildExpression()
+		require.NoError(t, err)
+
+		q := NewQueries(m, tables, nil).WithTimestamps("created", "modified", clock)
+		err = q.UpdateItem(context.Background(), CreateNewQueryObj("1", nil), "test-table", expr)
+		require.NoError(t, err)
+	})
+}
+
+func TestTimestamps_StampUpdate(t *testing.T) {
+	t.Parallel()
+	ts := &timestamps{updatedAt: DefaultUpdatedAtAttribute, clock: goaws.NewFakeClock(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))}
+	names := map[string]string{"#0": "tags"}
+
+	update, gotNames, gotValues := ts.stampUpdate(aws.String("REMOVE #0\n"), names, nil)
+
+	assert.Equal(t, "SET #tsUpdatedAt = :tsNow\nREMOVE #0\n", aws.ToString(update))
+	assert.Equal(t, map[string]string{"#0": "tags", "#tsUpdatedAt": DefaultUpdatedAtAttribute}, gotNames)
+	assert.Equal(t, map[string]types.AttributeValue{":tsNow": &types.AttributeValueMemberS{Value: "2024-05-01T00:00:00Z"}}, gotValues)
+	assert.Len(t, names, 1, "input names must not be modified")
+
+	var nilTs *timestamps
+	update, _, _ = nilTs.stampUpdate(aws.String("REMOVE #0\n"), names, nil)
+	assert.Equal(t, "REMOVE #0\n", aws.ToString(update))
+}
+
+func TestTimestamps_StampUpdate_TargetedAttribute(t *testing.T) {
+	t.Parallel()
+	ts := &timestamps{
+		createdAt: DefaultCreatedAtAttribute,
+		updatedAt: DefaultUpdatedAtAttribute,
+		clock:     goaws.NewFakeClock(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	// #1 is only used by the condition, so it doesn't count as targeted
+	names := map[string]string{"#0": DefaultUpdatedAtAttribute, "#1": DefaultCreatedAtAttribute}
+	values := map[string]types.AttributeValue{":0": &types.AttributeValueMemberS{Value: "2020-01-01T00:00:00Z"}}
+
+	update, gotNames, _ := ts.stampUpdate(aws.String("SET #0 = :0\n"), names, values)
+
+	assert.Equal(t, "SET #tsCreatedAt = if_not_exists(#tsCreatedAt, :tsNow), #0 = :0\n", aws.ToString(update))
+	assert.NotContains(t, gotNames, "#tsUpdatedAt")
+
+	ts.createdAt = ""
+	update, gotNames, gotValues := ts.stampUpdate(aws.String("SET #0 = :0\n"), names, values)
+	assert.Equal(t, "SET #0 = :0\n", aws.ToString(update))
+	assert.Equal(t, names, gotNames)
+	assert.Equal(t, values, gotValues)
+}
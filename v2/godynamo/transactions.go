@@ -2,6 +2,10 @@ package godynamo
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,11 +27,13 @@ type DynamoDBTransactionsClientAPI interface {
 //go:generate mockgen -destination=../mocks/godynamomock/transactions.go -package=godynamomock . TransactionsLogic
 type TransactionsLogic interface {
 	TxWrite(ctx context.Context, items []TransactionItem, requestToken string) ([]TransactionItem, error)
+	TxWriteWithRetry(ctx context.Context, items []TransactionItem, requestToken string) ([]TransactionItem, error)
 }
 
 type Transactions struct {
-	svc DynamoDBTransactionsClientAPI
-	fc  *FailConfig
+	svc                 DynamoDBTransactionsClientAPI
+	fc                  *FailConfig
+	deterministicTokens bool
 }
 
 func NewTransactions(svc DynamoDBTransactionsClientAPI, fc *FailConfig) *Transactions {
@@ -40,6 +46,16 @@ func NewTransactions(svc DynamoDBTransactionsClientAPI, fc *FailConfig) *Transac
 	}
 }
 
+// WithDeterministicRequestTokens makes TxWrite and TxWriteWithRetry derive the client
+// request token from a hash of the transaction items when requestToken is empty, so
+// retries of the same logical transaction are idempotent, and returns t for chaining.
+// Note that DynamoDB treats identical transactions sent within the 10 minute idempotency
+// window as a single transaction.
+func (t *Transactions) WithDeterministicRequestTokens() *Transactions {
+	t.deterministicTokens = true
+	return t
+}
+
 // TxConditionCheck checks that each conditional check for a list of transaction items passes. Failed condition checks
 // return an error value, and a list of the TransactionItems that failed their condition checks. Successful condition
 // checks return an empty list of TransactionItems and nil error value.
@@ -64,6 +80,15 @@ func (t *Transactions) TxWrite(ctx context.Context, items []TransactionItem, req
 		txInput.TransactItems = append(txInput.TransactItems, *txItem)
 	}
 
+	// derive the client request token from the items if configured
+	if requestToken == "" && t.deterministicTokens {
+		token, err := txRequestToken(txInput.TransactItems)
+		if err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("txRequestToken: %w", err))
+		}
+		txInput.ClientRequestToken = aws.String(token)
+	}
+
 	failed := make([]TransactionItem, 0)
 
 	if _, err := t.svc.TransactWriteItems(ctx, txInput); err != nil {
@@ -75,6 +100,7 @@ func (t *Transactions) TxWrite(ctx context.Context, items []TransactionItem, req
 		case errors.As(err, &txCanceled):
 			check := false     // denotes conditional checks failed
 			throttled := false // denotes if tx failed due to throttling
+			conflict := false  // denotes if tx conflicted with another transaction
 			msg := ""
 
 			for i, r := range txCanceled.CancellationReasons {
@@ -94,6 +120,10 @@ func (t *Transactions) TxWrite(ctx context.Context, items []TransactionItem, req
 					}
 					failed = append(failed, items[i])
 				}
+				if *r.Code == string(types.BatchStatementErrorCodeEnumTransactionConflict) {
+					conflict = true
+					failed = append(failed, items[i])
+				}
 			}
 
 			if check {
@@ -104,6 +134,10 @@ func (t *Transactions) TxWrite(ctx context.Context, items []TransactionItem, req
 				// retry
 				return failed, NewTxThrottledError()
 			}
+			if conflict {
+				// retry
+				return failed, NewTxConflictError()
+			}
 			// no retry
 			return failed, goaws.NewInternalError(fmt.Errorf("d.svc.TransactWriteItems: %w", err))
 		case errors.As(err, &txConflict):
@@ -114,24 +148,174 @@ func (t *Transactions) TxWrite(ctx context.Context, items []TransactionItem, req
 			return failed, NewTxInProgressError()
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
+				return nil, goaws.NewInternalError(fmt.Errorf("d.svc.TransactWriteItems: %w", err))
 			}
 			switch re.HTTPStatusCode() {
 			case http.StatusBadRequest:
 				return nil, NewBadTxRequestError()
+			case http.StatusForbidden:
+				return nil, goaws.NewAccessDeniedError(fmt.Errorf("d.svc.TransactWriteItems: %w", err))
 			case http.StatusNotFound:
 				return nil, NewResourceNotFoundError(re.Error())
 			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
+				return nil, goaws.NewInternalError(fmt.Errorf("d.svc.TransactWriteItems: %w", err))
 			}
 		default:
-			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
+			return nil, goaws.NewServiceError(fmt.Errorf("d.svc.TransactWriteItems: %w", err))
 		}
 	}
 
 	return failed, nil
 }
 
+// TxWriteWithRetry calls TxWrite, retrying with exponential backoff while the transaction conflicts
+// with another transaction, is throttled, or is still in progress. Every attempt sends the same
+// requestToken so the transaction is applied at most once; a token is generated if requestToken is empty,
+// or derived from the items if WithDeterministicRequestTokens is set. Retries stop with a
+// DeadlineExceededError if ctx's deadline would pass before the next attempt.
+func (t *Transactions) TxWriteWithRetry(ctx context.Context, items []TransactionItem, requestToken string) ([]TransactionItem, error) {
+	if requestToken == "" && !t.deterministicTokens {
+		token, err := newRequestToken()
+		if err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("newRequestToken: %w", err))
+		}
+		requestToken = token
+	}
+
+	retries := t.fc.NewRetries()
+	for {
+		failed, err := t.TxWrite(ctx, items, requestToken)
+		if err == nil || !isRetryableTxErr(err) {
+			return failed, err
+		}
+		if err := retries.ExponentialBackoffContext(ctx); err != nil { // waits
+			return failed, fmt.Errorf("retries.ExponentialBackoffContext: %w", err)
+		}
+	}
+}
+
+// isRetryableTxErr returns true if the transaction may succeed if sent again with the same token.
+func isRetryableTxErr(err error) bool {
+	return errors.Is(err, ErrTxConflict) || errors.Is(err, ErrTxThrottled) || errors.Is(err, ErrTxInProgress)
+}
+
+// newRequestToken returns a random client request token.
+func newRequestToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// txRequestToken returns a client request token derived from a SHA-256 hash of the
+// canonical JSON encoding of items. Identical items always produce the same token.
+func txRequestToken(items []types.TransactWriteItem) (string, error) {
+	canonical := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.Put != nil:
+			canonical = append(canonical, map[string]any{
+				"op":        "put",
+				"table":     aws.ToString(item.Put.TableName),
+				"item":      canonicalAVMap(item.Put.Item),
+				"condition": aws.ToString(item.Put.ConditionExpression),
+				"names":     canonicalNames(item.Put.ExpressionAttributeNames),
+				"values":    canonicalAVMap(item.Put.ExpressionAttributeValues),
+			})
+		case item.Update != nil:
+			canonical = append(canonical, map[string]any{
+				"op":        "update",
+				"table":     aws.ToString(item.Update.TableName),
+				"key":       canonicalAVMap(item.Update.Key),
+				"update":    aws.ToString(item.Update.UpdateExpression),
+				"condition": aws.ToString(item.Update.ConditionExpression),
+				"names":     canonicalNames(item.Update.ExpressionAttributeNames),
+				"values":    canonicalAVMap(item.Update.ExpressionAttributeValues),
+			})
+		case item.Delete != nil:
+			canonical = append(canonical, map[string]any{
+				"op":        "delete",
+				"table":     aws.ToString(item.Delete.TableName),
+				"key":       canonicalAVMap(item.Delete.Key),
+				"condition": aws.ToString(item.Delete.ConditionExpression),
+				"names":     canonicalNames(item.Delete.ExpressionAttributeNames),
+				"values":    canonicalAVMap(item.Delete.ExpressionAttributeValues),
+			})
+		case item.ConditionCheck != nil:
+			canonical = append(canonical, map[string]any{
+				"op":        "check",
+				"table":     aws.ToString(item.ConditionCheck.TableName),
+				"key":       canonicalAVMap(item.ConditionCheck.Key),
+				"condition": aws.ToString(item.ConditionCheck.ConditionExpression),
+				"names":     canonicalNames(item.ConditionCheck.ExpressionAttributeNames),
+				"values":    canonicalAVMap(item.ConditionCheck.ExpressionAttributeValues),
+			})
+		}
+	}
+
+	// encoding/json sorts map keys, so the encoding is stable
+	b, err := json.Marshal(canonical)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// canonicalNames returns names, or an empty map if names is nil, so nil and empty
+// attribute names produce the same token.
+func canonicalNames(names map[string]string) map[string]string {
+	if names == nil {
+		return map[string]string{}
+	}
+	return names
+}
+
+// canonicalAVMap converts m to plain values tagged with their DynamoDB type.
+// A nil map converts to an empty map, so nil and empty maps produce the same token.
+func canonicalAVMap(m map[string]types.AttributeValue) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = canonicalAV(v)
+	}
+	return out
+}
+
+// canonicalAV converts av to a plain value tagged with its DynamoDB type so that
+// values of different types with the same representation (e.g. S "1" and N "1")
+// encode differently.
+func canonicalAV(av types.AttributeValue) any {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]any{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]any{"N": v.Value}
+	case *types.AttributeValueMemberB:
+		return map[string]any{"B": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]any{"BOOL": v.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]any{"NULL": v.Value}
+	case *types.AttributeValueMemberSS:
+		return map[string]any{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]any{"NS": v.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]any{"BS": v.Value}
+	case *types.AttributeValueMemberM:
+		return map[string]any{"M": canonicalAVMap(v.Value)}
+	case *types.AttributeValueMemberL:
+		l := make([]any, 0, len(v.Value))
+		for _, e := range v.Value {
+			l = append(l, canonicalAV(e))
+		}
+		return map[string]any{"L": l}
+	default:
+		return nil
+	}
+}
+
 func newTxWriteItem(ti TransactionItem) (*types.TransactWriteItem, error) {
 	req := ti.GetRequest()
 
@@ -152,36 +336,48 @@ func newTxWriteItem(ti TransactionItem) (*types.TransactWriteItem, error) {
 		}
 		return txItem, nil
 	case "U":
+		key, err := keyMaker(ti.Query, ti.Table)
+		if err != nil {
+			return nil, err
+		}
 		txItem := &types.TransactWriteItem{
 			Update: &types.Update{
 				ConditionExpression:       ti.Expr.Condition(),
 				ExpressionAttributeNames:  ti.Expr.Names(),
 				ExpressionAttributeValues: ti.Expr.Values(),
 				TableName:                 aws.String(ti.Table.TableName),
-				Key:                       keyMaker(ti.Query, ti.Table),
+				Key:                       key,
 				UpdateExpression:          ti.Expr.Update(),
 			},
 		}
 		return txItem, nil
 	case "D":
+		key, err := keyMaker(ti.Query, ti.Table)
+		if err != nil {
+			return nil, err
+		}
 		txItem := &types.TransactWriteItem{
 			Delete: &types.Delete{
 				ConditionExpression:       ti.Expr.Condition(),
 				ExpressionAttributeNames:  ti.Expr.Names(),
 				ExpressionAttributeValues: ti.Expr.Values(),
 				TableName:                 aws.String(ti.Table.TableName),
-				Key:                       keyMaker(ti.Query, ti.Table),
+				Key:                       key,
 			},
 		}
 		return txItem, nil
 	case "CC":
+		key, err := keyMaker(ti.Query, ti.Table)
+		if err != nil {
+			return nil, err
+		}
 		txItem := &types.TransactWriteItem{
 			ConditionCheck: &types.ConditionCheck{
 				ConditionExpression:       ti.Expr.Condition(),
 				ExpressionAttributeNames:  ti.Expr.Names(),
 				ExpressionAttributeValues: ti.Expr.Values(),
 				TableName:                 aws.String(ti.Table.TableName),
-				Key:                       keyMaker(ti.Query, ti.Table),
+				Key:                       key,
 			},
 		}
 		return txItem, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -122,6 +123,23 @@ func TestTransactions_TxWrite(t *testing.T) {
 			expectedError: NewTxConflictError(),
 			expectedFail:  0,
 		},
+		{
+			name: "TransactionCanceledException/TransactionConflict",
+			items: []TransactionItem{
+				NewCreateTxItem("create-1", testItem, testTable, nil, NewExpression()),
+			},
+			mockSetup: func(ctrl *gomock.Controller) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+					CancellationReasons: []types.CancellationReason{
+						{Code: aws.String(string(types.BatchStatementErrorCodeEnumTransactionConflict))},
+					},
+				}).Times(1)
+				return m
+			},
+			expectedError: NewTxConflictError(),
+			expectedFail:  1,
+		},
 		{
 			name: "TransactionInProgressException",
 			items: []TransactionItem{
@@ -145,7 +163,7 @@ func TestTransactions_TxWrite(t *testing.T) {
 				m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error")).Times(1)
 				return m
 			},
-			expectedError: goaws.NewInternalError(errors.New("s.svc.DeleteMessage: some error")),
+			expectedError: goaws.NewInternalError(errors.New("d.svc.TransactWriteItems: some error")),
 			expectedFail:  0,
 		},
 	}
@@ -173,3 +191,231 @@ func TestTransactions_TxWrite(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactions_TxWriteWithRetry(t *testing.T) {
+	testTable := &Table{TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"}
+	testItem := map[string]interface{}{"id": "1", "data": "value"}
+	items := []TransactionItem{
+		NewCreateTxItem("create-1", testItem, testTable, nil, NewExpression()),
+	}
+
+	tests := []struct {
+		name          string
+		requestToken  string
+		deadline      time.Duration
+		mockSetup     func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI
+		expectedCalls int
+		expectedError error
+	}{
+		{
+			name:         "ConflictThenSuccess",
+			requestToken: "token-1",
+			mockSetup: func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				record := func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+					*tokens = append(*tokens, aws.ToString(in.ClientRequestToken))
+				}
+				gomock.InOrder(
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(nil, &types.TransactionConflictException{}).Times(1),
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Times(1),
+				)
+				return m
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:         "CanceledConflictThenSuccess",
+			requestToken: "token-1",
+			mockSetup: func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				record := func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+					*tokens = append(*tokens, aws.ToString(in.ClientRequestToken))
+				}
+				gomock.InOrder(
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{
+							{Code: aws.String(string(types.BatchStatementErrorCodeEnumTransactionConflict))},
+						},
+					}).Times(1),
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Times(1),
+				)
+				return m
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "ThrottledAndInProgressWithGeneratedToken",
+			mockSetup: func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				record := func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+					*tokens = append(*tokens, aws.ToString(in.ClientRequestToken))
+				}
+				gomock.InOrder(
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{
+							{Code: aws.String(string(types.BatchStatementErrorCodeEnumThrottlingError))},
+						},
+					}).Times(1),
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(nil, &types.TransactionInProgressException{}).Times(1),
+					m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(record).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Times(1),
+				)
+				return m
+			},
+			expectedCalls: 3,
+		},
+		{
+			name:         "NotRetryable",
+			requestToken: "token-1",
+			mockSetup: func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+						*tokens = append(*tokens, aws.ToString(in.ClientRequestToken))
+					}).Return(nil, &types.TransactionCanceledException{
+					CancellationReasons: []types.CancellationReason{
+						{Code: aws.String(string(types.BatchStatementErrorCodeEnumConditionalCheckFailed)), Message: aws.String("Condition failed")},
+					},
+				}).Times(1)
+				return m
+			},
+			expectedCalls: 1,
+			expectedError: NewTxConditonCheckFailedError("Condition failed"),
+		},
+		{
+			name:         "MaxRetriesExceeded",
+			requestToken: "token-1",
+			mockSetup: func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+						*tokens = append(*tokens, aws.ToString(in.ClientRequestToken))
+					}).Return(nil, &types.TransactionConflictException{}).Times(3)
+				return m
+			},
+			expectedCalls: 3,
+			expectedError: errors.New("retries.ExponentialBackoffContext: max retries exceeded"),
+		},
+		{
+			name:         "DeadlineExceeded",
+			requestToken: "token-1",
+			deadline:     time.Millisecond,
+			mockSetup: func(ctrl *gomock.Controller, tokens *[]string) DynamoDBTransactionsClientAPI {
+				m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+				m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+						*tokens = append(*tokens, aws.ToString(in.ClientRequestToken))
+					}).Return(nil, &types.TransactionConflictException{}).Times(1)
+				return m
+			},
+			expectedCalls: 1,
+			expectedError: errors.New("retries.ExponentialBackoffContext: context deadline exceeded before next retry"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var tokens []string
+			clock := goaws.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			// retries wait 2ms, then the remaining 3ms until the 5ms cap
+			tx := NewTransactions(tt.mockSetup(ctrl, &tokens), NewFailConfig(1, 5, 1).WithClock(clock))
+
+			ctx := context.Background()
+			if tt.deadline != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, clock.Now().Add(tt.deadline))
+				defer cancel()
+			}
+
+			_, err := tx.TxWriteWithRetry(ctx, items, tt.requestToken)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, tokens, tt.expectedCalls)
+			for _, token := range tokens {
+				assert.NotEmpty(t, token)
+				assert.Equal(t, tokens[0], token)
+			}
+			if tt.requestToken != "" {
+				assert.Equal(t, tt.requestToken, tokens[0])
+			}
+		})
+	}
+}
+
+func TestTransactions_DeterministicRequestToken(t *testing.T) {
+	testTable := &Table{TableName: "test-table", PrimaryKeyName: "id", PrimaryKeyType: "S"}
+	newItems := func(data string) []TransactionItem {
+		return []TransactionItem{
+			NewCreateTxItem("create-1", map[string]interface{}{"id": "1", "data": data}, testTable, nil, NewExpression()),
+			NewDeleteTxItem("delete-1", testTable, &Query{PrimaryValue: "2"}, NewExpression()),
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var tokens []string
+	m := NewMockDynamoDBTransactionsClientAPI(ctrl)
+	m.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+		func(_ context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) {
+			tokens = append(tokens, aws.ToString(in.ClientRequestToken))
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Times(4)
+
+	tx := NewTransactions(m, nil).WithDeterministicRequestTokens()
+	for _, items := range [][]TransactionItem{newItems("value"), newItems("value"), newItems("other")} {
+		_, err := tx.TxWrite(context.Background(), items, "")
+		require.NoError(t, err)
+	}
+	_, err := tx.TxWrite(context.Background(), newItems("value"), "token-1")
+	require.NoError(t, err)
+
+	require.Len(t, tokens, 4)
+	assert.Len(t, tokens[0], 32)
+	assert.Equal(t, tokens[0], tokens[1])
+	assert.NotEqual(t, tokens[0], tokens[2])
+	assert.Equal(t, "token-1", tokens[3])
+}
+
+func TestTxRequestToken(t *testing.T) {
+	newItems := func(av types.AttributeValue) []types.TransactWriteItem {
+		return []types.TransactWriteItem{{
+			Put: &types.Put{
+				TableName: aws.String("test-table"),
+				Item: map[string]types.AttributeValue{
+					"id":   &types.AttributeValueMemberS{Value: "1"},
+					"data": av,
+				},
+			},
+		}}
+	}
+
+	token1, err := txRequestToken(newItems(&types.AttributeValueMemberS{Value: "1"}))
+	require.NoError(t, err)
+	token2, err := txRequestToken(newItems(&types.AttributeValueMemberS{Value: "1"}))
+	require.NoError(t, err)
+	token3, err := txRequestToken(newItems(&types.AttributeValueMemberN{Value: "1"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, token1, token2)
+	assert.NotEqual(t, token1, token3)
+
+	// nil and empty names and values are equivalent
+	nilMaps := newItems(&types.AttributeValueMemberS{Value: "1"})
+	emptyMaps := newItems(&types.AttributeValueMemberS{Value: "1"})
+	emptyMaps[0].Put.ExpressionAttributeNames = map[string]string{}
+	emptyMaps[0].Put.ExpressionAttributeValues = map[string]types.AttributeValue{}
+	token4, err := txRequestToken(nilMaps)
+	require.NoError(t, err)
+	token5, err := txRequestToken(emptyMaps)
+	require.NoError(t, err)
+	assert.Equal(t, token4, token5)
+}
@@ -7,6 +7,18 @@ import (
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 )
 
+// Sentinel errors matched by the corresponding error types via errors.Is.
+var (
+	ErrItemNotFound      = errors.New("item not found")
+	ErrMissingChecksum   = errors.New("missing checksum")
+	ErrChecksumMismatch  = errors.New("checksum mismatch")
+	ErrInvalidExpiry     = errors.New("invalid presign expiry")
+	ErrBucketExists      = errors.New("bucket already exists")
+	ErrBucketNotFound    = errors.New("bucket not found")
+	ErrBucketPermissions = errors.New("bucket permissions error")
+	ErrNotModified       = errors.New("not modified")
+)
+
 type ItemNotFoundError struct {
 	*goaws.ClientErr
 }
@@ -17,6 +29,10 @@ func NewItemNotFoundError(item string) error {
 	}
 }
 
+func (e *ItemNotFoundError) Is(target error) bool {
+	return target == ErrItemNotFound || target == goaws.ErrNotFound
+}
+
 type MissingChecksumError struct {
 	*goaws.InternalError
 }
@@ -26,3 +42,94 @@ func NewMissingChecksumError() error {
 		goaws.NewInternalError(errors.New("missing checksum")),
 	}
 }
+
+func (e *MissingChecksumError) Is(target error) bool {
+	return target == ErrMissingChecksum
+}
+
+type ChecksumMismatchError struct {
+	*goaws.RetryableInternalError
+}
+
+func NewChecksumMismatchError(expected, actual SHA256Checksum) error {
+	return &ChecksumMismatchError{
+		goaws.NewRetryableInternalError(fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)),
+	}
+}
+
+func (e *ChecksumMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+type InvalidExpiryError struct {
+	*goaws.ClientErr
+}
+
+func NewInvalidExpiryError(seconds int) error {
+	return &InvalidExpiryError{
+		goaws.NewClientError(fmt.Errorf("invalid presign expiry: %d seconds (max %d)", seconds, MaxPresignExpirySeconds)),
+	}
+}
+
+func (e *InvalidExpiryError) Is(target error) bool {
+	return target == ErrInvalidExpiry
+}
+
+type BucketExistsError struct {
+	*goaws.ClientErr
+}
+
+func NewBucketExistsError(bucket string) error {
+	return &BucketExistsError{
+		goaws.NewClientError(fmt.Errorf("bucket already exists: %s", bucket)),
+	}
+}
+
+func (e *BucketExistsError) Is(target error) bool {
+	return target == ErrBucketExists
+}
+
+type BucketNotFoundError struct {
+	*goaws.ClientErr
+}
+
+func NewBucketNotFoundError(bucket string) error {
+	return &BucketNotFoundError{
+		goaws.NewClientError(fmt.Errorf("bucket not found: %s", bucket)),
+	}
+}
+
+func (e *BucketNotFoundError) Is(target error) bool {
+	return target == ErrBucketNotFound || target == goaws.ErrNotFound
+}
+
+type BucketPermissionsError struct {
+	*goaws.ClientErr
+}
+
+func NewBucketPermissionsError(bucket string) error {
+	return &BucketPermissionsError{
+		goaws.NewClientError(fmt.Errorf("bucket permissions error: %s", bucket)),
+	}
+}
+
+func (e *BucketPermissionsError) Is(target error) bool {
+	return target == ErrBucketPermissions || target == goaws.ErrAccessDenied
+}
+
+// NotModifiedError is returned by a conditional get (HTTP 304) when the object's
+// ETag matches GetFileRequest.IfNoneMatch or it hasn't been modified since
+// GetFileRequest.IfModifiedSince, so the caller's cached copy is current.
+type NotModifiedError struct {
+	*goaws.ClientErr
+}
+
+func NewNotModifiedError(item string) error {
+	return &NotModifiedError{
+		goaws.NewClientError(fmt.Errorf("not modified: %s", item)),
+	}
+}
+
+func (e *NotModifiedError) Is(target error) bool {
+	return target == ErrNotModified
+}
@@ -0,0 +1,41 @@
+package gos3
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	var tests = []struct {
+		name         string
+		err          error
+		sentinel     error
+		notFound     bool
+		accessDenied bool
+	}{
+		{name: "item not found", err: NewItemNotFoundError("test"), sentinel: ErrItemNotFound, notFound: true},
+		{name: "missing checksum", err: NewMissingChecksumError(), sentinel: ErrMissingChecksum},
+		{name: "checksum mismatch", err: NewChecksumMismatchError("a", "b"), sentinel: ErrChecksumMismatch},
+		{name: "invalid expiry", err: NewInvalidExpiryError(-1), sentinel: ErrInvalidExpiry},
+		{name: "bucket exists", err: NewBucketExistsError("test"), sentinel: ErrBucketExists},
+		{name: "bucket not found", err: NewBucketNotFoundError("test"), sentinel: ErrBucketNotFound, notFound: true},
+		{name: "bucket permissions", err: NewBucketPermissionsError("test"), sentinel: ErrBucketPermissions, accessDenied: true},
+		{name: "not modified", err: NewNotModifiedError("test"), sentinel: ErrNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.Equal(t, tt.notFound, errors.Is(wrapped, goaws.ErrNotFound))
+			assert.Equal(t, tt.accessDenied, errors.Is(wrapped, goaws.ErrAccessDenied))
+			assert.NotErrorIs(t, wrapped, errors.New(tt.sentinel.Error()))
+		})
+	}
+}
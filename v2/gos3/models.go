@@ -1,49 +1,146 @@
 package gos3
 
-import "io"
+import (
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
 
 type SHA256Checksum string
 
 const MetadataKeyChecksumSHA256 = "checksum_sha256"
 
+// DefaultPartitionSize is the default size of the ranges downloaded by DownloadConcurrent.
+const DefaultPartitionSize = int64(1024 * 1024 * 64) // 64mb
+
+// Presigned URL expiry limits. SigV4 presigned URLs are valid for at most 7 days.
+const (
+	DefaultPresignExpirySeconds = 900
+	MaxPresignExpirySeconds     = 604800
+)
+
+// UploadFileRequest contains the parameters for uploading a file.
+// If AutoChecksum is set, the SHA256 checksum of File is computed and
+// sent with the upload; Checksum is ignored.
 type UploadFileRequest struct {
-	Bucket   string            `json:"bucket"`
-	Key      string            `json:"key"`
-	File     io.Reader         `json:"file"`
-	Checksum *SHA256Checksum   `json:"checksum,omitempty"`
-	Metadata map[string]string `json:"metadata,omitempty"`
+	Bucket       string            `json:"bucket"`
+	Key          string            `json:"key"`
+	File         io.Reader         `json:"file"`
+	Checksum     *SHA256Checksum   `json:"checksum,omitempty"`
+	AutoChecksum bool              `json:"auto_checksum,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
 }
 
+// GetFileRequest identifies the object to get.
+// ResponseContentDisposition and ResponseContentType override the
+// Content-Disposition and Content-Type headers of the response to a
+// presigned GET, e.g. `attachment; filename="report.pdf"` to force a download.
+// They are ignored by the other methods.
+// IfNoneMatch and IfModifiedSince make GetObject and GetObjectStream conditional:
+// if the object's ETag matches IfNoneMatch, or it hasn't been modified since
+// IfModifiedSince, a NotModifiedError is returned instead of the object.
 type GetFileRequest struct {
-	Bucket      string  `json:"bucket"`
-	Key         string  `json:"key"`
-	VersionId   *string `json:"version_id,omitempty"`
-	UseChecksum bool    `json:"use_checksum"`
+	Bucket                     string     `json:"bucket"`
+	Key                        string     `json:"key"`
+	VersionId                  *string    `json:"version_id,omitempty"`
+	UseChecksum                bool       `json:"use_checksum"`
+	ResponseContentDisposition string     `json:"response_content_disposition,omitempty"`
+	ResponseContentType        string     `json:"response_content_type,omitempty"`
+	IfNoneMatch                *string    `json:"if_none_match,omitempty"`
+	IfModifiedSince            *time.Time `json:"if_modified_since,omitempty"`
 }
 
+// GetObjectResponse contains an S3 object's contents and metadata.
+// File is nil for streamed objects.
 type GetObjectResponse struct {
-	File []byte `json:"file"`
+	File          []byte `json:"file"`
+	ContentType   string `json:"content_type,omitempty"`
+	ContentLength int64  `json:"content_length,omitempty"`
+	ETag          string `json:"etag,omitempty"`
+}
+
+func newGetObjectResponse(obj *s3.GetObjectOutput) *GetObjectResponse {
+	return &GetObjectResponse{
+		ContentType:   aws.ToString(obj.ContentType),
+		ContentLength: aws.ToInt64(obj.ContentLength),
+		ETag:          aws.ToString(obj.ETag),
+	}
 }
 
+// ObjectExistsResponse reports whether an object exists.
+// Size, LastModified and ContentType are populated from the
+// object's metadata when Exists is true.
 type ObjectExistsResponse struct {
-	Exists bool `json:"exists"`
+	Exists       bool      `json:"exists"`
+	Size         int64     `json:"size,omitempty"`
+	LastModified time.Time `json:"last_modified,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
 }
 
 type HeadObjectResponse struct {
-	ContentType    string            `json:"content_type"`
-	Sha256Checksum string            `json:"sha256_checksum"`
-	Metadata       map[string]string `json:"metadata,omitempty"`
+	ContentType        string            `json:"content_type"`
+	Sha256Checksum     string            `json:"sha256_checksum"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	ETag               string            `json:"etag,omitempty"`
+	CacheControl       string            `json:"cache_control,omitempty"`
+	ContentEncoding    string            `json:"content_encoding,omitempty"`
+	ContentDisposition string            `json:"content_disposition,omitempty"`
+	ContentLanguage    string            `json:"content_language,omitempty"`
+	Expires            *time.Time        `json:"expires,omitempty"`
+	StorageClass       string            `json:"storage_class,omitempty"`
 }
 
+// CopyObjectRequest identifies the source object to copy and its destination.
+// Setting Metadata or ContentType replaces the source object's metadata and
+// content type on the copy; a non-nil empty Metadata map clears the metadata.
+// The other content headers are only applied when the metadata is replaced.
+// SourceIfMatch makes the copy fail unless the source object's ETag matches it.
+type CopyObjectRequest struct {
+	SourceBucket       string            `json:"source_bucket"`
+	SourceKey          string            `json:"source_key"`
+	SourceVersionId    *string           `json:"source_version_id,omitempty"`
+	SourceIfMatch      string            `json:"source_if_match,omitempty"`
+	Bucket             string            `json:"bucket"`
+	Key                string            `json:"key"`
+	ContentType        string            `json:"content_type,omitempty"`
+	CacheControl       string            `json:"cache_control,omitempty"`
+	ContentEncoding    string            `json:"content_encoding,omitempty"`
+	ContentDisposition string            `json:"content_disposition,omitempty"`
+	ContentLanguage    string            `json:"content_language,omitempty"`
+	Expires            *time.Time        `json:"expires,omitempty"`
+	StorageClass       string            `json:"storage_class,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// CopyObjectResponse contains the data returned by the S3 CopyObject operation.
+type CopyObjectResponse struct {
+	VersionID string `json:"version_id"`
+	ETag      string `json:"etag"`
+}
+
+// DeleteFileRequest identifies the object to delete.
+type DeleteFileRequest struct {
+	Bucket    string  `json:"bucket"`
+	Key       string  `json:"key"`
+	VersionId *string `json:"version_id,omitempty"`
+}
+
+// GetPresignedUrlRequest contains the requests to presign.
+// ExpirySeconds defaults to DefaultPresignExpirySeconds if zero
+// and may not exceed MaxPresignExpirySeconds.
 type GetPresignedUrlRequest struct {
 	ExpirySeconds int                `json:"expiry_seconds"`
 	Put           *UploadFileRequest `json:"put,omitempty"`
 	Get           *GetFileRequest    `json:"get,omitempty"`
+	Delete        *DeleteFileRequest `json:"delete,omitempty"`
 }
 
 type GetPresignedUrlResponse struct {
-	PutUrl string `json:"put,omitempty"`
-	GetUrl string `json:"get,omitempty"`
+	PutUrl    string `json:"put,omitempty"`
+	GetUrl    string `json:"get,omitempty"`
+	DeleteUrl string `json:"delete,omitempty"`
 }
 
 // UploadFileResponse contains the data returned by the S3 Upload operation.
@@ -53,3 +150,22 @@ type UploadFileResponse struct {
 	UploadID  string `json:"upload_id"`
 	ETag      string `json:"etag"`
 }
+
+// LifecycleRule is a simplified S3 bucket lifecycle rule applied to objects under Prefix.
+// Day counts of zero are not set on the rule. NoncurrentVersionExpirationDays expires
+// old object versions in versioned buckets.
+type LifecycleRule struct {
+	ID                                 string `json:"id"`
+	Prefix                             string `json:"prefix"`
+	Disabled                           bool   `json:"disabled,omitempty"`
+	ExpirationDays                     int32  `json:"expiration_days,omitempty"`
+	NoncurrentVersionExpirationDays    int32  `json:"noncurrent_version_expiration_days,omitempty"`
+	AbortIncompleteMultipartUploadDays int32  `json:"abort_incomplete_multipart_upload_days,omitempty"`
+}
+
+// MultipartUpload identifies a multipart upload that has been started but not completed or aborted.
+type MultipartUpload struct {
+	Key       string    `json:"key"`
+	UploadID  string    `json:"upload_id"`
+	Initiated time.Time `json:"initiated"`
+}
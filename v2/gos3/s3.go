@@ -2,17 +2,24 @@
 package gos3
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
@@ -22,11 +29,23 @@ import (
 //go:generate mockgen -destination=../mocks/gos3mock/s3.go -package=gos3mock . S3Logic
 type S3Logic interface {
 	GetObject(ctx context.Context, req GetFileRequest) (*GetObjectResponse, error)
+	GetObjectStream(ctx context.Context, req GetFileRequest) (io.ReadCloser, *GetObjectResponse, error)
+	DownloadConcurrent(ctx context.Context, req GetFileRequest, w io.WriterAt, concurrency int) (int64, error)
 	HeadObject(ctx context.Context, req GetFileRequest) (*HeadObjectResponse, error)
 	CheckIfObjectExists(ctx context.Context, req GetFileRequest) (*ObjectExistsResponse, error)
 	UploadFile(ctx context.Context, req UploadFileRequest) (*UploadFileResponse, error)
+	CopyObject(ctx context.Context, req CopyObjectRequest) (*CopyObjectResponse, error)
+	UpdateObjectMetadata(ctx context.Context, bucket, key string, metadata map[string]string) error
 	DeleteFile(ctx context.Context, bucket, key string, versionId *string) error
 	GetPresignedURL(ctx context.Context, req GetPresignedUrlRequest) (*GetPresignedUrlResponse, error)
+	CreateBucket(ctx context.Context, bucket, region string) error
+	DeleteBucket(ctx context.Context, bucket string) error
+	CheckBucketExists(ctx context.Context, bucket string) (bool, error)
+	PutBucketVersioning(ctx context.Context, bucket string, enabled bool) error
+	PutBucketLifecycle(ctx context.Context, bucket string, rules []LifecycleRule) error
+	ListMultipartUploads(ctx context.Context, bucket string) ([]MultipartUpload, error)
+	AbortMultipartUpload(ctx context.Context, bucket, key, uploadId string) error
+	StreamPrefixAsZip(ctx context.Context, bucket, prefix string, w io.Writer) error
 }
 
 // S3ClientAPI defines the interface for the AWS S3 client methods used by this package.
@@ -36,7 +55,16 @@ type S3ClientAPI interface {
 	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
 	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
 	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	CreateBucket(ctx context.Context, params *s3.CreateBucketInput, optFns ...func(*s3.Options)) (*s3.CreateBucketOutput, error)
+	DeleteBucket(ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	PutBucketVersioning(ctx context.Context, params *s3.PutBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error)
+	PutBucketLifecycleConfiguration(ctx context.Context, params *s3.PutBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error)
+	ListMultipartUploads(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 }
 
 // S3PresignClientAPI defines the interface for the AWS S3 presign client methods used by this package.
@@ -45,64 +73,171 @@ type S3ClientAPI interface {
 type S3PresignClientAPI interface {
 	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
 	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignDeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
 }
 
 type S3 struct {
 	svc        S3ClientAPI
 	presignSvc S3PresignClientAPI
+	fc         *goaws.FailConfig
+	partSize   int64
 }
 
+// NewS3 returns a new S3 client. partitionSize is the size of the ranges downloaded
+// by DownloadConcurrent; values under 1024 bytes default to DefaultPartitionSize.
 func NewS3(config goaws.AwsConfig, partitionSize int64) *S3 {
 	client := s3.NewFromConfig(config.Config)
+	if partitionSize < 1024 {
+		partitionSize = DefaultPartitionSize
+	}
 	return &S3{
 		svc:        client,
 		presignSvc: s3.NewPresignClient(client),
+		fc:         goaws.DefaultFailConfig,
+		partSize:   partitionSize,
 	}
 }
 
+// WithFailConfig sets the exponential backoff parameters used to retry throttled (503 SlowDown)
+// and 5xx GetObject, UploadFile, CopyObject and DeleteFile requests, and returns s for chaining.
+// A nil FailConfig disables retries.
+func (s *S3) WithFailConfig(fc *goaws.FailConfig) *S3 {
+	s.fc = fc
+	return s
+}
+
 // GetObject returns the S3 object at the given bucket/key as a byte slice.
-// TODO: add options for checksum
+// If req.UseChecksum is set, the SHA256 checksum of the downloaded bytes is verified
+// against the object's stored checksum and a ChecksumMismatchError is returned on mismatch.
 func (s *S3) GetObject(ctx context.Context, req GetFileRequest) (*GetObjectResponse, error) {
-	input := &s3.GetObjectInput{
-		Bucket:    aws.String(req.Bucket),
-		Key:       aws.String(req.Key),
-		VersionId: req.VersionId,
+	obj, err := s.getObject(ctx, req)
+	if err != nil {
+		return nil, err
 	}
+	defer obj.Body.Close()
 
-	if req.UseChecksum {
-		input.ChecksumMode = types.ChecksumModeEnabled
+	buf := new(strings.Builder)
+	if _, err = io.Copy(buf, obj.Body); err != nil {
+		return nil, goaws.NewInternalError(fmt.Errorf("io.Copy: %w", err))
 	}
 
-	obj, err := s.svc.GetObject(ctx, input)
-	if err != nil {
-		var notExist *types.NoSuchKey
-		var re *awshttp.ResponseError
-		switch {
-		case errors.As(err, &notExist):
-			return nil, NewItemNotFoundError(req.Key)
-		case errors.As(err, &re):
-			if re.ResponseError == nil {
-				return nil, fmt.Errorf("s.svc.HeadObject: %w", re.Err)
+	res := []byte(buf.String())
+
+	if req.UseChecksum {
+		var expected SHA256Checksum
+		if obj.ChecksumSHA256 != nil {
+			expected = SHA256Checksum(*obj.ChecksumSHA256)
+		} else {
+			val, ok := obj.Metadata[MetadataKeyChecksumSHA256]
+			if !ok {
+				return nil, NewMissingChecksumError()
 			}
-			switch re.HTTPStatusCode() {
-			case http.StatusNotFound:
-				return nil, NewItemNotFoundError(req.Key)
-			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", re.Err))
+			expected = SHA256Checksum(val)
+		}
+		// composite checksums of multipart uploads ("<checksum>-<parts>")
+		// can't be compared against the checksum of the full object
+		if !strings.Contains(string(expected), "-") {
+			if actual := computeChecksum(res); actual != expected {
+				return nil, NewChecksumMismatchError(expected, actual)
 			}
-		default:
-			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetObject: %w", err))
 		}
 	}
 
-	buf := new(strings.Builder)
-	if _, err = io.Copy(buf, obj.Body); err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("io.Copy: %w", err))
+	resp := newGetObjectResponse(obj)
+	resp.File = res
+	return resp, nil
+}
+
+// GetObjectStream returns the body of the S3 object at the given bucket/key without
+// buffering it, along with the object's metadata. The caller must close the returned
+// io.ReadCloser. If req.UseChecksum is set, the SDK validates the object's checksum
+// as the body is read and returns an error from Read on mismatch.
+func (s *S3) GetObjectStream(ctx context.Context, req GetFileRequest) (io.ReadCloser, *GetObjectResponse, error) {
+	obj, err := s.getObject(ctx, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	res := []byte(buf.String())
+	return obj.Body, newGetObjectResponse(obj), nil
+}
+
+// DownloadConcurrent downloads the S3 object at the given bucket/key into w, fetching
+// ranges of the S3 client's partition size with up to concurrency parallel GetObject requests,
+// and returns the number of bytes written. Use it over GetObject for large objects.
+// req.UseChecksum is ignored; failed ranges are retried by the download manager.
+func (s *S3) DownloadConcurrent(ctx context.Context, req GetFileRequest, w io.WriterAt, concurrency int) (int64, error) {
+	if concurrency < 1 {
+		concurrency = manager.DefaultDownloadConcurrency
+	}
+	downloader := manager.NewDownloader(s.svc, func(d *manager.Downloader) {
+		d.Concurrency = concurrency
+		if s.partSize > 0 {
+			d.PartSize = s.partSize
+		}
+	})
+
+	n, err := downloader.Download(ctx, w, &s3.GetObjectInput{
+		Bucket:          aws.String(req.Bucket),
+		Key:             aws.String(req.Key),
+		VersionId:       req.VersionId,
+		IfNoneMatch:     req.IfNoneMatch,
+		IfModifiedSince: req.IfModifiedSince,
+	})
+	if err != nil {
+		return n, getObjectErr(err, req.Key)
+	}
+
+	return n, nil
+}
 
-	return &GetObjectResponse{File: res}, nil
+func (s *S3) getObject(ctx context.Context, req GetFileRequest) (*s3.GetObjectOutput, error) {
+	input := &s3.GetObjectInput{
+		Bucket:          aws.String(req.Bucket),
+		Key:             aws.String(req.Key),
+		VersionId:       req.VersionId,
+		IfNoneMatch:     req.IfNoneMatch,
+		IfModifiedSince: req.IfModifiedSince,
+	}
+
+	if req.UseChecksum {
+		input.ChecksumMode = types.ChecksumModeEnabled
+	}
+
+	var obj *s3.GetObjectOutput
+	err := s.withRetries(ctx, func() (err error) {
+		obj, err = s.svc.GetObject(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, getObjectErr(err, req.Key)
+	}
+
+	return obj, nil
+}
+
+func getObjectErr(err error, key string) error {
+	var notExist *types.NoSuchKey
+	var re *awshttp.ResponseError
+	switch {
+	case errors.As(err, &notExist):
+		return NewItemNotFoundError(key)
+	case errors.As(err, &re):
+		if re.ResponseError == nil {
+			return goaws.NewInternalError(fmt.Errorf("s.svc.GetObject: %w", err))
+		}
+		switch re.HTTPStatusCode() {
+		case http.StatusNotModified:
+			return NewNotModifiedError(key)
+		case http.StatusForbidden:
+			return goaws.NewAccessDeniedError(fmt.Errorf("s.svc.GetObject: %w", err))
+		case http.StatusNotFound:
+			return NewItemNotFoundError(key)
+		default:
+			return goaws.NewInternalError(fmt.Errorf("s.svc.GetObject: %w", err))
+		}
+	default:
+		return goaws.NewServiceError(fmt.Errorf("s.svc.GetObject: %w", err))
+	}
 }
 
 func (s *S3) HeadObject(ctx context.Context, req GetFileRequest) (*HeadObjectResponse, error) {
@@ -125,21 +260,30 @@ func (s *S3) HeadObject(ctx context.Context, req GetFileRequest) (*HeadObjectRes
 			return nil, NewItemNotFoundError(req.Key)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return nil, goaws.NewAccessDeniedError(fmt.Errorf("s.svc.HeadObject: %w", err))
 			case http.StatusNotFound:
 				return nil, NewItemNotFoundError(req.Key)
 			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", err))
 			}
 		default:
-			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetObject: %w", err))
+			return nil, goaws.NewServiceError(fmt.Errorf("s.svc.GetObject: %w", err))
 		}
 	}
 
 	resp := &HeadObjectResponse{
-		Metadata: obj.Metadata,
+		Metadata:           obj.Metadata,
+		ETag:               aws.ToString(obj.ETag),
+		CacheControl:       aws.ToString(obj.CacheControl),
+		ContentEncoding:    aws.ToString(obj.ContentEncoding),
+		ContentDisposition: aws.ToString(obj.ContentDisposition),
+		ContentLanguage:    aws.ToString(obj.ContentLanguage),
+		Expires:            obj.Expires,
+		StorageClass:       string(obj.StorageClass),
 	}
 
 	if obj.ContentType != nil {
@@ -161,16 +305,18 @@ func (s *S3) HeadObject(ctx context.Context, req GetFileRequest) (*HeadObjectRes
 	return resp, nil
 }
 
-// CheckIfObjectExists checks if a head object exists at bucket/key
+// CheckIfObjectExists checks if a head object exists at bucket/key and
+// returns its size, last modified time and content type if it does.
 func (s *S3) CheckIfObjectExists(ctx context.Context, req GetFileRequest) (*ObjectExistsResponse, error) {
-	if _, err := s.svc.HeadObject(
+	obj, err := s.svc.HeadObject(
 		ctx,
 		&s3.HeadObjectInput{
 			Bucket:    aws.String(req.Bucket),
 			Key:       aws.String(req.Key),
 			VersionId: req.VersionId,
 		},
-	); err != nil {
+	)
+	if err != nil {
 		var notExist *types.NoSuchKey
 		var re *awshttp.ResponseError
 		switch {
@@ -178,20 +324,27 @@ func (s *S3) CheckIfObjectExists(ctx context.Context, req GetFileRequest) (*Obje
 			return &ObjectExistsResponse{Exists: false}, nil
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return nil, goaws.NewAccessDeniedError(fmt.Errorf("s.svc.HeadObject: %w", err))
 			case http.StatusNotFound:
 				return &ObjectExistsResponse{Exists: false}, nil
 			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", err))
 			}
 		default:
-			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.HeadObject: %w", err))
+			return nil, goaws.NewServiceError(fmt.Errorf("s.svc.HeadObject: %w", err))
 		}
 	}
 
-	return &ObjectExistsResponse{Exists: true}, nil
+	return &ObjectExistsResponse{
+		Exists:       true,
+		Size:         aws.ToInt64(obj.ContentLength),
+		LastModified: aws.ToTime(obj.LastModified),
+		ContentType:  aws.ToString(obj.ContentType),
+	}, nil
 }
 
 // UploadFile uploads a new file to the given S3 bucket.
@@ -203,17 +356,51 @@ func (s *S3) UploadFile(ctx context.Context, req UploadFileRequest) (*UploadFile
 		Metadata: req.Metadata,
 	}
 
+	if req.AutoChecksum {
+		body, checksum, err := checksumReader(req.File)
+		if err != nil {
+			return nil, err
+		}
+		input.Body = body
+		req.Checksum = &checksum
+	}
+
 	if req.Checksum != nil {
 		input.ChecksumAlgorithm = types.ChecksumAlgorithmSha256
 		input.ChecksumSHA256 = pointy.String(string(*req.Checksum))
 	}
 
-	result, err := s.svc.PutObject(ctx, input)
+	var result *s3.PutObjectOutput
+	put := func() (err error) {
+		result, err = s.svc.PutObject(ctx, input)
+		return err
+	}
+
+	// the body can only be resent if it can be rewound
+	var err error
+	if seeker, ok := input.Body.(io.Seeker); ok {
+		start, serr := seeker.Seek(0, io.SeekCurrent)
+		if serr != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("seeker.Seek: %w", serr))
+		}
+		err = s.withRetries(ctx, func() error {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return fmt.Errorf("seeker.Seek: %w", err)
+			}
+			return put()
+		})
+	} else if input.Body == nil {
+		err = s.withRetries(ctx, put)
+	} else {
+		err = put()
+	}
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.PutObject: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.PutObject: %w", err))
 	}
 
-	resp := &UploadFileResponse{}
+	resp := &UploadFileResponse{
+		ETag: aws.ToString(result.ETag),
+	}
 	if result.VersionId != nil {
 		resp.VersionID = *result.VersionId
 	}
@@ -221,6 +408,114 @@ func (s *S3) UploadFile(ctx context.Context, req UploadFileRequest) (*UploadFile
 	return resp, nil
 }
 
+// CopyObject copies the object at req.SourceBucket/req.SourceKey to req.Bucket/req.Key.
+// The source object's metadata and content type are preserved (MetadataDirective COPY)
+// unless req.Metadata or req.ContentType is set, in which case they are replaced
+// (MetadataDirective REPLACE) with the given values.
+func (s *S3) CopyObject(ctx context.Context, req CopyObjectRequest) (*CopyObjectResponse, error) {
+	input := &s3.CopyObjectInput{
+		Bucket:     aws.String(req.Bucket),
+		Key:        aws.String(req.Key),
+		CopySource: aws.String(copySource(req.SourceBucket, req.SourceKey, req.SourceVersionId)),
+	}
+
+	if req.SourceIfMatch != "" {
+		input.CopySourceIfMatch = aws.String(req.SourceIfMatch)
+	}
+	if req.StorageClass != "" {
+		input.StorageClass = types.StorageClass(req.StorageClass)
+	}
+
+	if req.Metadata != nil || req.ContentType != "" {
+		input.MetadataDirective = types.MetadataDirectiveReplace
+		input.Metadata = req.Metadata
+		input.Expires = req.Expires
+		if req.ContentType != "" {
+			input.ContentType = aws.String(req.ContentType)
+		}
+		if req.CacheControl != "" {
+			input.CacheControl = aws.String(req.CacheControl)
+		}
+		if req.ContentEncoding != "" {
+			input.ContentEncoding = aws.String(req.ContentEncoding)
+		}
+		if req.ContentDisposition != "" {
+			input.ContentDisposition = aws.String(req.ContentDisposition)
+		}
+		if req.ContentLanguage != "" {
+			input.ContentLanguage = aws.String(req.ContentLanguage)
+		}
+	}
+
+	var result *s3.CopyObjectOutput
+	err := s.withRetries(ctx, func() (err error) {
+		result, err = s.svc.CopyObject(ctx, input)
+		return err
+	})
+	if err != nil {
+		var re *awshttp.ResponseError
+		if errors.As(err, &re) && re.ResponseError != nil && re.HTTPStatusCode() == http.StatusNotFound {
+			return nil, NewItemNotFoundError(req.SourceKey)
+		}
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.CopyObject: %w", err))
+	}
+
+	resp := &CopyObjectResponse{
+		VersionID: aws.ToString(result.VersionId),
+	}
+	if result.CopyObjectResult != nil {
+		resp.ETag = aws.ToString(result.CopyObjectResult.ETag)
+	}
+
+	return resp, nil
+}
+
+// UpdateObjectMetadata replaces the user-defined metadata of the object at bucket/key
+// with metadata by copying the object onto itself. The object's content headers and
+// storage class are preserved; a nil or empty metadata map clears the metadata. The
+// copy fails if the object changes after its headers are read. On versioned buckets
+// the copy creates a new version of the object.
+func (s *S3) UpdateObjectMetadata(ctx context.Context, bucket, key string, metadata map[string]string) error {
+	head, err := s.HeadObject(ctx, GetFileRequest{Bucket: bucket, Key: key})
+	if err != nil {
+		return err
+	}
+
+	// a non-nil Metadata map makes CopyObject replace the metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	_, err = s.CopyObject(ctx, CopyObjectRequest{
+		SourceBucket:       bucket,
+		SourceKey:          key,
+		SourceIfMatch:      head.ETag,
+		Bucket:             bucket,
+		Key:                key,
+		ContentType:        head.ContentType,
+		CacheControl:       head.CacheControl,
+		ContentEncoding:    head.ContentEncoding,
+		ContentDisposition: head.ContentDisposition,
+		ContentLanguage:    head.ContentLanguage,
+		Expires:            head.Expires,
+		StorageClass:       head.StorageClass,
+		Metadata:           metadata,
+	})
+	return err
+}
+
+// copySource returns the URL encoded CopySource value for the given source object.
+func copySource(bucket, key string, versionId *string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	source := bucket + "/" + strings.Join(segments, "/")
+	if versionId != nil {
+		source += "?versionId=" + url.QueryEscape(*versionId)
+	}
+	return source
+}
+
 // DeleteFile deletes the the file at bucket/key
 func (s *S3) DeleteFile(ctx context.Context, bucket, key string, versionId *string) error {
 	input := &s3.DeleteObjectInput{
@@ -229,17 +524,310 @@ func (s *S3) DeleteFile(ctx context.Context, bucket, key string, versionId *stri
 		VersionId: versionId,
 	}
 
-	if _, err := s.svc.DeleteObject(ctx, input); err != nil {
-		return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteObject: %w", err))
+	err := s.withRetries(ctx, func() error {
+		_, err := s.svc.DeleteObject(ctx, input)
+		return err
+	})
+	if err != nil {
+		return goaws.NewServiceError(fmt.Errorf("s.svc.DeleteObject: %w", err))
+	}
+
+	return nil
+}
+
+// CreateBucket creates a new bucket in the given region. Buckets in us-east-1 are
+// created without a LocationConstraint, which S3 rejects for that region.
+// Creating a bucket that is already owned by the caller is not an error.
+func (s *S3) CreateBucket(ctx context.Context, bucket, region string) error {
+	input := &s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	}
+	if region != "" && region != "us-east-1" {
+		input.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(region),
+		}
+	}
+
+	if _, err := s.svc.CreateBucket(ctx, input); err != nil {
+		var owned *types.BucketAlreadyOwnedByYou
+		var exists *types.BucketAlreadyExists
+		switch {
+		case errors.As(err, &owned):
+			return nil
+		case errors.As(err, &exists):
+			return NewBucketExistsError(bucket)
+		default:
+			return goaws.NewServiceError(fmt.Errorf("s.svc.CreateBucket: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// DeleteBucket deletes the given bucket. The bucket must be empty.
+func (s *S3) DeleteBucket(ctx context.Context, bucket string) error {
+	input := &s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	}
+
+	if _, err := s.svc.DeleteBucket(ctx, input); err != nil {
+		var notExist *types.NoSuchBucket
+		if errors.As(err, &notExist) {
+			return NewBucketNotFoundError(bucket)
+		}
+		return goaws.NewServiceError(fmt.Errorf("s.svc.DeleteBucket: %w", err))
+	}
+
+	return nil
+}
+
+// CheckBucketExists reports whether the given bucket exists. A BucketPermissionsError
+// is returned if the bucket exists but the caller does not have access to it.
+func (s *S3) CheckBucketExists(ctx context.Context, bucket string) (bool, error) {
+	input := &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	}
+
+	if _, err := s.svc.HeadBucket(ctx, input); err != nil {
+		var notExist *types.NotFound
+		var re *awshttp.ResponseError
+		switch {
+		case errors.As(err, &notExist):
+			return false, nil
+		case errors.As(err, &re):
+			if re.ResponseError == nil {
+				return false, goaws.NewInternalError(fmt.Errorf("s.svc.HeadBucket: %w", err))
+			}
+			switch re.HTTPStatusCode() {
+			case http.StatusNotFound:
+				return false, nil
+			case http.StatusForbidden:
+				return false, NewBucketPermissionsError(bucket)
+			default:
+				return false, goaws.NewInternalError(fmt.Errorf("s.svc.HeadBucket: %w", err))
+			}
+		default:
+			return false, goaws.NewServiceError(fmt.Errorf("s.svc.HeadBucket: %w", err))
+		}
+	}
+
+	return true, nil
+}
+
+// PutBucketVersioning enables or suspends versioning on the given bucket.
+// Versioning can't be disabled once enabled, only suspended.
+func (s *S3) PutBucketVersioning(ctx context.Context, bucket string, enabled bool) error {
+	status := types.BucketVersioningStatusSuspended
+	if enabled {
+		status = types.BucketVersioningStatusEnabled
+	}
+	input := &s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucket),
+		VersioningConfiguration: &types.VersioningConfiguration{
+			Status: status,
+		},
+	}
+
+	if _, err := s.svc.PutBucketVersioning(ctx, input); err != nil {
+		var notExist *types.NoSuchBucket
+		if errors.As(err, &notExist) {
+			return NewBucketNotFoundError(bucket)
+		}
+		return goaws.NewServiceError(fmt.Errorf("s.svc.PutBucketVersioning: %w", err))
+	}
+
+	return nil
+}
+
+// PutBucketLifecycle sets the lifecycle configuration of the given bucket,
+// replacing any existing rules.
+func (s *S3) PutBucketLifecycle(ctx context.Context, bucket string, rules []LifecycleRule) error {
+	lcRules := make([]types.LifecycleRule, 0, len(rules))
+	for _, r := range rules {
+		lcRules = append(lcRules, lifecycleRule(r))
+	}
+	input := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+			Rules: lcRules,
+		},
+	}
+
+	if _, err := s.svc.PutBucketLifecycleConfiguration(ctx, input); err != nil {
+		var notExist *types.NoSuchBucket
+		if errors.As(err, &notExist) {
+			return NewBucketNotFoundError(bucket)
+		}
+		return goaws.NewServiceError(fmt.Errorf("s.svc.PutBucketLifecycleConfiguration: %w", err))
+	}
+
+	return nil
+}
+
+// lifecycleRule converts r to the equivalent types.LifecycleRule.
+func lifecycleRule(r LifecycleRule) types.LifecycleRule {
+	rule := types.LifecycleRule{
+		ID:     aws.String(r.ID),
+		Status: types.ExpirationStatusEnabled,
+		Filter: &types.LifecycleRuleFilter{Prefix: aws.String(r.Prefix)},
+	}
+	if r.Disabled {
+		rule.Status = types.ExpirationStatusDisabled
+	}
+	if r.ExpirationDays > 0 {
+		rule.Expiration = &types.LifecycleExpiration{Days: aws.Int32(r.ExpirationDays)}
+	}
+	if r.NoncurrentVersionExpirationDays > 0 {
+		rule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{
+			NoncurrentDays: aws.Int32(r.NoncurrentVersionExpirationDays),
+		}
+	}
+	if r.AbortIncompleteMultipartUploadDays > 0 {
+		rule.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{
+			DaysAfterInitiation: aws.Int32(r.AbortIncompleteMultipartUploadDays),
+		}
+	}
+	return rule
+}
+
+// ListMultipartUploads returns the multipart uploads in the given bucket that have been
+// started but not completed or aborted. The parts of these uploads accrue storage charges
+// until the upload is aborted.
+func (s *S3) ListMultipartUploads(ctx context.Context, bucket string) ([]MultipartUpload, error) {
+	input := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucket),
+	}
+
+	uploads := make([]MultipartUpload, 0)
+	for {
+		out, err := s.svc.ListMultipartUploads(ctx, input)
+		if err != nil {
+			var notExist *types.NoSuchBucket
+			if errors.As(err, &notExist) {
+				return nil, NewBucketNotFoundError(bucket)
+			}
+			return nil, goaws.NewServiceError(fmt.Errorf("s.svc.ListMultipartUploads: %w", err))
+		}
+
+		for _, u := range out.Uploads {
+			uploads = append(uploads, MultipartUpload{
+				Key:       aws.ToString(u.Key),
+				UploadID:  aws.ToString(u.UploadId),
+				Initiated: aws.ToTime(u.Initiated),
+			})
+		}
+
+		if !aws.ToBool(out.IsTruncated) {
+			break
+		}
+		input.KeyMarker = out.NextKeyMarker
+		input.UploadIdMarker = out.NextUploadIdMarker
+	}
+
+	return uploads, nil
+}
+
+// AbortMultipartUpload aborts the given multipart upload and deletes its uploaded parts.
+func (s *S3) AbortMultipartUpload(ctx context.Context, bucket, key, uploadId string) error {
+	input := &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadId),
+	}
+
+	if _, err := s.svc.AbortMultipartUpload(ctx, input); err != nil {
+		var notExist *types.NoSuchUpload
+		if errors.As(err, &notExist) {
+			return NewItemNotFoundError(uploadId)
+		}
+		return goaws.NewServiceError(fmt.Errorf("s.svc.AbortMultipartUpload: %w", err))
+	}
+
+	return nil
+}
+
+// StreamPrefixAsZip writes a zip archive of every object under the given prefix to w.
+// Each object is streamed into the archive as it is downloaded rather than buffered
+// in memory. Entries are named by their key relative to prefix, and folder placeholder
+// keys ending in "/" are skipped. If an error is returned, w may hold a partial archive.
+func (s *S3) StreamPrefixAsZip(ctx context.Context, bucket, prefix string, w io.Writer) error {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+
+	zw := zip.NewWriter(w)
+	for {
+		out, err := s.svc.ListObjectsV2(ctx, input)
+		if err != nil {
+			var notExist *types.NoSuchBucket
+			if errors.As(err, &notExist) {
+				return NewBucketNotFoundError(bucket)
+			}
+			return goaws.NewServiceError(fmt.Errorf("s.svc.ListObjectsV2: %w", err))
+		}
+
+		for _, obj := range out.Contents {
+			key := aws.ToString(obj.Key)
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+			if err := s.writeZipEntry(ctx, zw, bucket, key, zipEntryName(prefix, key), aws.ToTime(obj.LastModified)); err != nil {
+				return err
+			}
+		}
+
+		if !aws.ToBool(out.IsTruncated) {
+			break
+		}
+		input.ContinuationToken = out.NextContinuationToken
+	}
+
+	if err := zw.Close(); err != nil {
+		return goaws.NewInternalError(fmt.Errorf("zw.Close: %w", err))
 	}
+	return nil
+}
+
+// writeZipEntry copies the body of the object at bucket/key into a new zip entry.
+func (s *S3) writeZipEntry(ctx context.Context, zw *zip.Writer, bucket, key, name string, modified time.Time) error {
+	body, _, err := s.GetObjectStream(ctx, GetFileRequest{Bucket: bucket, Key: key})
+	if err != nil {
+		return err
+	}
+	defer body.Close()
 
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return goaws.NewInternalError(fmt.Errorf("zw.CreateHeader: %w", err))
+	}
+	if _, err := io.Copy(fw, body); err != nil {
+		return goaws.NewInternalError(fmt.Errorf("io.Copy: %w", err))
+	}
 	return nil
 }
 
-// GetPresignedURL returns presigned URLs for put and get requests
+// zipEntryName returns the name of key relative to prefix, falling back to the
+// key's base name if the key is the prefix itself.
+func zipEntryName(prefix, key string) string {
+	name := strings.TrimLeft(strings.TrimPrefix(key, prefix), "/")
+	if name == "" {
+		return path.Base(key)
+	}
+	return name
+}
+
+// GetPresignedURL returns presigned URLs for put, get and delete requests
 func (s *S3) GetPresignedURL(ctx context.Context, req GetPresignedUrlRequest) (*GetPresignedUrlResponse, error) {
 	var presignedUrl = new(GetPresignedUrlResponse)
 
+	if req.ExpirySeconds == 0 {
+		req.ExpirySeconds = DefaultPresignExpirySeconds
+	}
+	if req.ExpirySeconds < 0 || req.ExpirySeconds > MaxPresignExpirySeconds {
+		return nil, NewInvalidExpiryError(req.ExpirySeconds)
+	}
+
 	if req.Put != nil {
 		input := &s3.PutObjectInput{
 			Bucket:   aws.String(req.Put.Bucket),
@@ -281,6 +869,12 @@ func (s *S3) GetPresignedURL(ctx context.Context, req GetPresignedUrlRequest) (*
 		if req.Get.UseChecksum {
 			input.ChecksumMode = types.ChecksumModeEnabled
 		}
+		if req.Get.ResponseContentDisposition != "" {
+			input.ResponseContentDisposition = aws.String(req.Get.ResponseContentDisposition)
+		}
+		if req.Get.ResponseContentType != "" {
+			input.ResponseContentType = aws.String(req.Get.ResponseContentType)
+		}
 
 		resp, err := s.presignSvc.PresignGetObject(
 			ctx,
@@ -293,5 +887,91 @@ func (s *S3) GetPresignedURL(ctx context.Context, req GetPresignedUrlRequest) (*
 		presignedUrl.GetUrl = resp.URL
 	}
 
+	if req.Delete != nil {
+		input := &s3.DeleteObjectInput{
+			Bucket:    aws.String(req.Delete.Bucket),
+			Key:       aws.String(req.Delete.Key),
+			VersionId: req.Delete.VersionId,
+		}
+
+		resp, err := s.presignSvc.PresignDeleteObject(
+			ctx,
+			input,
+			s3.WithPresignExpires(time.Second*time.Duration(req.ExpirySeconds)),
+		)
+		if err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("psCli.PresignDeleteObject: %w", err))
+		}
+		presignedUrl.DeleteUrl = resp.URL
+	}
+
 	return presignedUrl, nil
 }
+
+// withRetries calls fn until it succeeds or returns an error that isn't retryable,
+// waiting between attempts per s.fc. Retries stop with a DeadlineExceededError once
+// ctx is done or its deadline would pass before the next attempt.
+// Retries are disabled if s.fc is nil.
+func (s *S3) withRetries(ctx context.Context, fn func() error) error {
+	if s.fc == nil {
+		return fn()
+	}
+	retries := s.fc.NewRetries()
+	for {
+		err := fn()
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if err := retries.ExponentialBackoffContext(ctx); err != nil { // waits
+			return fmt.Errorf("retries.ExponentialBackoffContext: %w", err)
+		}
+	}
+}
+
+// isRetryable returns true if err is an S3 throttling (503 SlowDown) or server error.
+func isRetryable(err error) bool {
+	var re *awshttp.ResponseError
+	if !errors.As(err, &re) || re.ResponseError == nil || re.Response == nil {
+		return false
+	}
+	switch re.HTTPStatusCode() {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// computeChecksum returns the base64 encoded SHA256 checksum of b.
+func computeChecksum(b []byte) SHA256Checksum {
+	sum := sha256.Sum256(b)
+	return SHA256Checksum(base64.StdEncoding.EncodeToString(sum[:]))
+}
+
+// checksumReader computes the SHA256 checksum of r and returns a reader positioned at the
+// start of the same content. Seekable readers are rewound; other readers are buffered.
+func checksumReader(r io.Reader) (io.Reader, SHA256Checksum, error) {
+	if r == nil {
+		return nil, computeChecksum(nil), nil
+	}
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		start, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, "", goaws.NewInternalError(fmt.Errorf("rs.Seek: %w", err))
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, rs); err != nil {
+			return nil, "", goaws.NewInternalError(fmt.Errorf("io.Copy: %w", err))
+		}
+		if _, err := rs.Seek(start, io.SeekStart); err != nil {
+			return nil, "", goaws.NewInternalError(fmt.Errorf("rs.Seek: %w", err))
+		}
+		return rs, SHA256Checksum(base64.StdEncoding.EncodeToString(h.Sum(nil))), nil
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", goaws.NewInternalError(fmt.Errorf("io.ReadAll: %w", err))
+	}
+	return bytes.NewReader(b), computeChecksum(b), nil
+}
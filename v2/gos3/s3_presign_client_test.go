@@ -42,6 +42,26 @@ func (m *MockS3PresignClientAPI) EXPECT() *MockS3PresignClientAPIMockRecorder {
 	return m.recorder
 }
 
+// PresignDeleteObject mocks base method.
+func (m *MockS3PresignClientAPI) PresignDeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PresignDeleteObject", varargs...)
+	ret0, _ := ret[0].(*v4.PresignedHTTPRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignDeleteObject indicates an expected call of PresignDeleteObject.
+func (mr *MockS3PresignClientAPIMockRecorder) PresignDeleteObject(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignDeleteObject", reflect.TypeOf((*MockS3PresignClientAPI)(nil).PresignDeleteObject), varargs...)
+}
+
 // PresignGetObject mocks base method.
 func (m *MockS3PresignClientAPI) PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
 	m.ctrl.T.Helper()
@@ -1,19 +1,26 @@
 package gos3
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"go.uber.org/mock/gomock"
 
@@ -67,6 +74,55 @@ func TestS3_GetObject(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "ChecksumMatch",
+			req: GetFileRequest{
+				Bucket:      "test-bucket",
+				Key:         "test-key",
+				UseChecksum: true,
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().GetObject(context.Background(), &s3.GetObjectInput{
+					Bucket:       aws.String("test-bucket"),
+					Key:          aws.String("test-key"),
+					ChecksumMode: types.ChecksumModeEnabled,
+				}).Return(&s3.GetObjectOutput{
+					Body:           io.NopCloser(strings.NewReader("test content")),
+					ChecksumSHA256: aws.String("auinVVUgn9bEQVfArtgBbnY/9DWhnPGG92hjFAFD/3I="),
+				}, nil).Times(1)
+				return m
+			},
+			expectedBytes: &GetObjectResponse{
+				File: []byte("test content"),
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ChecksumMismatch",
+			req: GetFileRequest{
+				Bucket:      "test-bucket",
+				Key:         "test-key",
+				UseChecksum: true,
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().GetObject(context.Background(), &s3.GetObjectInput{
+					Bucket:       aws.String("test-bucket"),
+					Key:          aws.String("test-key"),
+					ChecksumMode: types.ChecksumModeEnabled,
+				}).Return(&s3.GetObjectOutput{
+					Body:           io.NopCloser(strings.NewReader("corrupted content")),
+					ChecksumSHA256: aws.String("auinVVUgn9bEQVfArtgBbnY/9DWhnPGG92hjFAFD/3I="),
+				}, nil).Times(1)
+				return m
+			},
+			expectedBytes: nil,
+			expectedError: NewChecksumMismatchError(
+				"auinVVUgn9bEQVfArtgBbnY/9DWhnPGG92hjFAFD/3I=",
+				computeChecksum([]byte("corrupted content")),
+			),
+		},
 		{
 			name: "NotFound",
 			req: GetFileRequest{
@@ -109,6 +165,59 @@ func TestS3_GetObject(t *testing.T) {
 			expectedBytes: nil,
 			expectedError: NewItemNotFoundError("missing-key"),
 		},
+		{
+			name: "ConditionalModified",
+			req: GetFileRequest{
+				Bucket:          "test-bucket",
+				Key:             "test-key",
+				IfNoneMatch:     aws.String(`"old-etag"`),
+				IfModifiedSince: aws.Time(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().GetObject(context.Background(), &s3.GetObjectInput{
+					Bucket:          aws.String("test-bucket"),
+					Key:             aws.String("test-key"),
+					IfNoneMatch:     aws.String(`"old-etag"`),
+					IfModifiedSince: aws.Time(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				}).Return(&s3.GetObjectOutput{
+					Body: io.NopCloser(strings.NewReader("new content")),
+				}, nil).Times(1)
+				return m
+			},
+			expectedBytes: &GetObjectResponse{
+				File: []byte("new content"),
+			},
+			expectedError: nil,
+		},
+		{
+			name: "NotModified",
+			req: GetFileRequest{
+				Bucket:      "test-bucket",
+				Key:         "test-key",
+				IfNoneMatch: aws.String(`"current-etag"`),
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().GetObject(context.Background(), &s3.GetObjectInput{
+					Bucket:      aws.String("test-bucket"),
+					Key:         aws.String("test-key"),
+					IfNoneMatch: aws.String(`"current-etag"`),
+				}).Return(nil, &awshttp.ResponseError{
+					ResponseError: &smithyhttp.ResponseError{
+						Response: &smithyhttp.Response{
+							Response: &http.Response{
+								StatusCode: http.StatusNotModified,
+							},
+						},
+						Err: &smithy.GenericAPIError{Code: "NotModified", Message: "Not Modified"},
+					},
+				}).Times(1)
+				return m
+			},
+			expectedBytes: nil,
+			expectedError: NewNotModifiedError("test-key"),
+		},
 		{
 			name: "OtherError",
 			req: GetFileRequest{
@@ -150,6 +259,189 @@ func TestS3_GetObject(t *testing.T) {
 	}
 }
 
+// closeRecorder records whether the body returned by GetObjectStream was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestS3_GetObjectStream(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           GetFileRequest
+		body          *closeRecorder
+		mockSetup     func(ctrl *gomock.Controller, body io.ReadCloser) S3ClientAPI
+		expectedResp  *GetObjectResponse
+		expectedBody  string
+		expectedError error
+	}{
+		{
+			name: "Success",
+			req: GetFileRequest{
+				Bucket: "test-bucket",
+				Key:    "test-key",
+			},
+			body: &closeRecorder{Reader: strings.NewReader("test content")},
+			mockSetup: func(ctrl *gomock.Controller, body io.ReadCloser) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().GetObject(context.Background(), &s3.GetObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("test-key"),
+				}).Return(&s3.GetObjectOutput{
+					Body:          body,
+					ContentType:   aws.String("text/plain"),
+					ContentLength: aws.Int64(12),
+					ETag:          aws.String("\"etag\""),
+				}, nil).Times(1)
+				return m
+			},
+			expectedResp: &GetObjectResponse{
+				ContentType:   "text/plain",
+				ContentLength: 12,
+				ETag:          "\"etag\"",
+			},
+			expectedBody: "test content",
+		},
+		{
+			name: "NotFound",
+			req: GetFileRequest{
+				Bucket: "test-bucket",
+				Key:    "missing-key",
+			},
+			mockSetup: func(ctrl *gomock.Controller, _ io.ReadCloser) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().GetObject(context.Background(), &s3.GetObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("missing-key"),
+				}).Return(nil, &types.NoSuchKey{}).Times(1)
+				return m
+			},
+			expectedError: NewItemNotFoundError("missing-key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSvc := tt.mockSetup(ctrl, tt.body)
+			s := &S3{svc: mockSvc}
+
+			body, res, err := s.GetObjectStream(context.Background(), tt.req)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Nil(t, body)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResp, res)
+
+			b, err := io.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, string(b))
+
+			// the body is left open for the caller
+			assert.False(t, tt.body.closed)
+			require.NoError(t, body.Close())
+			assert.True(t, tt.body.closed)
+		})
+	}
+}
+
+func TestS3_DownloadConcurrent(t *testing.T) {
+	content := "0123456789abcdefghij"
+
+	// serveRange returns the requested byte range of content like S3 does for a ranged GET.
+	serveRange := func(calls *atomic.Int32) func(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+		return func(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			calls.Add(1)
+			var start, end int
+			if _, err := fmt.Sscanf(aws.ToString(in.Range), "bytes=%d-%d", &start, &end); err != nil {
+				return nil, err
+			}
+			end = min(end, len(content)-1)
+			return &s3.GetObjectOutput{
+				Body:          io.NopCloser(strings.NewReader(content[start : end+1])),
+				ContentLength: aws.Int64(int64(end - start + 1)),
+				ContentRange:  aws.String(fmt.Sprintf("bytes %d-%d/%d", start, end, len(content))),
+			}, nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		concurrency   int
+		mockSetup     func(m *MockS3ClientAPI, calls *atomic.Int32)
+		expected      string
+		expectedCalls int32
+		expectedError error
+	}{
+		{
+			name:        "Success",
+			concurrency: 3,
+			mockSetup: func(m *MockS3ClientAPI, calls *atomic.Int32) {
+				m.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(serveRange(calls)).Times(5)
+			},
+			expected:      content,
+			expectedCalls: 5,
+		},
+		{
+			name:        "DefaultConcurrency",
+			concurrency: 0,
+			mockSetup: func(m *MockS3ClientAPI, calls *atomic.Int32) {
+				m.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(serveRange(calls)).Times(5)
+			},
+			expected:      content,
+			expectedCalls: 5,
+		},
+		{
+			name:        "NoSuchKey",
+			concurrency: 3,
+			mockSetup: func(m *MockS3ClientAPI, calls *atomic.Int32) {
+				m.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.NoSuchKey{}).Times(1)
+			},
+			expectedError: NewItemNotFoundError("test-key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var calls atomic.Int32
+			m := NewMockS3ClientAPI(ctrl)
+			tt.mockSetup(m, &calls)
+			s := &S3{svc: m, partSize: 4}
+
+			buf := manager.NewWriteAtBuffer(nil)
+			n, err := s.DownloadConcurrent(context.Background(), GetFileRequest{Bucket: "test-bucket", Key: "test-key"}, buf, tt.concurrency)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expected)), n)
+			assert.Equal(t, tt.expected, string(buf.Bytes()))
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
 func TestS3_HeadObject(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -297,6 +589,32 @@ func TestS3_CheckIfObjectExists(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "ExistsWithMetadata",
+			req: GetFileRequest{
+				Bucket: "test-bucket",
+				Key:    "test-key",
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().HeadObject(context.Background(), &s3.HeadObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("test-key"),
+				}).Return(&s3.HeadObjectOutput{
+					ContentLength: aws.Int64(1024),
+					LastModified:  aws.Time(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+					ContentType:   aws.String("application/json"),
+				}, nil).Times(1)
+				return m
+			},
+			expectedExists: &ObjectExistsResponse{
+				Exists:       true,
+				Size:         1024,
+				LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				ContentType:  "application/json",
+			},
+			expectedError: nil,
+		},
 		{
 			name: "DoesNotExist",
 			req: GetFileRequest{
@@ -380,11 +698,13 @@ func TestS3_UploadFile(t *testing.T) {
 					Body:   bytes.NewReader([]byte("content")),
 				}).Return(&s3.PutObjectOutput{
 					VersionId: aws.String("v1"),
+					ETag:      aws.String("\"9a0364b9e99bb480dd25e1f0284c8555\""),
 				}, nil).Times(1)
 				return m
 			},
 			expectedResp: &UploadFileResponse{
 				VersionID: "v1",
+				ETag:      "\"9a0364b9e99bb480dd25e1f0284c8555\"",
 			},
 			expectedError: nil,
 		},
@@ -431,6 +751,59 @@ func TestS3_UploadFile(t *testing.T) {
 	}
 }
 
+func TestS3_UploadFile_AutoChecksum(t *testing.T) {
+	const expected = "auinVVUgn9bEQVfArtgBbnY/9DWhnPGG92hjFAFD/3I="
+
+	tests := []struct {
+		name string
+		file io.Reader
+	}{
+		{
+			name: "Seeker",
+			file: bytes.NewReader([]byte("test content")),
+		},
+		{
+			name: "Reader",
+			file: io.MultiReader(strings.NewReader("test "), strings.NewReader("content")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				checksum string
+				body     []byte
+			)
+			m := NewMockS3ClientAPI(ctrl)
+			m.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					checksum = aws.ToString(in.ChecksumSHA256)
+					b, err := io.ReadAll(in.Body)
+					if err != nil {
+						return nil, err
+					}
+					body = b
+					return &s3.PutObjectOutput{}, nil
+				}).Times(1)
+			s := &S3{svc: m}
+
+			_, err := s.UploadFile(context.Background(), UploadFileRequest{
+				Bucket:       "test-bucket",
+				Key:          "test-key",
+				File:         tt.file,
+				AutoChecksum: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, expected, checksum)
+			assert.Equal(t, "test content", string(body))
+		})
+	}
+}
+
 func TestS3_DeleteFile(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -491,53 +864,1066 @@ func TestS3_DeleteFile(t *testing.T) {
 	}
 }
 
-func TestS3_GetPresignedURL(t *testing.T) {
+func TestS3_Retries(t *testing.T) {
+	slowDown := func() error {
+		return &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{
+					Response: &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+					},
+				},
+				Err: errors.New("SlowDown"),
+			},
+		}
+	}
+
 	tests := []struct {
 		name          string
-		req           GetPresignedUrlRequest
-		mockSetup     func(ctrl *gomock.Controller) S3PresignClientAPI
-		expectedResp  *GetPresignedUrlResponse
-		expectedError error
+		mockSetup     func(ctrl *gomock.Controller) S3ClientAPI
+		call          func(s *S3) error
+		expectedError string
 	}{
 		{
-			name: "PutRequest",
-			req: GetPresignedUrlRequest{
-				Put: &UploadFileRequest{
-					Bucket: "test-bucket",
-					Key:    "test-key",
-					File:   bytes.NewReader([]byte("content")),
-				},
-				ExpirySeconds: 3600,
-			},
-			mockSetup: func(ctrl *gomock.Controller) S3PresignClientAPI {
-				m := NewMockS3PresignClientAPI(ctrl)
-				m.EXPECT().PresignPutObject(context.Background(), &s3.PutObjectInput{
-					Bucket: aws.String("test-bucket"),
-					Key:    aws.String("test-key"),
-					Body:   bytes.NewReader([]byte("content")),
-				},
-					gomock.Any(),
-				).Return(&v4.PresignedHTTPRequest{
-					URL: "https://test-bucket.s3.amazonaws.com/test-key?signature=xyz",
-				}, nil).Times(1)
+			name: "GetObject",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				gomock.InOrder(
+					m.EXPECT().GetObject(gomock.Any(), gomock.Any()).Return(nil, slowDown()).Times(1),
+					m.EXPECT().GetObject(gomock.Any(), gomock.Any()).Return(&s3.GetObjectOutput{
+						Body: io.NopCloser(strings.NewReader("test content")),
+					}, nil).Times(1),
+				)
 				return m
 			},
-			expectedResp: &GetPresignedUrlResponse{
-				PutUrl: "https://test-bucket.s3.amazonaws.com/test-key?signature=xyz",
+			call: func(s *S3) error {
+				resp, err := s.GetObject(context.Background(), GetFileRequest{Bucket: "test-bucket", Key: "test-key"})
+				if err == nil {
+					assert.Equal(t, []byte("test content"), resp.File)
+				}
+				return err
 			},
-			expectedError: nil,
 		},
 		{
-			name: "GetRequest",
-			req: GetPresignedUrlRequest{
-				ExpirySeconds: 3600,
-				Get: &GetFileRequest{
+			name: "UploadFile",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				readBody := func(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) {
+					b, err := io.ReadAll(in.Body)
+					require.NoError(t, err)
+					assert.Equal(t, "test content", string(b))
+				}
+				gomock.InOrder(
+					m.EXPECT().PutObject(gomock.Any(), gomock.Any()).Do(readBody).Return(nil, slowDown()).Times(1),
+					m.EXPECT().PutObject(gomock.Any(), gomock.Any()).Do(readBody).Return(&s3.PutObjectOutput{
+						VersionId: aws.String("v1"),
+					}, nil).Times(1),
+				)
+				return m
+			},
+			call: func(s *S3) error {
+				resp, err := s.UploadFile(context.Background(), UploadFileRequest{
 					Bucket: "test-bucket",
 					Key:    "test-key",
-				},
+					File:   bytes.NewReader([]byte("test content")),
+				})
+				if err == nil {
+					assert.Equal(t, "v1", resp.VersionID)
+				}
+				return err
 			},
-			mockSetup: func(ctrl *gomock.Controller) S3PresignClientAPI {
-				m := NewMockS3PresignClientAPI(ctrl)
+		},
+		{
+			name: "UploadFileNotSeekable",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().PutObject(gomock.Any(), gomock.Any()).Return(nil, slowDown()).Times(1)
+				return m
+			},
+			call: func(s *S3) error {
+				_, err := s.UploadFile(context.Background(), UploadFileRequest{
+					Bucket: "test-bucket",
+					Key:    "test-key",
+					File:   io.NopCloser(strings.NewReader("test content")),
+				})
+				return err
+			},
+			expectedError: "s.svc.PutObject: ",
+		},
+		{
+			name: "DeleteFile",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				gomock.InOrder(
+					m.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(nil, slowDown()).Times(2),
+					m.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(&s3.DeleteObjectOutput{}, nil).Times(1),
+				)
+				return m
+			},
+			call: func(s *S3) error {
+				return s.DeleteFile(context.Background(), "test-bucket", "test-key", nil)
+			},
+		},
+		{
+			name: "MaxRetriesExceeded",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(nil, slowDown()).MinTimes(2)
+				return m
+			},
+			call: func(s *S3) error {
+				return s.DeleteFile(context.Background(), "test-bucket", "test-key", nil)
+			},
+			expectedError: "s.svc.DeleteObject: retries.ExponentialBackoffContext: max retries exceeded",
+		},
+		{
+			name: "ContextCanceled",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(nil, slowDown()).Times(1)
+				return m
+			},
+			call: func(s *S3) error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return s.DeleteFile(ctx, "test-bucket", "test-key", nil)
+			},
+			expectedError: "s.svc.DeleteObject: retries.ExponentialBackoffContext: context deadline exceeded before next retry",
+		},
+		{
+			name: "NotRetryable",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(nil, errors.New("access denied")).Times(1)
+				return m
+			},
+			call: func(s *S3) error {
+				return s.DeleteFile(context.Background(), "test-bucket", "test-key", nil)
+			},
+			expectedError: "s.svc.DeleteObject: access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := (&S3{svc: tt.mockSetup(ctrl)}).WithFailConfig(goaws.NewFailConfig(1, 5, 1))
+
+			err := tt.call(s)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_CreateBucket(t *testing.T) {
+	tests := []struct {
+		name          string
+		bucket        string
+		region        string
+		mockSetup     func(ctrl *gomock.Controller) S3ClientAPI
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			bucket: "test-bucket",
+			region: "us-west-2",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().CreateBucket(context.Background(), &s3.CreateBucketInput{
+					Bucket: aws.String("test-bucket"),
+					CreateBucketConfiguration: &types.CreateBucketConfiguration{
+						LocationConstraint: types.BucketLocationConstraintUsWest2,
+					},
+				}).Return(&s3.CreateBucketOutput{}, nil).Times(1)
+				return m
+			},
+		},
+		{
+			name:   "UsEast1",
+			bucket: "test-bucket",
+			region: "us-east-1",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().CreateBucket(context.Background(), &s3.CreateBucketInput{
+					Bucket: aws.String("test-bucket"),
+				}).Return(&s3.CreateBucketOutput{}, nil).Times(1)
+				return m
+			},
+		},
+		{
+			name:   "AlreadyOwned",
+			bucket: "test-bucket",
+			region: "us-west-2",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().CreateBucket(gomock.Any(), gomock.Any()).Return(nil, &types.BucketAlreadyOwnedByYou{}).Times(1)
+				return m
+			},
+		},
+		{
+			name:   "AlreadyExists",
+			bucket: "test-bucket",
+			region: "us-west-2",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().CreateBucket(gomock.Any(), gomock.Any()).Return(nil, &types.BucketAlreadyExists{}).Times(1)
+				return m
+			},
+			expectedError: NewBucketExistsError("test-bucket"),
+		},
+		{
+			name:   "Error",
+			bucket: "test-bucket",
+			region: "us-west-2",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().CreateBucket(gomock.Any(), gomock.Any()).Return(nil, errors.New("create fail")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.CreateBucket: create fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s := &S3{svc: tt.mockSetup(ctrl)}
+
+			err := s.CreateBucket(context.Background(), tt.bucket, tt.region)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_DeleteBucket(t *testing.T) {
+	tests := []struct {
+		name          string
+		bucket        string
+		mockSetup     func(ctrl *gomock.Controller) S3ClientAPI
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			bucket: "test-bucket",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().DeleteBucket(context.Background(), &s3.DeleteBucketInput{
+					Bucket: aws.String("test-bucket"),
+				}).Return(&s3.DeleteBucketOutput{}, nil).Times(1)
+				return m
+			},
+		},
+		{
+			name:   "NotFound",
+			bucket: "missing-bucket",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().DeleteBucket(gomock.Any(), gomock.Any()).Return(nil, &types.NoSuchBucket{}).Times(1)
+				return m
+			},
+			expectedError: NewBucketNotFoundError("missing-bucket"),
+		},
+		{
+			name:   "Error",
+			bucket: "test-bucket",
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().DeleteBucket(gomock.Any(), gomock.Any()).Return(nil, errors.New("delete fail")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.DeleteBucket: delete fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s := &S3{svc: tt.mockSetup(ctrl)}
+
+			err := s.DeleteBucket(context.Background(), tt.bucket)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_CheckBucketExists(t *testing.T) {
+	responseError := func(status int) error {
+		return &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{
+					Response: &http.Response{StatusCode: status},
+				},
+				Err: errors.New(http.StatusText(status)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mockErr       error
+		expected      bool
+		expectedError error
+	}{
+		{name: "Exists", expected: true},
+		{name: "NotFound", mockErr: &types.NotFound{}, expected: false},
+		{name: "StatusNotFound", mockErr: responseError(http.StatusNotFound), expected: false},
+		{name: "Forbidden", mockErr: responseError(http.StatusForbidden), expectedError: NewBucketPermissionsError("test-bucket")},
+		{name: "Error", mockErr: errors.New("head fail"), expectedError: goaws.NewInternalError(errors.New("s.svc.HeadBucket: head fail"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockS3ClientAPI(ctrl)
+			var out *s3.HeadBucketOutput
+			if tt.mockErr == nil {
+				out = &s3.HeadBucketOutput{}
+			}
+			m.EXPECT().HeadBucket(context.Background(), &s3.HeadBucketInput{
+				Bucket: aws.String("test-bucket"),
+			}).Return(out, tt.mockErr).Times(1)
+			s := &S3{svc: m}
+
+			exists, err := s.CheckBucketExists(context.Background(), "test-bucket")
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.False(t, exists)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, exists)
+			}
+		})
+	}
+}
+
+func TestS3_AccessDenied(t *testing.T) {
+	forbidden := &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusForbidden}},
+			Err:      errors.New("forbidden"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		mockSetup func(m *MockS3ClientAPI)
+		call      func(s *S3) error
+	}{
+		{
+			name: "GetObject",
+			mockSetup: func(m *MockS3ClientAPI) {
+				m.EXPECT().GetObject(gomock.Any(), gomock.Any()).Return(nil, forbidden).Times(1)
+			},
+			call: func(s *S3) error {
+				_, err := s.GetObject(context.Background(), GetFileRequest{Bucket: "test-bucket", Key: "test-key"})
+				return err
+			},
+		},
+		{
+			name: "UploadFile",
+			mockSetup: func(m *MockS3ClientAPI) {
+				m.EXPECT().PutObject(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied"}).Times(1)
+			},
+			call: func(s *S3) error {
+				_, err := s.UploadFile(context.Background(), UploadFileRequest{Bucket: "test-bucket", Key: "test-key"})
+				return err
+			},
+		},
+		{
+			name: "DeleteFile",
+			mockSetup: func(m *MockS3ClientAPI) {
+				m.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(nil, forbidden).Times(1)
+			},
+			call: func(s *S3) error {
+				return s.DeleteFile(context.Background(), "test-bucket", "test-key", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockS3ClientAPI(ctrl)
+			tt.mockSetup(m)
+			s := &S3{svc: m}
+
+			err := tt.call(s)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, goaws.ErrAccessDenied)
+			assert.Implements(t, (*goaws.AwsError)(nil), err)
+			assert.True(t, err.(goaws.AwsError).ClientError())
+		})
+	}
+}
+
+func TestS3_PutBucketVersioning(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		mockErr        error
+		expectedStatus types.BucketVersioningStatus
+		expectedError  error
+	}{
+		{name: "Enable", enabled: true, expectedStatus: types.BucketVersioningStatusEnabled},
+		{name: "Suspend", enabled: false, expectedStatus: types.BucketVersioningStatusSuspended},
+		{name: "NotFound", enabled: true, mockErr: &types.NoSuchBucket{}, expectedStatus: types.BucketVersioningStatusEnabled, expectedError: NewBucketNotFoundError("test-bucket")},
+		{name: "Error", enabled: true, mockErr: errors.New("put fail"), expectedStatus: types.BucketVersioningStatusEnabled, expectedError: goaws.NewInternalError(errors.New("s.svc.PutBucketVersioning: put fail"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := NewMockS3ClientAPI(ctrl)
+			m.EXPECT().PutBucketVersioning(context.Background(), &s3.PutBucketVersioningInput{
+				Bucket: aws.String("test-bucket"),
+				VersioningConfiguration: &types.VersioningConfiguration{
+					Status: tt.expectedStatus,
+				},
+			}).Return(&s3.PutBucketVersioningOutput{}, tt.mockErr).Times(1)
+			s := &S3{svc: m}
+
+			err := s.PutBucketVersioning(context.Background(), "test-bucket", tt.enabled)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_PutBucketLifecycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []LifecycleRule
+		mockSetup     func(ctrl *gomock.Controller) S3ClientAPI
+		expectedError error
+	}{
+		{
+			name: "ExpireOldVersions",
+			rules: []LifecycleRule{
+				{
+					ID:                                 "expire-old-versions",
+					Prefix:                             "logs/",
+					NoncurrentVersionExpirationDays:    30,
+					AbortIncompleteMultipartUploadDays: 7,
+				},
+				{
+					ID:             "expire-tmp",
+					Prefix:         "tmp/",
+					Disabled:       true,
+					ExpirationDays: 1,
+				},
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().PutBucketLifecycleConfiguration(context.Background(), &s3.PutBucketLifecycleConfigurationInput{
+					Bucket: aws.String("test-bucket"),
+					LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+						Rules: []types.LifecycleRule{
+							{
+								ID:     aws.String("expire-old-versions"),
+								Status: types.ExpirationStatusEnabled,
+								Filter: &types.LifecycleRuleFilter{Prefix: aws.String("logs/")},
+								NoncurrentVersionExpiration: &types.NoncurrentVersionExpiration{
+									NoncurrentDays: aws.Int32(30),
+								},
+								AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{
+									DaysAfterInitiation: aws.Int32(7),
+								},
+							},
+							{
+								ID:         aws.String("expire-tmp"),
+								Status:     types.ExpirationStatusDisabled,
+								Filter:     &types.LifecycleRuleFilter{Prefix: aws.String("tmp/")},
+								Expiration: &types.LifecycleExpiration{Days: aws.Int32(1)},
+							},
+						},
+					},
+				}).Return(&s3.PutBucketLifecycleConfigurationOutput{}, nil).Times(1)
+				return m
+			},
+		},
+		{
+			name:  "Error",
+			rules: []LifecycleRule{{ID: "rule", ExpirationDays: 1}},
+			mockSetup: func(ctrl *gomock.Controller) S3ClientAPI {
+				m := NewMockS3ClientAPI(ctrl)
+				m.EXPECT().PutBucketLifecycleConfiguration(gomock.Any(), gomock.Any()).Return(nil, errors.New("put fail")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.PutBucketLifecycleConfiguration: put fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s := &S3{svc: tt.mockSetup(ctrl)}
+
+			err := s.PutBucketLifecycle(context.Background(), "test-bucket", tt.rules)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_ListMultipartUploads(t *testing.T) {
+	initiated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		mockSetup     func(t *testing.T, m *MockS3ClientAPI)
+		expected      []MultipartUpload
+		expectedError error
+	}{
+		{
+			name: "Paginated",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().ListMultipartUploads(context.Background(), &s3.ListMultipartUploadsInput{
+						Bucket: aws.String("test-bucket"),
+					}).Return(&s3.ListMultipartUploadsOutput{
+						Uploads: []types.MultipartUpload{
+							{Key: aws.String("a"), UploadId: aws.String("upload-a"), Initiated: aws.Time(initiated)},
+						},
+						IsTruncated:        aws.Bool(true),
+						NextKeyMarker:      aws.String("a"),
+						NextUploadIdMarker: aws.String("upload-a"),
+					}, nil).Times(1),
+					m.EXPECT().ListMultipartUploads(context.Background(), &s3.ListMultipartUploadsInput{
+						Bucket:         aws.String("test-bucket"),
+						KeyMarker:      aws.String("a"),
+						UploadIdMarker: aws.String("upload-a"),
+					}).Return(&s3.ListMultipartUploadsOutput{
+						Uploads: []types.MultipartUpload{
+							{Key: aws.String("b"), UploadId: aws.String("upload-b"), Initiated: aws.Time(initiated)},
+						},
+						IsTruncated: aws.Bool(false),
+					}, nil).Times(1),
+				)
+			},
+			expected: []MultipartUpload{
+				{Key: "a", UploadID: "upload-a", Initiated: initiated},
+				{Key: "b", UploadID: "upload-b", Initiated: initiated},
+			},
+		},
+		{
+			name: "Empty",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListMultipartUploads(gomock.Any(), gomock.Any()).Return(&s3.ListMultipartUploadsOutput{}, nil).Times(1)
+			},
+			expected: []MultipartUpload{},
+		},
+		{
+			name: "NotFound",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListMultipartUploads(gomock.Any(), gomock.Any()).Return(nil, &types.NoSuchBucket{}).Times(1)
+			},
+			expectedError: NewBucketNotFoundError("test-bucket"),
+		},
+		{
+			name: "Error",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListMultipartUploads(gomock.Any(), gomock.Any()).Return(nil, errors.New("list fail")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.ListMultipartUploads: list fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := NewMockS3ClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := &S3{svc: m}
+
+			uploads, err := s.ListMultipartUploads(context.Background(), "test-bucket")
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, uploads)
+			}
+		})
+	}
+}
+
+func TestS3_AbortMultipartUpload(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockErr       error
+		expectedError error
+	}{
+		{name: "Success"},
+		{name: "NotFound", mockErr: &types.NoSuchUpload{}, expectedError: NewItemNotFoundError("upload-1")},
+		{name: "Error", mockErr: errors.New("abort fail"), expectedError: goaws.NewInternalError(errors.New("s.svc.AbortMultipartUpload: abort fail"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockS3ClientAPI(ctrl)
+			var out *s3.AbortMultipartUploadOutput
+			if tt.mockErr == nil {
+				out = &s3.AbortMultipartUploadOutput{}
+			}
+			m.EXPECT().AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+				Bucket:   aws.String("test-bucket"),
+				Key:      aws.String("test-key"),
+				UploadId: aws.String("upload-1"),
+			}).Return(out, tt.mockErr).Times(1)
+			s := &S3{svc: m}
+
+			err := s.AbortMultipartUpload(context.Background(), "test-bucket", "test-key", "upload-1")
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_StreamPrefixAsZip(t *testing.T) {
+	modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	getObject := func(m *MockS3ClientAPI, key, body string) *gomock.Call {
+		return m.EXPECT().GetObject(gomock.Any(), &s3.GetObjectInput{
+			Bucket: aws.String("test-bucket"),
+			Key:    aws.String(key),
+		}).Return(&s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(body))}, nil).Times(1)
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(t *testing.T, m *MockS3ClientAPI)
+		expected      map[string]string
+		expectedError error
+	}{
+		{
+			name: "Paginated",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+						Bucket: aws.String("test-bucket"),
+						Prefix: aws.String("exports/"),
+					}).Return(&s3.ListObjectsV2Output{
+						Contents: []types.Object{
+							{Key: aws.String("exports/"), LastModified: aws.Time(modified)},
+							{Key: aws.String("exports/a.txt"), LastModified: aws.Time(modified)},
+						},
+						IsTruncated:           aws.Bool(true),
+						NextContinuationToken: aws.String("token-1"),
+					}, nil).Times(1),
+					getObject(m, "exports/a.txt", "file a"),
+					m.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+						Bucket:            aws.String("test-bucket"),
+						Prefix:            aws.String("exports/"),
+						ContinuationToken: aws.String("token-1"),
+					}).Return(&s3.ListObjectsV2Output{
+						Contents: []types.Object{
+							{Key: aws.String("exports/nested/b.csv"), LastModified: aws.Time(modified)},
+						},
+						IsTruncated: aws.Bool(false),
+					}, nil).Times(1),
+					getObject(m, "exports/nested/b.csv", "id,name\n1,b\n"),
+				)
+			},
+			expected: map[string]string{
+				"a.txt":        "file a",
+				"nested/b.csv": "id,name\n1,b\n",
+			},
+		},
+		{
+			name: "Empty",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListObjectsV2(gomock.Any(), gomock.Any()).Return(&s3.ListObjectsV2Output{}, nil).Times(1)
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "BucketNotFound",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListObjectsV2(gomock.Any(), gomock.Any()).Return(nil, &types.NoSuchBucket{}).Times(1)
+			},
+			expectedError: NewBucketNotFoundError("test-bucket"),
+		},
+		{
+			name: "ListError",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListObjectsV2(gomock.Any(), gomock.Any()).Return(nil, errors.New("list fail")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.ListObjectsV2: list fail")),
+		},
+		{
+			name: "GetObjectNotFound",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().ListObjectsV2(gomock.Any(), gomock.Any()).Return(&s3.ListObjectsV2Output{
+					Contents: []types.Object{{Key: aws.String("exports/a.txt")}},
+				}, nil).Times(1)
+				m.EXPECT().GetObject(gomock.Any(), gomock.Any()).Return(nil, &types.NoSuchKey{}).Times(1)
+			},
+			expectedError: NewItemNotFoundError("exports/a.txt"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := NewMockS3ClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := &S3{svc: m}
+
+			var buf bytes.Buffer
+			err := s.StreamPrefixAsZip(context.Background(), "test-bucket", "exports/", &buf)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, tt.expectedError, err.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				return
+			}
+			require.NoError(t, err)
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+			files := make(map[string]string, len(zr.File))
+			for _, f := range zr.File {
+				rc, err := f.Open()
+				require.NoError(t, err)
+				b, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				require.NoError(t, rc.Close())
+				files[f.Name] = string(b)
+				assert.True(t, modified.Equal(f.Modified.UTC()))
+			}
+			assert.Equal(t, tt.expected, files)
+		})
+	}
+}
+
+func TestS3_CopyObject(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           CopyObjectRequest
+		mockSetup     func(t *testing.T, m *MockS3ClientAPI)
+		expectedResp  *CopyObjectResponse
+		expectedError error
+	}{
+		{
+			name: "CopyMetadata",
+			req: CopyObjectRequest{
+				SourceBucket:    "src-bucket",
+				SourceKey:       "path/to/my file.txt",
+				SourceVersionId: aws.String("v1"),
+				Bucket:          "dst-bucket",
+				Key:             "dst-key",
+			},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *s3.CopyObjectInput, _ ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+						assert.Equal(t, "src-bucket/path/to/my%20file.txt?versionId=v1", aws.ToString(in.CopySource))
+						assert.Equal(t, "dst-bucket", aws.ToString(in.Bucket))
+						assert.Equal(t, "dst-key", aws.ToString(in.Key))
+						assert.Empty(t, in.MetadataDirective)
+						assert.Nil(t, in.Metadata)
+						assert.Nil(t, in.ContentType)
+						return &s3.CopyObjectOutput{
+							VersionId:        aws.String("v2"),
+							CopyObjectResult: &types.CopyObjectResult{ETag: aws.String("etag")},
+						}, nil
+					}).Times(1)
+			},
+			expectedResp: &CopyObjectResponse{VersionID: "v2", ETag: "etag"},
+		},
+		{
+			name: "ReplaceMetadata",
+			req: CopyObjectRequest{
+				SourceBucket: "src-bucket",
+				SourceKey:    "src-key",
+				Bucket:       "dst-bucket",
+				Key:          "dst-key",
+				ContentType:  "image/webp",
+				Metadata:     map[string]string{"encoding": "webp"},
+			},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *s3.CopyObjectInput, _ ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+						assert.Equal(t, "src-bucket/src-key", aws.ToString(in.CopySource))
+						assert.Equal(t, types.MetadataDirectiveReplace, in.MetadataDirective)
+						assert.Equal(t, "image/webp", aws.ToString(in.ContentType))
+						assert.Equal(t, map[string]string{"encoding": "webp"}, in.Metadata)
+						return &s3.CopyObjectOutput{}, nil
+					}).Times(1)
+			},
+			expectedResp: &CopyObjectResponse{},
+		},
+		{
+			name: "NotFound",
+			req:  CopyObjectRequest{SourceBucket: "src-bucket", SourceKey: "src-key", Bucket: "dst-bucket", Key: "dst-key"},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).Return(nil, &awshttp.ResponseError{
+					ResponseError: &smithyhttp.ResponseError{
+						Response: &smithyhttp.Response{
+							Response: &http.Response{StatusCode: http.StatusNotFound},
+						},
+						Err: errors.New("not found"),
+					},
+				}).Times(1)
+			},
+			expectedError: NewItemNotFoundError("src-key"),
+		},
+		{
+			name: "Error",
+			req:  CopyObjectRequest{SourceBucket: "src-bucket", SourceKey: "src-key", Bucket: "dst-bucket", Key: "dst-key"},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).Return(nil, errors.New("copy fail")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.CopyObject: copy fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockS3ClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := &S3{svc: m}
+
+			resp, err := s.CopyObject(context.Background(), tt.req)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func TestS3_UpdateObjectMetadata(t *testing.T) {
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		metadata      map[string]string
+		mockSetup     func(t *testing.T, m *MockS3ClientAPI)
+		expectedError error
+	}{
+		{
+			name:     "Success",
+			metadata: map[string]string{"owner": "alice"},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().HeadObject(gomock.Any(), &s3.HeadObjectInput{
+						Bucket: aws.String("test-bucket"),
+						Key:    aws.String("path/to/file.txt"),
+					}).Return(&s3.HeadObjectOutput{
+						ContentType:        aws.String("text/plain"),
+						ETag:               aws.String(`"abc123"`),
+						CacheControl:       aws.String("max-age=60"),
+						ContentEncoding:    aws.String("gzip"),
+						ContentDisposition: aws.String("attachment"),
+						ContentLanguage:    aws.String("en"),
+						Expires:            &expires,
+						StorageClass:       types.StorageClassStandardIa,
+						Metadata:           map[string]string{"owner": "bob"},
+					}, nil).Times(1),
+					m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *s3.CopyObjectInput, _ ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+							assert.Equal(t, "test-bucket/path/to/file.txt", aws.ToString(in.CopySource))
+							assert.Equal(t, `"abc123"`, aws.ToString(in.CopySourceIfMatch))
+							assert.Equal(t, "test-bucket", aws.ToString(in.Bucket))
+							assert.Equal(t, "path/to/file.txt", aws.ToString(in.Key))
+							assert.Equal(t, types.MetadataDirectiveReplace, in.MetadataDirective)
+							assert.Equal(t, map[string]string{"owner": "alice"}, in.Metadata)
+							assert.Equal(t, "text/plain", aws.ToString(in.ContentType))
+							assert.Equal(t, "max-age=60", aws.ToString(in.CacheControl))
+							assert.Equal(t, "gzip", aws.ToString(in.ContentEncoding))
+							assert.Equal(t, "attachment", aws.ToString(in.ContentDisposition))
+							assert.Equal(t, "en", aws.ToString(in.ContentLanguage))
+							assert.Equal(t, &expires, in.Expires)
+							assert.Equal(t, types.StorageClassStandardIa, in.StorageClass)
+							return &s3.CopyObjectOutput{}, nil
+						}).Times(1),
+				)
+			},
+		},
+		{
+			name: "ClearMetadata",
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().HeadObject(gomock.Any(), gomock.Any()).Return(&s3.HeadObjectOutput{
+					Metadata: map[string]string{"owner": "bob"},
+				}, nil).Times(1)
+				m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *s3.CopyObjectInput, _ ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+						assert.Equal(t, types.MetadataDirectiveReplace, in.MetadataDirective)
+						assert.Empty(t, in.Metadata)
+						assert.Nil(t, in.ContentType)
+						assert.Nil(t, in.CopySourceIfMatch)
+						assert.Empty(t, in.StorageClass)
+						return &s3.CopyObjectOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name:     "NotFound",
+			metadata: map[string]string{"owner": "alice"},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().HeadObject(gomock.Any(), gomock.Any()).Return(nil, &types.NoSuchKey{}).Times(1)
+			},
+			expectedError: NewItemNotFoundError("path/to/file.txt"),
+		},
+		{
+			name:     "CopyError",
+			metadata: map[string]string{"owner": "alice"},
+			mockSetup: func(t *testing.T, m *MockS3ClientAPI) {
+				m.EXPECT().HeadObject(gomock.Any(), gomock.Any()).Return(&s3.HeadObjectOutput{}, nil).Times(1)
+				m.EXPECT().CopyObject(gomock.Any(), gomock.Any()).Return(nil, errors.New("copy fail")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.CopyObject: copy fail")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockS3ClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := &S3{svc: m}
+
+			err := s.UpdateObjectMetadata(context.Background(), "test-bucket", "path/to/file.txt", tt.metadata)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestS3_GetPresignedURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           GetPresignedUrlRequest
+		mockSetup     func(ctrl *gomock.Controller) S3PresignClientAPI
+		expectedResp  *GetPresignedUrlResponse
+		expectedError error
+	}{
+		{
+			name: "PutRequest",
+			req: GetPresignedUrlRequest{
+				Put: &UploadFileRequest{
+					Bucket: "test-bucket",
+					Key:    "test-key",
+					File:   bytes.NewReader([]byte("content")),
+				},
+				ExpirySeconds: 3600,
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3PresignClientAPI {
+				m := NewMockS3PresignClientAPI(ctrl)
+				m.EXPECT().PresignPutObject(context.Background(), &s3.PutObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("test-key"),
+					Body:   bytes.NewReader([]byte("content")),
+				},
+					gomock.Any(),
+				).Return(&v4.PresignedHTTPRequest{
+					URL: "https://test-bucket.s3.amazonaws.com/test-key?signature=xyz",
+				}, nil).Times(1)
+				return m
+			},
+			expectedResp: &GetPresignedUrlResponse{
+				PutUrl: "https://test-bucket.s3.amazonaws.com/test-key?signature=xyz",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "GetRequest",
+			req: GetPresignedUrlRequest{
+				ExpirySeconds: 3600,
+				Get: &GetFileRequest{
+					Bucket: "test-bucket",
+					Key:    "test-key",
+				},
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3PresignClientAPI {
+				m := NewMockS3PresignClientAPI(ctrl)
 				m.EXPECT().PresignGetObject(context.Background(), &s3.GetObjectInput{
 					Bucket: aws.String("test-bucket"),
 					Key:    aws.String("test-key"),
@@ -575,6 +1961,54 @@ func TestS3_GetPresignedURL(t *testing.T) {
 			expectedResp:  nil,
 			expectedError: goaws.NewInternalError(errors.New("psCli.PresignGetObject: presign fail")),
 		},
+		{
+			name: "DeleteRequest",
+			req: GetPresignedUrlRequest{
+				ExpirySeconds: 3600,
+				Delete: &DeleteFileRequest{
+					Bucket: "test-bucket",
+					Key:    "test-key",
+				},
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3PresignClientAPI {
+				m := NewMockS3PresignClientAPI(ctrl)
+				m.EXPECT().PresignDeleteObject(context.Background(), &s3.DeleteObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("test-key"),
+				},
+					gomock.Any(),
+				).Return(&v4.PresignedHTTPRequest{
+					URL: "https://test-bucket.s3.amazonaws.com/test-key?signature=del",
+				}, nil).Times(1)
+				return m
+			},
+			expectedResp: &GetPresignedUrlResponse{
+				DeleteUrl: "https://test-bucket.s3.amazonaws.com/test-key?signature=del",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "DeleteRequestError",
+			req: GetPresignedUrlRequest{
+				ExpirySeconds: 3600,
+				Delete: &DeleteFileRequest{
+					Bucket: "test-bucket",
+					Key:    "test-key",
+				},
+			},
+			mockSetup: func(ctrl *gomock.Controller) S3PresignClientAPI {
+				m := NewMockS3PresignClientAPI(ctrl)
+				m.EXPECT().PresignDeleteObject(context.Background(), &s3.DeleteObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("test-key"),
+				},
+					gomock.Any(),
+				).Return(nil, errors.New("presign fail")).Times(1)
+				return m
+			},
+			expectedResp:  nil,
+			expectedError: goaws.NewInternalError(errors.New("psCli.PresignDeleteObject: presign fail")),
+		},
 		{
 			name: "PutRequestWithChecksum",
 			req: GetPresignedUrlRequest{
@@ -630,3 +2064,104 @@ func TestS3_GetPresignedURL(t *testing.T) {
 		})
 	}
 }
+
+func TestS3_GetPresignedURL_Expiry(t *testing.T) {
+	tests := []struct {
+		name           string
+		expirySeconds  int
+		expectedExpiry time.Duration
+		expectedError  error
+	}{
+		{
+			name:           "Zero",
+			expirySeconds:  0,
+			expectedExpiry: 900 * time.Second,
+		},
+		{
+			name:           "Valid",
+			expirySeconds:  3600,
+			expectedExpiry: time.Hour,
+		},
+		{
+			name:           "Max",
+			expirySeconds:  MaxPresignExpirySeconds,
+			expectedExpiry: 7 * 24 * time.Hour,
+		},
+		{
+			name:          "OverMax",
+			expirySeconds: MaxPresignExpirySeconds + 1,
+			expectedError: NewInvalidExpiryError(MaxPresignExpirySeconds + 1),
+		},
+		{
+			name:          "Negative",
+			expirySeconds: -1,
+			expectedError: NewInvalidExpiryError(-1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var expiry time.Duration
+			m := NewMockS3PresignClientAPI(ctrl)
+			if tt.expectedError == nil {
+				m.EXPECT().PresignGetObject(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+						opts := s3.PresignOptions{}
+						for _, fn := range optFns {
+							fn(&opts)
+						}
+						expiry = opts.Expires
+						return &v4.PresignedHTTPRequest{URL: "https://test-bucket.s3.amazonaws.com/test-key"}, nil
+					}).Times(1)
+			}
+			s := &S3{presignSvc: m}
+
+			_, err := s.GetPresignedURL(context.Background(), GetPresignedUrlRequest{
+				ExpirySeconds: tt.expirySeconds,
+				Get: &GetFileRequest{
+					Bucket: "test-bucket",
+					Key:    "test-key",
+				},
+			})
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrInvalidExpiry)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedExpiry, expiry)
+			}
+		})
+	}
+}
+
+func TestS3_GetPresignedURL_ResponseOverrides(t *testing.T) {
+	t.Parallel()
+	client := s3.New(s3.Options{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+	})
+	s := &S3{presignSvc: s3.NewPresignClient(client)}
+
+	resp, err := s.GetPresignedURL(context.Background(), GetPresignedUrlRequest{
+		Get: &GetFileRequest{
+			Bucket:                     "test-bucket",
+			Key:                        "reports/test.pdf",
+			ResponseContentDisposition: `attachment; filename="test.pdf"`,
+			ResponseContentType:        "application/pdf",
+		},
+	})
+	require.NoError(t, err)
+
+	u, err := url.Parse(resp.GetUrl)
+	require.NoError(t, err)
+	assert.Equal(t, `attachment; filename="test.pdf"`, u.Query().Get("response-content-disposition"))
+	assert.Equal(t, "application/pdf", u.Query().Get("response-content-type"))
+}
@@ -7,16 +7,61 @@ import (
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 )
 
+// Sentinel errors matched by the corresponding error types via errors.Is.
+var (
+	ErrInvalidRecipient   = errors.New("invalid recipient")
+	ErrInvalidSender      = errors.New("invalid sender")
+	ErrUnverifiedDomain   = errors.New("unverified domain")
+	ErrInvalidSendRequest = errors.New("invalid send request")
+	ErrConfigSetNotFound  = errors.New("configuration set not found")
+	ErrMessageTooLarge    = errors.New("message too large")
+	ErrTooManyAttachments = errors.New("too many attachments")
+)
+
+// InvalidRecipientError is returned when an email has no recipients or one of its recipient
+// addresses is malformed. Address holds the offending address, if any.
 type InvalidRecipientError struct {
 	*goaws.ClientErr
+	Address string
 }
 
 func NewInvalidRecipientError() *InvalidRecipientError {
 	return &InvalidRecipientError{
-		goaws.NewClientError(errors.New("invalid recipient")),
+		ClientErr: goaws.NewClientError(errors.New("invalid recipient")),
+	}
+}
+
+// NewInvalidRecipientAddressError returns an InvalidRecipientError for the malformed
+// To, Cc, Bcc or Reply-To address.
+func NewInvalidRecipientAddressError(address string) *InvalidRecipientError {
+	return &InvalidRecipientError{
+		ClientErr: goaws.NewClientError(fmt.Errorf("invalid recipient: %q", address)),
+		Address:   address,
+	}
+}
+
+func (e *InvalidRecipientError) Is(target error) bool {
+	return target == ErrInvalidRecipient
+}
+
+// InvalidSenderError is returned when an email's From address is malformed.
+// Address holds the offending address.
+type InvalidSenderError struct {
+	*goaws.ClientErr
+	Address string
+}
+
+func NewInvalidSenderError(address string) *InvalidSenderError {
+	return &InvalidSenderError{
+		ClientErr: goaws.NewClientError(fmt.Errorf("invalid sender: %q", address)),
+		Address:   address,
 	}
 }
 
+func (e *InvalidSenderError) Is(target error) bool {
+	return target == ErrInvalidSender
+}
+
 type UnverifiedDomainError struct {
 	*goaws.ClientErr
 }
@@ -27,6 +72,10 @@ func NewUnverifiedDomainError(domain string) *UnverifiedDomainError {
 	}
 }
 
+func (e *UnverifiedDomainError) Is(target error) bool {
+	return target == ErrUnverifiedDomain
+}
+
 type InvalidSendRequestError struct {
 	*goaws.ClientErr
 }
@@ -36,3 +85,51 @@ func NewInvalidSendRequestError(message string) *InvalidSendRequestError {
 		goaws.NewClientError(fmt.Errorf("invalid send request: %s", message)),
 	}
 }
+
+func (e *InvalidSendRequestError) Is(target error) bool {
+	return target == ErrInvalidSendRequest
+}
+
+type ConfigSetNotFoundError struct {
+	*goaws.ClientErr
+}
+
+func NewConfigSetNotFoundError(name string) *ConfigSetNotFoundError {
+	return &ConfigSetNotFoundError{
+		goaws.NewClientError(fmt.Errorf("configuration set '%s' does not exist", name)),
+	}
+}
+
+func (e *ConfigSetNotFoundError) Is(target error) bool {
+	return target == ErrConfigSetNotFound || target == goaws.ErrNotFound
+}
+
+// MessageTooLargeError is returned when an email's content and base64 encoded
+// attachments exceed MaxMessageSize.
+type MessageTooLargeError struct {
+	*goaws.ClientErr
+}
+
+func NewMessageTooLargeError(size, max int) *MessageTooLargeError {
+	return &MessageTooLargeError{
+		goaws.NewClientError(fmt.Errorf("message size %d bytes exceeds maximum of %d bytes", size, max)),
+	}
+}
+
+func (e *MessageTooLargeError) Is(target error) bool {
+	return target == ErrMessageTooLarge
+}
+
+type TooManyAttachmentsError struct {
+	*goaws.ClientErr
+}
+
+func NewTooManyAttachmentsError(count, max int) *TooManyAttachmentsError {
+	return &TooManyAttachmentsError{
+		goaws.NewClientError(fmt.Errorf("%d attachments exceeds maximum of %d", count, max)),
+	}
+}
+
+func (e *TooManyAttachmentsError) Is(target error) bool {
+	return target == ErrTooManyAttachments
+}
@@ -0,0 +1,39 @@
+package goses
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		sentinel error
+		notFound bool
+	}{
+		{name: "invalid recipient", err: NewInvalidRecipientError(), sentinel: ErrInvalidRecipient},
+		{name: "invalid recipient address", err: NewInvalidRecipientAddressError("test"), sentinel: ErrInvalidRecipient},
+		{name: "invalid sender", err: NewInvalidSenderError("test"), sentinel: ErrInvalidSender},
+		{name: "unverified domain", err: NewUnverifiedDomainError("test"), sentinel: ErrUnverifiedDomain},
+		{name: "invalid send request", err: NewInvalidSendRequestError("test"), sentinel: ErrInvalidSendRequest},
+		{name: "config set not found", err: NewConfigSetNotFoundError("test"), sentinel: ErrConfigSetNotFound, notFound: true},
+		{name: "message too large", err: NewMessageTooLargeError(MaxMessageSize+1, MaxMessageSize), sentinel: ErrMessageTooLarge},
+		{name: "too many attachments", err: NewTooManyAttachmentsError(MaxAttachments+1, MaxAttachments), sentinel: ErrTooManyAttachments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.Equal(t, tt.notFound, errors.Is(wrapped, goaws.ErrNotFound))
+			assert.NotErrorIs(t, wrapped, errors.New(tt.sentinel.Error()))
+		})
+	}
+}
@@ -6,6 +6,7 @@ type SendEmailParams struct {
 	To          []string     `json:"to"`
 	ReplyTo     []string     `json:"reply_to,omitempty"`
 	Cc          []string     `json:"cc,omitempty"`
+	Bcc         []string     `json:"bcc,omitempty"`
 	TextBody    string       `json:"text_body"`
 	HtmlBody    string       `json:"html_body,omitempty"`
 	ConfigSet   string       `json:"config_set,omitempty"`
@@ -21,3 +22,16 @@ type Attachment struct {
 type ListVerifiedIdentitiesResponse struct {
 	EmailAddresses []string `json:"email_addresses"`
 }
+
+type SendEmailResponse struct {
+	MessageId string `json:"message_id"`
+}
+
+// EventDestination describes where SES publishes the sending events
+// of a configuration set.
+type EventDestination struct {
+	Name               string   `json:"name"`
+	Enabled            bool     `json:"enabled"`
+	MatchingEventTypes []string `json:"matching_event_types"`
+	SnsTopicArn        string   `json:"sns_topic_arn,omitempty"`
+}
@@ -4,9 +4,11 @@ package goses
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/mail"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
@@ -19,10 +21,19 @@ import (
 // CharSet repsents the charset type for email messages (UTF-8)
 const CharSet = "UTF-8"
 
+const (
+	// MaxMessageSize is the maximum size in bytes of an email sent by SES,
+	// with attachments counted at their base64 encoded size.
+	MaxMessageSize = 40 * 1024 * 1024
+	// MaxAttachments is the maximum number of attachments SendEmail accepts.
+	MaxAttachments = 100
+)
+
 //go:generate mockgen -destination=../mocks/gosesmock/ses.go -package=gosesmock . SESLogic
 type SESLogic interface {
 	ListVerifiedIdentities(ctx context.Context) (*ListVerifiedIdentitiesResponse, error)
-	SendEmail(ctx context.Context, params SendEmailParams) error
+	SendEmail(ctx context.Context, params SendEmailParams) (*SendEmailResponse, error)
+	GetConfigurationSetEventDestinations(ctx context.Context, configSet string) ([]EventDestination, error)
 }
 
 // SESClientAPI defines the interface for the AWS SES client methods used by this package.
@@ -31,6 +42,7 @@ type SESLogic interface {
 type SESClientAPI interface {
 	ListEmailIdentities(ctx context.Context, params *sesv2.ListEmailIdentitiesInput, optFns ...func(*sesv2.Options)) (*sesv2.ListEmailIdentitiesOutput, error)
 	SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+	GetConfigurationSetEventDestinations(ctx context.Context, params *sesv2.GetConfigurationSetEventDestinationsInput, optFns ...func(*sesv2.Options)) (*sesv2.GetConfigurationSetEventDestinationsOutput, error)
 }
 
 type SES struct {
@@ -60,10 +72,24 @@ func (s *SES) ListVerifiedIdentities(ctx context.Context) (*ListVerifiedIdentiti
 	return &ListVerifiedIdentitiesResponse{EmailAddresses: verifiedIds}, nil
 }
 
-// SendEmail sends a new email message. To and CC addresses are passed as []string, all other fields as strings.
-func (s *SES) SendEmail(ctx context.Context, params SendEmailParams) error {
+// SendEmail sends a new email message and returns the SES message ID. To, CC, BCC and
+// Reply-To addresses are passed as []string, all other fields as strings.
+// All addresses are validated before calling SES; an InvalidSenderError or
+// InvalidRecipientError holding the first malformed address is returned without sending. A TooManyAttachmentsError or
+// MessageTooLargeError is returned without sending if the attachments exceed MaxAttachments
+// or the message exceeds MaxMessageSize.
+func (s *SES) SendEmail(ctx context.Context, params SendEmailParams) (*SendEmailResponse, error) {
 	if len(params.To) == 0 {
-		return NewInvalidRecipientError()
+		return nil, NewInvalidRecipientError()
+	}
+	if err := validateAddresses(params); err != nil {
+		return nil, err
+	}
+	if len(params.Attachments) > MaxAttachments {
+		return nil, NewTooManyAttachmentsError(len(params.Attachments), MaxAttachments)
+	}
+	if size := messageSize(params); size > MaxMessageSize {
+		return nil, NewMessageTooLargeError(size, MaxMessageSize)
 	}
 
 	// Assemble the email.
@@ -91,8 +117,9 @@ func (s *SES) SendEmail(ctx context.Context, params SendEmailParams) error {
 
 	input := &sesv2.SendEmailInput{
 		Destination: &types.Destination{
-			CcAddresses: params.Cc,
-			ToAddresses: params.To,
+			CcAddresses:  params.Cc,
+			BccAddresses: params.Bcc,
+			ToAddresses:  params.To,
 		},
 		Content: &types.EmailContent{
 			Simple: &types.Message{
@@ -116,7 +143,8 @@ func (s *SES) SendEmail(ctx context.Context, params SendEmailParams) error {
 	}
 
 	// Attempt to send the email.
-	if _, err := s.svc.SendEmail(ctx, input); err != nil {
+	result, err := s.svc.SendEmail(ctx, input)
+	if err != nil {
 		var re *awshttp.ResponseError
 		var msgReject *types.MessageRejected
 		var domainNotVerified *types.MailFromDomainNotVerifiedException
@@ -127,23 +155,90 @@ func (s *SES) SendEmail(ctx context.Context, params SendEmailParams) error {
 			if msgReject.Message != nil {
 				msg = *msgReject.Message
 			}
-			return goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %s", msg))
+			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %s", msg))
 		case errors.As(err, &domainNotVerified):
-			return NewUnverifiedDomainError(*domainNotVerified.Message)
+			return nil, NewUnverifiedDomainError(*domainNotVerified.Message)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %w", err))
 			}
 			switch re.HTTPStatusCode() {
 			case http.StatusBadRequest:
-				return NewInvalidSendRequestError(re.ResponseError.Error())
+				return nil, NewInvalidSendRequestError(re.ResponseError.Error())
 			default:
-				return goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %w", err))
 			}
 		default:
-			return goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %w", err))
+			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.SendEmail: %w", err))
 		}
 	}
 
+	var messageId string
+	if result.MessageId != nil {
+		messageId = *result.MessageId
+	}
+
+	return &SendEmailResponse{MessageId: messageId}, nil
+}
+
+// messageSize returns the approximate size SES counts against MaxMessageSize:
+// the subject and bodies plus each attachment's base64 encoded content.
+func messageSize(params SendEmailParams) int {
+	size := len(params.Subject) + len(params.TextBody) + len(params.HtmlBody)
+	for _, attachment := range params.Attachments {
+		size += base64.StdEncoding.EncodedLen(len(attachment.Data))
+	}
+	return size
+}
+
+// validateAddresses checks that each address in params is a valid RFC 5322 address,
+// e.g. "jane@example.com" or "Jane Doe <jane@example.com>".
+func validateAddresses(params SendEmailParams) error {
+	if _, err := mail.ParseAddress(params.From); err != nil {
+		return NewInvalidSenderError(params.From)
+	}
+	var addresses []string
+	for _, list := range [][]string{params.To, params.Cc, params.Bcc, params.ReplyTo} {
+		addresses = append(addresses, list...)
+	}
+	for _, address := range addresses {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return NewInvalidRecipientAddressError(address)
+		}
+	}
 	return nil
 }
+
+// GetConfigurationSetEventDestinations lists the event destinations of the given
+// configuration set. Use it to verify that bounce and complaint events are
+// published (e.g. to SNS) before sending.
+func (s *SES) GetConfigurationSetEventDestinations(ctx context.Context, configSet string) ([]EventDestination, error) {
+	result, err := s.svc.GetConfigurationSetEventDestinations(ctx, &sesv2.GetConfigurationSetEventDestinationsInput{
+		ConfigurationSetName: aws.String(configSet),
+	})
+	if err != nil {
+		var notFound *types.NotFoundException
+		if errors.As(err, &notFound) {
+			return nil, NewConfigSetNotFoundError(configSet)
+		}
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.GetConfigurationSetEventDestinations: %w", err))
+	}
+
+	destinations := make([]EventDestination, 0, len(result.EventDestinations))
+	for _, d := range result.EventDestinations {
+		dest := EventDestination{
+			Name:               aws.ToString(d.Name),
+			Enabled:            d.Enabled,
+			MatchingEventTypes: make([]string, 0, len(d.MatchingEventTypes)),
+		}
+		for _, et := range d.MatchingEventTypes {
+			dest.MatchingEventTypes = append(dest.MatchingEventTypes, string(et))
+		}
+		if d.SnsDestination != nil {
+			dest.SnsTopicArn = aws.ToString(d.SnsDestination.TopicArn)
+		}
+		destinations = append(destinations, dest)
+	}
+
+	return destinations, nil
+}
@@ -41,6 +41,26 @@ func (m *MockSESClientAPI) EXPECT() *MockSESClientAPIMockRecorder {
 	return m.recorder
 }
 
+// GetConfigurationSetEventDestinations mocks base method.
+func (m *MockSESClientAPI) GetConfigurationSetEventDestinations(ctx context.Context, params *sesv2.GetConfigurationSetEventDestinationsInput, optFns ...func(*sesv2.Options)) (*sesv2.GetConfigurationSetEventDestinationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetConfigurationSetEventDestinations", varargs...)
+	ret0, _ := ret[0].(*sesv2.GetConfigurationSetEventDestinationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigurationSetEventDestinations indicates an expected call of GetConfigurationSetEventDestinations.
+func (mr *MockSESClientAPIMockRecorder) GetConfigurationSetEventDestinations(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigurationSetEventDestinations", reflect.TypeOf((*MockSESClientAPI)(nil).GetConfigurationSetEventDestinations), varargs...)
+}
+
 // ListEmailIdentities mocks base method.
 func (m *MockSESClientAPI) ListEmailIdentities(ctx context.Context, params *sesv2.ListEmailIdentitiesInput, optFns ...func(*sesv2.Options)) (*sesv2.ListEmailIdentitiesOutput, error) {
 	m.ctrl.T.Helper()
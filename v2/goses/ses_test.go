@@ -104,10 +104,11 @@ func TestSES_ListEmailIdentities(t *testing.T) {
 
 func TestSES_SendEmail(t *testing.T) {
 	tests := []struct {
-		name          string
-		params        SendEmailParams
-		mockSetup     func(ctrl *gomock.Controller) SESClientAPI
-		expectedError error
+		name              string
+		params            SendEmailParams
+		mockSetup         func(ctrl *gomock.Controller) SESClientAPI
+		expectedMessageId string
+		expectedError     error
 	}{
 		{
 			name: "Success",
@@ -122,10 +123,11 @@ func TestSES_SendEmail(t *testing.T) {
 			},
 			mockSetup: func(ctrl *gomock.Controller) SESClientAPI {
 				mockSvc := NewMockSESClientAPI(ctrl)
-				mockSvc.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Return(&sesv2.SendEmailOutput{}, nil).Times(1)
+				mockSvc.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Return(&sesv2.SendEmailOutput{MessageId: aws.String("test-message-id")}, nil).Times(1)
 				return mockSvc
 			},
-			expectedError: nil,
+			expectedMessageId: "test-message-id",
+			expectedError:     nil,
 		},
 		{
 			name: "Success - with attachments",
@@ -243,7 +245,228 @@ func TestSES_SendEmail(t *testing.T) {
 			mockSvc := tt.mockSetup(ctrl)
 			s := &SES{svc: mockSvc}
 
-			err := s.SendEmail(context.Background(), tt.params)
+			res, err := s.SendEmail(context.Background(), tt.params)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedMessageId, res.MessageId)
+			}
+		})
+	}
+}
+
+func TestSES_SendEmail_ValidateAddresses(t *testing.T) {
+	valid := SendEmailParams{
+		Subject:  "test",
+		From:     "Sender <sender@example.com>",
+		To:       []string{"recipient@example.com"},
+		Cc:       []string{"cc@example.com"},
+		Bcc:      []string{"bcc@example.com"},
+		ReplyTo:  []string{"reply+tag@sub.example.com"},
+		TextBody: "test",
+	}
+
+	tests := []struct {
+		name    string
+		params  func(p *SendEmailParams)
+		address string
+	}{
+		{name: "to", params: func(p *SendEmailParams) { p.To = append(p.To, "recipient@") }, address: "recipient@"},
+		{name: "cc", params: func(p *SendEmailParams) { p.Cc = []string{"cc@example.com, other@example.com"} }, address: "cc@example.com, other@example.com"},
+		{name: "bcc", params: func(p *SendEmailParams) { p.Bcc = []string{"not an address"} }, address: "not an address"},
+		{name: "reply to", params: func(p *SendEmailParams) { p.ReplyTo = []string{"<reply@example.com"} }, address: "<reply@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &SES{svc: NewMockSESClientAPI(ctrl)}
+			params := valid
+			params.To = append([]string{}, valid.To...)
+			tt.params(&params)
+
+			_, err := s.SendEmail(context.Background(), params)
+
+			require.Error(t, err)
+			assert.EqualError(t, err, NewInvalidRecipientAddressError(tt.address).Error())
+			assert.ErrorIs(t, err, ErrInvalidRecipient)
+			var invalid *InvalidRecipientError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, tt.address, invalid.Address)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := NewMockSESClientAPI(ctrl)
+		m.EXPECT().SendEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *sesv2.SendEmailInput, _ ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+				assert.Equal(t, valid.Bcc, in.Destination.BccAddresses)
+				return &sesv2.SendEmailOutput{MessageId: aws.String("test-message-id")}, nil
+			}).Times(1)
+		s := &SES{svc: m}
+
+		_, err := s.SendEmail(context.Background(), valid)
+		require.NoError(t, err)
+	})
+}
+
+func TestSES_SendEmail_AttachmentLimits(t *testing.T) {
+	base := SendEmailParams{
+		Subject:  "test",
+		From:     "sender@example.com",
+		To:       []string{"recipient@example.com"},
+		TextBody: "test",
+	}
+	// subject and text body take 8 bytes; 3 raw bytes encode to 4 base64 bytes
+	atLimit := (MaxMessageSize - 8) / 4 * 3
+
+	tests := []struct {
+		name          string
+		attachments   []Attachment
+		expectSend    bool
+		expectedError error
+		sentinel      error
+	}{
+		{
+			name:        "AtSizeLimit",
+			attachments: []Attachment{{FileName: "a.bin", Data: make([]byte, atLimit)}},
+			expectSend:  true,
+		},
+		{
+			name:          "OverSizeLimit",
+			attachments:   []Attachment{{FileName: "a.bin", Data: make([]byte, atLimit+1)}},
+			expectedError: NewMessageTooLargeError(MaxMessageSize+4, MaxMessageSize),
+			sentinel:      ErrMessageTooLarge,
+		},
+		{
+			name: "OverSizeLimitCombined",
+			attachments: []Attachment{
+				{FileName: "a.bin", Data: make([]byte, atLimit/2)},
+				{FileName: "b.bin", Data: make([]byte, atLimit/2+3)},
+			},
+			expectedError: NewMessageTooLargeError(MaxMessageSize+4, MaxMessageSize),
+			sentinel:      ErrMessageTooLarge,
+		},
+		{
+			name:        "AtCountLimit",
+			attachments: make([]Attachment, MaxAttachments),
+			expectSend:  true,
+		},
+		{
+			name:          "OverCountLimit",
+			attachments:   make([]Attachment, MaxAttachments+1),
+			expectedError: NewTooManyAttachmentsError(MaxAttachments+1, MaxAttachments),
+			sentinel:      ErrTooManyAttachments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSESClientAPI(ctrl)
+			if tt.expectSend {
+				m.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Return(&sesv2.SendEmailOutput{MessageId: aws.String("test-message-id")}, nil).Times(1)
+			}
+			s := &SES{svc: m}
+			params := base
+			params.Attachments = tt.attachments
+
+			_, err := s.SendEmail(context.Background(), params)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, tt.sentinel)
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+func TestSES_GetConfigurationSetEventDestinations(t *testing.T) {
+	tests := []struct {
+		name                 string
+		mockSetup            func(t *testing.T, m *MockSESClientAPI)
+		expectedDestinations []EventDestination
+		expectedError        error
+	}{
+		{
+			name: "success",
+			mockSetup: func(t *testing.T, m *MockSESClientAPI) {
+				m.EXPECT().GetConfigurationSetEventDestinations(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *sesv2.GetConfigurationSetEventDestinationsInput, _ ...func(*sesv2.Options)) (*sesv2.GetConfigurationSetEventDestinationsOutput, error) {
+						assert.Equal(t, "test-config", aws.ToString(in.ConfigurationSetName))
+						return &sesv2.GetConfigurationSetEventDestinationsOutput{
+							EventDestinations: []types.EventDestination{
+								{
+									Name:               aws.String("bounces"),
+									Enabled:            true,
+									MatchingEventTypes: []types.EventType{types.EventTypeBounce, types.EventTypeComplaint},
+									SnsDestination:     &types.SnsDestination{TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:bounces")},
+								},
+								{
+									Name:               aws.String("opens"),
+									MatchingEventTypes: []types.EventType{types.EventTypeOpen},
+								},
+							},
+						}, nil
+					}).Times(1)
+			},
+			expectedDestinations: []EventDestination{
+				{
+					Name:               "bounces",
+					Enabled:            true,
+					MatchingEventTypes: []string{"BOUNCE", "COMPLAINT"},
+					SnsTopicArn:        "arn:aws:sns:us-east-1:123456789012:bounces",
+				},
+				{
+					Name:               "opens",
+					MatchingEventTypes: []string{"OPEN"},
+				},
+			},
+		},
+		{
+			name: "error - config set not found",
+			mockSetup: func(t *testing.T, m *MockSESClientAPI) {
+				m.EXPECT().GetConfigurationSetEventDestinations(gomock.Any(), gomock.Any()).Return(nil, &types.NotFoundException{Message: aws.String("not found")}).Times(1)
+			},
+			expectedError: NewConfigSetNotFoundError("test-config"),
+		},
+		{
+			name: "error - internal",
+			mockSetup: func(t *testing.T, m *MockSESClientAPI) {
+				m.EXPECT().GetConfigurationSetEventDestinations(gomock.Any(), gomock.Any()).Return(nil, errors.New("request failed")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.GetConfigurationSetEventDestinations: request failed")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockSESClientAPI(ctrl)
+			tt.mockSetup(t, mockSvc)
+			s := &SES{svc: mockSvc}
+
+			res, err := s.GetConfigurationSetEventDestinations(context.Background(), "test-config")
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -251,6 +474,7 @@ func TestSES_SendEmail(t *testing.T) {
 				assert.Implements(t, (*goaws.AwsError)(nil), err)
 			} else {
 				require.NoError(t, err)
+				assert.Equal(t, tt.expectedDestinations, res)
 			}
 		})
 	}
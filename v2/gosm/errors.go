@@ -1,11 +1,19 @@
 package gosm
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 )
 
+// Sentinel errors matched by the corresponding error types via errors.Is.
+var (
+	ErrSecretNotFound      = errors.New("secret not found")
+	ErrSecretPermissions   = errors.New("secret permissions error")
+	ErrMissingResponseData = errors.New("missing response data")
+)
+
 type SecretNotFoundError struct {
 	*goaws.ClientErr
 }
@@ -16,6 +24,10 @@ func NewSecretNotFoundError(key string) *SecretNotFoundError {
 	}
 }
 
+func (e *SecretNotFoundError) Is(target error) bool {
+	return target == ErrSecretNotFound || target == goaws.ErrNotFound
+}
+
 type SecretPermissionsError struct {
 	*goaws.ClientErr
 }
@@ -26,6 +38,10 @@ func NewSecretPermissionsError(key string) *SecretPermissionsError {
 	}
 }
 
+func (e *SecretPermissionsError) Is(target error) bool {
+	return target == ErrSecretPermissions
+}
+
 type MissingResponseDataError struct {
 	*goaws.RetryableInternalError
 }
@@ -35,3 +51,7 @@ func NewMissingResponseDataError(property string) *MissingResponseDataError {
 		goaws.NewRetryableInternalError(fmt.Errorf("missing %s in response", property)),
 	}
 }
+
+func (e *MissingResponseDataError) Is(target error) bool {
+	return target == ErrMissingResponseData
+}
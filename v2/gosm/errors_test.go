@@ -0,0 +1,34 @@
+package gosm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		sentinel error
+		notFound bool
+	}{
+		{name: "secret not found", err: NewSecretNotFoundError("test"), sentinel: ErrSecretNotFound, notFound: true},
+		{name: "secret permissions", err: NewSecretPermissionsError("test"), sentinel: ErrSecretPermissions},
+		{name: "missing response data", err: NewMissingResponseDataError("ARN"), sentinel: ErrMissingResponseData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.Equal(t, tt.notFound, errors.Is(wrapped, goaws.ErrNotFound))
+			assert.NotErrorIs(t, wrapped, errors.New(tt.sentinel.Error()))
+		})
+	}
+}
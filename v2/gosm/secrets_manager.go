@@ -56,7 +56,7 @@ func (s *SecretsManager) GetSecret(ctx context.Context, key string) (*GetSecretR
 			return nil, NewSecretPermissionsError(key)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return nil, fmt.Errorf("s.svc.GetSecretValue: %w", re.Err)
+				return nil, fmt.Errorf("s.svc.GetSecretValue: %w", err)
 			}
 			switch re.HTTPStatusCode() {
 			case http.StatusUnauthorized,
@@ -65,7 +65,7 @@ func (s *SecretsManager) GetSecret(ctx context.Context, key string) (*GetSecretR
 			case http.StatusNotFound:
 				return nil, NewSecretNotFoundError(key)
 			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetSecretValue: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetSecretValue: %w", err))
 			}
 		default:
 			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetSecretValue: %w", err))
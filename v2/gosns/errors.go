@@ -1,11 +1,18 @@
 package gosns
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 )
 
+// Sentinel errors matched by the corresponding error types via errors.Is.
+var (
+	ErrInvalidProtocol = errors.New("invalid protocol")
+	ErrMessageTooLarge = errors.New("message too large")
+)
+
 type InvalidProtocolError struct {
 	*goaws.ClientErr
 }
@@ -13,3 +20,21 @@ type InvalidProtocolError struct {
 func NewInvalidProtocolError(protocol string) error {
 	return &InvalidProtocolError{goaws.NewClientError(fmt.Errorf("invalid protocol: %s", protocol))}
 }
+
+func (e *InvalidProtocolError) Is(target error) bool {
+	return target == ErrInvalidProtocol
+}
+
+type MessageTooLargeError struct {
+	*goaws.ClientErr
+}
+
+func NewMessageTooLargeError(size, max int) *MessageTooLargeError {
+	return &MessageTooLargeError{
+		goaws.NewClientError(fmt.Errorf("message size %d bytes exceeds maximum of %d bytes; store large payloads in S3 and publish a reference", size, max)),
+	}
+}
+
+func (e *MessageTooLargeError) Is(target error) bool {
+	return target == ErrMessageTooLarge
+}
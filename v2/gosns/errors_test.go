@@ -0,0 +1,32 @@
+package gosns
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		sentinel error
+	}{
+		{name: "invalid protocol", err: NewInvalidProtocolError("test"), sentinel: ErrInvalidProtocol},
+		{name: "message too large", err: NewMessageTooLargeError(MaxMessageSize+1, MaxMessageSize), sentinel: ErrMessageTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.False(t, errors.Is(wrapped, goaws.ErrNotFound))
+			assert.NotErrorIs(t, wrapped, errors.New(tt.sentinel.Error()))
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package gosns
 
+// MaxMessageSize is the maximum size in bytes of a published message,
+// including its message attributes.
+const MaxMessageSize = 262144
+
 type ListTopicsResponse struct {
 	TopicArns []string
 }
@@ -8,10 +12,24 @@ type CreateTopicResponse struct {
 	TopicArn string
 }
 
+// AttributeRawMessageDelivery is the subscription attribute enabling raw message delivery.
+const AttributeRawMessageDelivery = "RawMessageDelivery"
+
+// SubscribeOptions contains optional subscription settings.
+// RawMessageDelivery delivers messages without the SNS JSON envelope
+// and is supported by the sqs, http(s) and firehose protocols.
+type SubscribeOptions struct {
+	RawMessageDelivery bool
+}
+
 type SubscribeResponse struct {
 	SubscriptionArn string
 }
 
+type ConfirmSubscriptionResponse struct {
+	SubscriptionArn string
+}
+
 type PublishResponse struct {
 	MessageId string
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 
 	"fmt"
@@ -15,8 +16,10 @@ import (
 type SNSLogic interface {
 	ListTopics(ctx context.Context) (*ListTopicsResponse, error)
 	CreateTopic(ctx context.Context, name string) (*CreateTopicResponse, error)
-	Subscribe(ectx context.Context, ndpoint, protocol, topicArn string) (*SubscribeResponse, error)
+	Subscribe(ctx context.Context, endpoint, protocol, topicArn string, options SubscribeOptions) (*SubscribeResponse, error)
+	ConfirmSubscription(ctx context.Context, topicArn, token string) (*ConfirmSubscriptionResponse, error)
 	Publish(ctx context.Context, msgStr, topicArn string) (*PublishResponse, error)
+	PublishWithAttributes(ctx context.Context, msgStr, topicArn string, attributes map[string]types.MessageAttributeValue) (*PublishResponse, error)
 }
 
 // SNSClientAPI defines the interface for the AWS SNS client methods used by this package.
@@ -26,6 +29,7 @@ type SNSClientAPI interface {
 	ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error)
 	CreateTopic(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error)
 	Subscribe(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error)
+	ConfirmSubscription(ctx context.Context, params *sns.ConfirmSubscriptionInput, optFns ...func(*sns.Options)) (*sns.ConfirmSubscriptionOutput, error)
 	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
 }
 
@@ -38,6 +42,7 @@ func NewSNS(config goaws.AwsConfig) *SNS {
 		svc: sns.New(sns.Options{
 			Credentials: config.Config.Credentials,
 			Region:      config.Config.Region,
+			HTTPClient:  config.Config.HTTPClient,
 		}),
 	}
 }
@@ -49,7 +54,7 @@ func (s *SNS) ListTopics(ctx context.Context) (*ListTopicsResponse, error) {
 	// get topics
 	result, err := s.svc.ListTopics(ctx, &sns.ListTopicsInput{})
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.ListTopics: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.ListTopics: %w", err))
 	}
 
 	// print topic ARNs
@@ -66,7 +71,7 @@ func (s *SNS) CreateTopic(ctx context.Context, name string) (*CreateTopicRespons
 		Name: aws.String(name),
 	})
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.CreateTopic: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.CreateTopic: %w", err))
 	}
 
 	var topicArn string
@@ -77,8 +82,8 @@ func (s *SNS) CreateTopic(ctx context.Context, name string) (*CreateTopicRespons
 	return &CreateTopicResponse{TopicArn: topicArn}, nil
 }
 
-// Subscribe creates a new subscription for an endpoint.
-func (s *SNS) Subscribe(ctx context.Context, endpoint, protocol, topicArn string) (*SubscribeResponse, error) {
+// Subscribe creates a new subscription for an endpoint with the given options.
+func (s *SNS) Subscribe(ctx context.Context, endpoint, protocol, topicArn string, options SubscribeOptions) (*SubscribeResponse, error) {
 	validProtocols := map[string]bool{
 		"http":        true,
 		"https":       true,
@@ -94,14 +99,21 @@ func (s *SNS) Subscribe(ctx context.Context, endpoint, protocol, topicArn string
 		return nil, NewInvalidProtocolError(protocol)
 	}
 
-	result, err := s.svc.Subscribe(ctx, &sns.SubscribeInput{
+	input := &sns.SubscribeInput{
 		Endpoint:              aws.String(endpoint),
 		Protocol:              aws.String(protocol),
 		ReturnSubscriptionArn: true, // Return the ARN, even if user has yet to confirm
 		TopicArn:              aws.String(topicArn),
-	})
+	}
+	if options.RawMessageDelivery {
+		input.Attributes = map[string]string{
+			AttributeRawMessageDelivery: "true",
+		}
+	}
+
+	result, err := s.svc.Subscribe(ctx, input)
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.Subscribe: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.Subscribe: %w", err))
 	}
 
 	var subscriptionArn string
@@ -112,15 +124,48 @@ func (s *SNS) Subscribe(ctx context.Context, endpoint, protocol, topicArn string
 	return &SubscribeResponse{SubscriptionArn: subscriptionArn}, nil
 }
 
+// ConfirmSubscription confirms a pending http(s) subscription to the given topic with the
+// token sent to the endpoint in the SubscriptionConfirmation message, and returns the ARN
+// of the confirmed subscription.
+func (s *SNS) ConfirmSubscription(ctx context.Context, topicArn, token string) (*ConfirmSubscriptionResponse, error) {
+	result, err := s.svc.ConfirmSubscription(ctx, &sns.ConfirmSubscriptionInput{
+		TopicArn: aws.String(topicArn),
+		Token:    aws.String(token),
+	})
+	if err != nil {
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.ConfirmSubscription: %w", err))
+	}
+
+	var subscriptionArn string
+	if result.SubscriptionArn != nil {
+		subscriptionArn = *result.SubscriptionArn
+	}
+
+	return &ConfirmSubscriptionResponse{SubscriptionArn: subscriptionArn}, nil
+}
+
 // Publish publishes a new message to a Topic and returns the message ID
-// of the published message.
+// of the published message. A MessageTooLargeError is returned without
+// publishing if the message exceeds MaxMessageSize.
 func (s *SNS) Publish(ctx context.Context, msgStr, topicArn string) (*PublishResponse, error) {
+	return s.PublishWithAttributes(ctx, msgStr, topicArn, nil)
+}
+
+// PublishWithAttributes publishes a new message with the given message attributes
+// to a Topic and returns the message ID of the published message. A MessageTooLargeError
+// is returned without publishing if the message and attributes exceed MaxMessageSize.
+func (s *SNS) PublishWithAttributes(ctx context.Context, msgStr, topicArn string, attributes map[string]types.MessageAttributeValue) (*PublishResponse, error) {
+	if size := messageSize(msgStr, attributes); size > MaxMessageSize {
+		return nil, NewMessageTooLargeError(size, MaxMessageSize)
+	}
+
 	result, err := s.svc.Publish(ctx, &sns.PublishInput{
-		Message:  aws.String(msgStr),
-		TopicArn: aws.String(topicArn),
+		Message:           aws.String(msgStr),
+		MessageAttributes: attributes,
+		TopicArn:          aws.String(topicArn),
 	})
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.Publish: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.Publish: %w", err))
 	}
 
 	var messageId string
@@ -130,3 +175,13 @@ func (s *SNS) Publish(ctx context.Context, msgStr, topicArn string) (*PublishRes
 
 	return &PublishResponse{MessageId: messageId}, nil
 }
+
+// messageSize returns the size SNS counts against MaxMessageSize:
+// the message plus each attribute's name, data type and value.
+func messageSize(msg string, attributes map[string]types.MessageAttributeValue) int {
+	size := len(msg)
+	for name, av := range attributes {
+		size += len(name) + len(aws.ToString(av.DataType)) + len(aws.ToString(av.StringValue)) + len(av.BinaryValue)
+	}
+	return size
+}
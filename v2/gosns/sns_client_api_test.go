@@ -41,6 +41,26 @@ func (m *MockSNSClientAPI) EXPECT() *MockSNSClientAPIMockRecorder {
 	return m.recorder
 }
 
+// ConfirmSubscription mocks base method.
+func (m *MockSNSClientAPI) ConfirmSubscription(ctx context.Context, params *sns.ConfirmSubscriptionInput, optFns ...func(*sns.Options)) (*sns.ConfirmSubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmSubscription", varargs...)
+	ret0, _ := ret[0].(*sns.ConfirmSubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmSubscription indicates an expected call of ConfirmSubscription.
+func (mr *MockSNSClientAPIMockRecorder) ConfirmSubscription(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmSubscription", reflect.TypeOf((*MockSNSClientAPI)(nil).ConfirmSubscription), varargs...)
+}
+
 // CreateTopic mocks base method.
 func (m *MockSNSClientAPI) CreateTopic(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error) {
 	m.ctrl.T.Helper()
@@ -3,11 +3,16 @@ package gosns
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -161,6 +166,7 @@ func TestSNS_Subscribe(t *testing.T) {
 		endpoint      string
 		protocol      string
 		topicArn      string
+		options       SubscribeOptions
 		mockSetup     func(*gomock.Controller) SNSClientAPI
 		expectedArn   string
 		expectedError error
@@ -180,6 +186,27 @@ func TestSNS_Subscribe(t *testing.T) {
 			expectedArn:   "arn:aws:sns:us-east-1:123456789012:MyTopic:subscription-id",
 			expectedError: nil,
 		},
+		{
+			name:     "RawMessageDelivery",
+			endpoint: "arn:aws:sqs:us-east-1:123456789012:MyQueue",
+			protocol: "sqs",
+			topicArn: "arn:aws:sns:us-east-1:123456789012:MyTopic",
+			options:  SubscribeOptions{RawMessageDelivery: true},
+			mockSetup: func(ctrl *gomock.Controller) SNSClientAPI {
+				m := NewMockSNSClientAPI(ctrl)
+				m.EXPECT().Subscribe(gomock.Any(), &sns.SubscribeInput{
+					Endpoint:              aws.String("arn:aws:sqs:us-east-1:123456789012:MyQueue"),
+					Protocol:              aws.String("sqs"),
+					ReturnSubscriptionArn: true,
+					TopicArn:              aws.String("arn:aws:sns:us-east-1:123456789012:MyTopic"),
+					Attributes:            map[string]string{"RawMessageDelivery": "true"},
+				}).Return(&sns.SubscribeOutput{
+					SubscriptionArn: aws.String("arn:aws:sns:us-east-1:123456789012:MyTopic:subscription-id"),
+				}, nil).Times(1)
+				return m
+			},
+			expectedArn: "arn:aws:sns:us-east-1:123456789012:MyTopic:subscription-id",
+		},
 		{
 			name:     "InvalidProtocol",
 			endpoint: "test",
@@ -215,7 +242,7 @@ func TestSNS_Subscribe(t *testing.T) {
 			mockSvc := tt.mockSetup(ctrl)
 			s := &SNS{svc: mockSvc}
 
-			arn, err := s.Subscribe(context.Background(), tt.endpoint, tt.protocol, tt.topicArn)
+			arn, err := s.Subscribe(context.Background(), tt.endpoint, tt.protocol, tt.topicArn, tt.options)
 			if tt.expectedError != nil {
 				require.Error(t, err)
 				assert.EqualError(t, err, tt.expectedError.Error())
@@ -228,6 +255,64 @@ func TestSNS_Subscribe(t *testing.T) {
 	}
 }
 
+func TestSNS_ConfirmSubscription(t *testing.T) {
+	topicArn := "arn:aws:sns:us-east-1:123456789012:MyTopic"
+
+	tests := []struct {
+		name          string
+		token         string
+		mockSetup     func(*gomock.Controller) SNSClientAPI
+		expectedArn   string
+		expectedError error
+	}{
+		{
+			name:  "Success",
+			token: "confirmation-token",
+			mockSetup: func(ctrl *gomock.Controller) SNSClientAPI {
+				m := NewMockSNSClientAPI(ctrl)
+				m.EXPECT().ConfirmSubscription(gomock.Any(), &sns.ConfirmSubscriptionInput{
+					TopicArn: aws.String(topicArn),
+					Token:    aws.String("confirmation-token"),
+				}).Return(&sns.ConfirmSubscriptionOutput{
+					SubscriptionArn: aws.String("arn:aws:sns:us-east-1:123456789012:MyTopic:subscription-id"),
+				}, nil).Times(1)
+				return m
+			},
+			expectedArn: "arn:aws:sns:us-east-1:123456789012:MyTopic:subscription-id",
+		},
+		{
+			name:  "Error",
+			token: "expired-token",
+			mockSetup: func(ctrl *gomock.Controller) SNSClientAPI {
+				m := NewMockSNSClientAPI(ctrl)
+				m.EXPECT().ConfirmSubscription(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid token")).Times(1)
+				return m
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.ConfirmSubscription: invalid token")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &SNS{svc: tt.mockSetup(ctrl)}
+
+			resp, err := s.ConfirmSubscription(context.Background(), topicArn, tt.token)
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedArn, resp.SubscriptionArn)
+			}
+		})
+	}
+}
+
 func TestSNS_Publish(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -287,3 +372,92 @@ func TestSNS_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestSNS_PublishWithAttributes_MessageSize(t *testing.T) {
+	attributes := map[string]types.MessageAttributeValue{
+		"event": {DataType: aws.String("String"), StringValue: aws.String("created")},
+	}
+	// "event" + "String" + "created"
+	const attributesSize = 18
+
+	tests := []struct {
+		name          string
+		msgStr        string
+		attributes    map[string]types.MessageAttributeValue
+		expectPublish bool
+		expectedError error
+	}{
+		{name: "AtLimit", msgStr: strings.Repeat("a", MaxMessageSize), expectPublish: true},
+		{name: "OverLimit", msgStr: strings.Repeat("a", MaxMessageSize+1), expectedError: NewMessageTooLargeError(MaxMessageSize+1, MaxMessageSize)},
+		{name: "AttributesAtLimit", msgStr: strings.Repeat("a", MaxMessageSize-attributesSize), attributes: attributes, expectPublish: true},
+		{name: "AttributesOverLimit", msgStr: strings.Repeat("a", MaxMessageSize-attributesSize+1), attributes: attributes, expectedError: NewMessageTooLargeError(MaxMessageSize+1, MaxMessageSize)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSNSClientAPI(ctrl)
+			if tt.expectPublish {
+				m.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *sns.PublishInput, _ ...func(*sns.Options)) (*sns.PublishOutput, error) {
+						assert.Equal(t, tt.attributes, in.MessageAttributes)
+						return &sns.PublishOutput{MessageId: aws.String("msg-id-123")}, nil
+					}).Times(1)
+			}
+			s := &SNS{svc: m}
+
+			res, err := s.PublishWithAttributes(context.Background(), tt.msgStr, "arn:aws:sns:us-east-1:123456789012:MyTopic", tt.attributes)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrMessageTooLarge)
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "msg-id-123", res.MessageId)
+			}
+		})
+	}
+}
+
+func TestSNS_AccessDenied(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockErr error
+	}{
+		{name: "AccessDenied", mockErr: &smithy.GenericAPIError{Code: "AccessDenied"}},
+		{name: "AccessDeniedException", mockErr: &smithy.GenericAPIError{Code: "AccessDeniedException"}},
+		{
+			name: "StatusForbidden",
+			mockErr: &awshttp.ResponseError{
+				ResponseError: &smithyhttp.ResponseError{
+					Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusForbidden}},
+					Err:      errors.New("forbidden"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSNSClientAPI(ctrl)
+			m.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, tt.mockErr).Times(1)
+			s := &SNS{svc: m}
+
+			_, err := s.Publish(context.Background(), "test message", "arn:aws:sns:us-east-1:123456789012:MyTopic")
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, goaws.ErrAccessDenied)
+			assert.Implements(t, (*goaws.AwsError)(nil), err)
+			assert.True(t, err.(goaws.AwsError).ClientError())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package gosns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/ggarcia209/go-aws-v2/v2/gosqs"
+)
+
+//go:generate mockgen -destination=./sqs_queues_client_api_test.go -package=gosns github.com/ggarcia209/go-aws-v2/v2/gosqs SQSQueuesClientAPI
+
+// ProtocolSQS is the subscription protocol for SQS queue endpoints.
+const ProtocolSQS = "sqs"
+
+// SubscribeQueueToTopic subscribes the SQS queue at queueUrl to the given topic and returns
+// the subscription ARN. The queue's access policy is set to allow the topic to send messages
+// to the queue before subscribing; this replaces any existing queue policy.
+func SubscribeQueueToTopic(ctx context.Context, s *SNS, q *gosqs.Queues, topicArn, queueUrl string) (string, error) {
+	queueArn, err := q.GetQueueArn(ctx, queueUrl)
+	if err != nil {
+		return "", fmt.Errorf("q.GetQueueArn: %w", err)
+	}
+
+	policy, err := queuePolicy(topicArn, queueArn)
+	if err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("queuePolicy: %w", err))
+	}
+	if err := q.SetQueuePolicy(ctx, queueUrl, policy); err != nil {
+		return "", fmt.Errorf("q.SetQueuePolicy: %w", err)
+	}
+
+	result, err := s.Subscribe(ctx, queueArn, ProtocolSQS, topicArn, SubscribeOptions{})
+	if err != nil {
+		return "", fmt.Errorf("s.Subscribe: %w", err)
+	}
+
+	return result.SubscriptionArn, nil
+}
+
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Sid       string                       `json:"Sid"`
+	Effect    string                       `json:"Effect"`
+	Principal map[string]string            `json:"Principal"`
+	Action    string                       `json:"Action"`
+	Resource  string                       `json:"Resource"`
+	Condition map[string]map[string]string `json:"Condition"`
+}
+
+// queuePolicy returns an SQS access policy allowing the topic to send messages to the queue.
+func queuePolicy(topicArn, queueArn string) (string, error) {
+	b, err := json.Marshal(policyDocument{
+		Version: "2012-10-17",
+		Statement: []policyStatement{{
+			Sid:       "AllowSNSPublish",
+			Effect:    "Allow",
+			Principal: map[string]string{"Service": "sns.amazonaws.com"},
+			Action:    "sqs:SendMessage",
+			Resource:  queueArn,
+			Condition: map[string]map[string]string{
+				"ArnEquals": {"aws:SourceArn": topicArn},
+			},
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,118 @@
+package gosns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/ggarcia209/go-aws-v2/v2/gosqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestSubscribeQueueToTopic(t *testing.T) {
+	const (
+		topicArn = "arn:aws:sns:us-east-1:123456789012:test-topic"
+		queueUrl = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+		queueArn = "arn:aws:sqs:us-east-1:123456789012:test-queue"
+	)
+
+	tests := []struct {
+		name          string
+		mockSetup     func(t *testing.T, snsMock *MockSNSClientAPI, sqsMock *MockSQSQueuesClientAPI)
+		expectedArn   string
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func(t *testing.T, snsMock *MockSNSClientAPI, sqsMock *MockSQSQueuesClientAPI) {
+				gomock.InOrder(
+					sqsMock.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+							assert.Equal(t, queueUrl, aws.ToString(in.QueueUrl))
+							return &sqs.GetQueueAttributesOutput{Attributes: map[string]string{"QueueArn": queueArn}}, nil
+						}).Times(1),
+					sqsMock.EXPECT().SetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *sqs.SetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.SetQueueAttributesOutput, error) {
+							assert.Equal(t, queueUrl, aws.ToString(in.QueueUrl))
+							assert.JSONEq(t, `{
+								"Version": "2012-10-17",
+								"Statement": [{
+									"Sid": "AllowSNSPublish",
+									"Effect": "Allow",
+									"Principal": {"Service": "sns.amazonaws.com"},
+									"Action": "sqs:SendMessage",
+									"Resource": "`+queueArn+`",
+									"Condition": {"ArnEquals": {"aws:SourceArn": "`+topicArn+`"}}
+								}]
+							}`, in.Attributes["Policy"])
+							return &sqs.SetQueueAttributesOutput{}, nil
+						}).Times(1),
+					snsMock.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, in *sns.SubscribeInput, _ ...func(*sns.Options)) (*sns.SubscribeOutput, error) {
+							assert.Equal(t, queueArn, aws.ToString(in.Endpoint))
+							assert.Equal(t, ProtocolSQS, aws.ToString(in.Protocol))
+							assert.Equal(t, topicArn, aws.ToString(in.TopicArn))
+							assert.Nil(t, in.Attributes)
+							return &sns.SubscribeOutput{SubscriptionArn: aws.String(topicArn + ":sub-1")}, nil
+						}).Times(1),
+				)
+			},
+			expectedArn: topicArn + ":sub-1",
+		},
+		{
+			name: "QueueNotFound",
+			mockSetup: func(t *testing.T, snsMock *MockSNSClientAPI, sqsMock *MockSQSQueuesClientAPI) {
+				sqsMock.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &sqstypes.QueueDoesNotExist{}).Times(1)
+			},
+			expectedError: gosqs.NewQueueNotFoundError(queueUrl),
+		},
+		{
+			name: "SetPolicyError",
+			mockSetup: func(t *testing.T, snsMock *MockSNSClientAPI, sqsMock *MockSQSQueuesClientAPI) {
+				sqsMock.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.GetQueueAttributesOutput{Attributes: map[string]string{"QueueArn": queueArn}}, nil).Times(1)
+				sqsMock.EXPECT().SetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("policy error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.SetQueueAttributes: policy error")),
+		},
+		{
+			name: "SubscribeError",
+			mockSetup: func(t *testing.T, snsMock *MockSNSClientAPI, sqsMock *MockSQSQueuesClientAPI) {
+				sqsMock.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.GetQueueAttributesOutput{Attributes: map[string]string{"QueueArn": queueArn}}, nil).Times(1)
+				sqsMock.EXPECT().SetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.SetQueueAttributesOutput{}, nil).Times(1)
+				snsMock.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("subscribe error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.Subscribe: subscribe error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			snsMock := NewMockSNSClientAPI(ctrl)
+			sqsMock := NewMockSQSQueuesClientAPI(ctrl)
+			tt.mockSetup(t, snsMock, sqsMock)
+
+			arn, err := SubscribeQueueToTopic(context.Background(), &SNS{svc: snsMock}, gosqs.NewQueues(sqsMock), topicArn, queueUrl)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedError.Error())
+				var awsErr goaws.AwsError
+				assert.True(t, errors.As(err, &awsErr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedArn, arn)
+			}
+		})
+	}
+}
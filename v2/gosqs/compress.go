@@ -0,0 +1,51 @@
+package gosqs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+)
+
+// ContentEncodingAttribute is the message attribute set to ContentEncodingGzip
+// on messages whose body was compressed by SendMessage.
+const ContentEncodingAttribute = "Content-Encoding"
+
+// ContentEncodingGzip indicates a gzip compressed, base64 encoded message body.
+const ContentEncodingGzip = "gzip"
+
+// compressBody gzips body and base64 encodes the result, as message bodies
+// may only contain valid XML characters.
+func compressBody(body string) (string, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("zw.Write: %w", err))
+	}
+	if err := zw.Close(); err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("zw.Close: %w", err))
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decompressBody reverses compressBody.
+func decompressBody(body string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return "", NewInvalidMessageContentError(&body)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return "", NewInvalidMessageContentError(&body)
+	}
+	defer zr.Close()
+
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		return "", NewInvalidMessageContentError(&body)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,153 @@
+package gosqs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestSQSMessages_Compression_RoundTrip(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := `{"items":[` + strings.Repeat(`{"id":"1","status":"active"},`, 1000) + `{}]}`
+	attributes := map[string]types.MessageAttributeValue{
+		"type": {DataType: aws.String("String"), StringValue: aws.String("report")},
+	}
+
+	var sent *sqs.SendMessageInput
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+			sent = in
+			return &sqs.SendMessageOutput{MessageId: aws.String("msg-1")}, nil
+		}).Times(1)
+	m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			assert.Equal(t, []string{"type", ContentEncodingAttribute}, in.MessageAttributeNames)
+			return &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{{
+					MessageId:         aws.String("msg-1"),
+					ReceiptHandle:     aws.String("handle-1"),
+					Body:              sent.MessageBody,
+					MessageAttributes: sent.MessageAttributes,
+				}},
+			}, nil
+		}).Times(1)
+
+	s := NewMessages(m).WithCompression()
+
+	_, err := s.SendMessage(context.Background(), SendMsgOptions{
+		QueueURL:          testQueueURL,
+		MessageBody:       body,
+		MessageAttributes: attributes,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, sent)
+	assert.Less(t, len(aws.ToString(sent.MessageBody)), len(body))
+	assert.Equal(t, ContentEncodingGzip, aws.ToString(sent.MessageAttributes[ContentEncodingAttribute].StringValue))
+	assert.Equal(t, "report", aws.ToString(sent.MessageAttributes["type"].StringValue))
+	assert.NotContains(t, attributes, ContentEncodingAttribute)
+
+	resp, err := s.ReceiveMessage(context.Background(), RecMsgOptions{
+		QueueURL:              testQueueURL,
+		MessageAttributeNames: []string{"type"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 1)
+	assert.Equal(t, body, resp.Messages[0].Body)
+}
+
+func TestSQSMessages_Compression_TooManyAttributes(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the Content-Encoding attribute would be the 11th attribute
+	attributes := make(map[string]types.MessageAttributeValue, MaxMessageAttributes)
+	for i := 0; i < MaxMessageAttributes; i++ {
+		attributes[fmt.Sprintf("attr-%d", i)] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("v")}
+	}
+
+	s := NewMessages(NewMockSQSMessagesClientAPI(ctrl)).WithCompression()
+
+	_, err := s.SendMessage(context.Background(), SendMsgOptions{
+		QueueURL:          testQueueURL,
+		MessageBody:       "hello world",
+		MessageAttributes: attributes,
+	})
+	require.Error(t, err)
+	assert.EqualError(t, err, NewTooManyMessageAttributesError(MaxMessageAttributes+1, MaxMessageAttributes).Error())
+	assert.ErrorIs(t, err, ErrTooManyMessageAttributes)
+	assert.NotContains(t, attributes, ContentEncodingAttribute)
+}
+
+func TestSQSMessages_Compression_Receive(t *testing.T) {
+	compressed, err := compressBody("hello")
+	require.NoError(t, err)
+
+	gzipAttribute := map[string]types.MessageAttributeValue{
+		ContentEncodingAttribute: {DataType: aws.String("String"), StringValue: aws.String(ContentEncodingGzip)},
+	}
+
+	tests := []struct {
+		name          string
+		message       types.Message
+		expectedBody  string
+		expectedError error
+	}{
+		{
+			name:         "Compressed",
+			message:      types.Message{Body: aws.String(compressed), MessageAttributes: gzipAttribute},
+			expectedBody: "hello",
+		},
+		{
+			name:         "Uncompressed",
+			message:      types.Message{Body: aws.String("hello")},
+			expectedBody: "hello",
+		},
+		{
+			name:          "Invalid",
+			message:       types.Message{Body: aws.String("hello"), MessageAttributes: gzipAttribute},
+			expectedError: NewInvalidMessageContentError(aws.String("hello")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+				Messages: []types.Message{tt.message},
+			}, nil).Times(1)
+
+			s := NewMessages(m).WithCompression()
+
+			resp, err := s.ReceiveMessage(context.Background(), RecMsgOptions{QueueURL: testQueueURL})
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrInvalidMessageContent)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.Messages, 1)
+			assert.Equal(t, tt.expectedBody, resp.Messages[0].Body)
+		})
+	}
+}
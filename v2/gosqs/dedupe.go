@@ -0,0 +1,124 @@
+package gosqs
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+)
+
+// Default settings for the SendMessage idempotency key cache.
+const (
+	DefaultDedupeCapacity = 1000
+	DefaultDedupeWindow   = 5 * time.Minute
+)
+
+// dedupeCache is a bounded LRU cache of recently sent idempotency keys
+// and the responses returned when the messages were sent. A key is claimed
+// before its message is sent, so concurrent sends of the same key send once.
+type dedupeCache struct {
+	mu       sync.Mutex
+	capacity int
+	window   time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+	clock    goaws.Clock
+}
+
+// dedupeEntry is a claimed key. done is closed once the send finishes;
+// resp is nil until then, and if the send failed.
+type dedupeEntry struct {
+	key    string
+	resp   *SendMsgResponse
+	sentAt time.Time
+	done   chan struct{}
+}
+
+func newDedupeCache(capacity int, window time.Duration, clock goaws.Clock) *dedupeCache {
+	if capacity < 1 {
+		capacity = DefaultDedupeCapacity
+	}
+	if window <= 0 {
+		window = DefaultDedupeWindow
+	}
+	if clock == nil {
+		clock = goaws.RealClock{}
+	}
+	return &dedupeCache{
+		capacity: capacity,
+		window:   window,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		clock:    clock,
+	}
+}
+
+// claim atomically reserves key for the caller to send, unless a message with key
+// was sent within the dedupe window, in which case its response is returned. If a
+// send of key is in flight, claim waits for it and returns its response, or claims
+// key if it failed. The caller must pass a claimed entry to finish.
+func (c *dedupeCache) claim(ctx context.Context, key string) (*dedupeEntry, *SendMsgResponse, error) {
+	for {
+		c.mu.Lock()
+		el, ok := c.items[key]
+		if !ok {
+			entry := &dedupeEntry{key: key, sentAt: c.clock.Now(), done: make(chan struct{})}
+			c.push(entry)
+			c.mu.Unlock()
+			return entry, nil, nil
+		}
+
+		entry := el.Value.(*dedupeEntry)
+		select {
+		case <-entry.done:
+			if c.clock.Now().Sub(entry.sentAt) > c.window {
+				c.ll.Remove(el)
+				delete(c.items, key)
+				c.mu.Unlock()
+				continue
+			}
+			c.ll.MoveToFront(el)
+			c.mu.Unlock()
+			return nil, entry.resp, nil
+		default:
+		}
+		c.mu.Unlock()
+
+		// wait for the in-flight send, then check the key again
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, nil, goaws.NewClientError(fmt.Errorf("dedupe claim: %w", ctx.Err()))
+		}
+	}
+}
+
+// finish records resp as the response for the claimed entry, or releases the
+// entry's key if the send failed and resp is nil, and wakes any waiting claims.
+func (c *dedupeCache) finish(entry *dedupeEntry, resp *SendMsgResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if resp != nil {
+		entry.resp = resp
+		entry.sentAt = c.clock.Now()
+	} else if el, ok := c.items[entry.key]; ok && el.Value == entry {
+		c.ll.Remove(el)
+		delete(c.items, entry.key)
+	}
+	close(entry.done)
+}
+
+// push adds entry to the front of the cache, evicting the least recently used key if
+// the cache is full. c.mu must be held.
+func (c *dedupeCache) push(entry *dedupeEntry) {
+	c.items[entry.key] = c.ll.PushFront(entry)
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*dedupeEntry).key)
+	}
+}
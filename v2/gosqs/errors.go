@@ -7,6 +7,24 @@ import (
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 )
 
+// Sentinel errors matched by the corresponding error types via errors.Is.
+var (
+	ErrEmptyQueueUrlInRequest     = errors.New("empty queue url in request")
+	ErrEmptyQueueUrlInResponse    = errors.New("empty queue url in response")
+	ErrInvalidMessageContent      = errors.New("invalid message content")
+	ErrInvalidReceiptHandles      = errors.New("invalid receipt handles")
+	ErrNoMessageIDsInBatchRequest = errors.New("no message IDs in request")
+	ErrMaxMessagesInBatchRequest  = errors.New("max 10 messages per request")
+	ErrQueueNotFound              = errors.New("queue not found")
+	ErrInvalidAddress             = errors.New("invalid address")
+	ErrMessageTooLarge            = errors.New("message too large")
+	ErrThrottled                  = errors.New("request throttled")
+	ErrTooManyMessageAttributes   = errors.New("too many message attributes")
+	ErrPurgeInProgress            = errors.New("purge in progress")
+	ErrBodyDecode                 = errors.New("message body decode failed")
+	ErrReceiveTimeout             = errors.New("receive timed out")
+)
+
 type EmptyQueueUrlInRequestError struct {
 	*goaws.ClientErr
 }
@@ -17,6 +35,10 @@ func NewEmptyQueueUrlInRequestError() *EmptyQueueUrlInRequestError {
 	}
 }
 
+func (e *EmptyQueueUrlInRequestError) Is(target error) bool {
+	return target == ErrEmptyQueueUrlInRequest
+}
+
 type EmptyQueueUrlInResponseError struct {
 	*goaws.ClientErr
 }
@@ -27,6 +49,10 @@ func NewEmptyQueueUrlInResponseError() *EmptyQueueUrlInResponseError {
 	}
 }
 
+func (e *EmptyQueueUrlInResponseError) Is(target error) bool {
+	return target == ErrEmptyQueueUrlInResponse
+}
+
 type InvalidMessageContentError struct {
 	*goaws.ClientErr
 }
@@ -41,6 +67,10 @@ func NewInvalidMessageContentError(content *string) *InvalidMessageContentError
 	}
 }
 
+func (e *InvalidMessageContentError) Is(target error) bool {
+	return target == ErrInvalidMessageContent
+}
+
 type InvalidReceiptHandlesError struct {
 	*goaws.ClientErr
 }
@@ -51,6 +81,10 @@ func NewInvalidReceiptHandlesError(receiptHandles, messageIds int) *InvalidRecei
 	}
 }
 
+func (e *InvalidReceiptHandlesError) Is(target error) bool {
+	return target == ErrInvalidReceiptHandles
+}
+
 type NoMessageIDsInBatchRequestError struct {
 	*goaws.ClientErr
 }
@@ -61,6 +95,10 @@ func NewNoMessageIDsInBatchRequestError() *NoMessageIDsInBatchRequestError {
 	}
 }
 
+func (e *NoMessageIDsInBatchRequestError) Is(target error) bool {
+	return target == ErrNoMessageIDsInBatchRequest
+}
+
 type MaxMessagesInBatchRequestError struct {
 	*goaws.ClientErr
 }
@@ -71,6 +109,10 @@ func NewMaxMessagesExceededError(msgs int) *MaxMessagesInBatchRequestError {
 	}
 }
 
+func (e *MaxMessagesInBatchRequestError) Is(target error) bool {
+	return target == ErrMaxMessagesInBatchRequest
+}
+
 type QueueNotFoundError struct {
 	*goaws.ClientErr
 }
@@ -81,6 +123,10 @@ func NewQueueNotFoundError(name string) *QueueNotFoundError {
 	}
 }
 
+func (e *QueueNotFoundError) Is(target error) bool {
+	return target == ErrQueueNotFound || target == goaws.ErrNotFound
+}
+
 type InvalidAddressError struct {
 	*goaws.ClientErr
 }
@@ -90,3 +136,106 @@ func NewInvalidAddressError(address string) *InvalidAddressError {
 		goaws.NewClientError(fmt.Errorf("invalid address '%s'", address)),
 	}
 }
+
+func (e *InvalidAddressError) Is(target error) bool {
+	return target == ErrInvalidAddress
+}
+
+type MessageTooLargeError struct {
+	*goaws.ClientErr
+}
+
+func NewMessageTooLargeError(size, max int) *MessageTooLargeError {
+	return &MessageTooLargeError{
+		goaws.NewClientError(fmt.Errorf("message size %d bytes exceeds maximum of %d bytes", size, max)),
+	}
+}
+
+func (e *MessageTooLargeError) Is(target error) bool {
+	return target == ErrMessageTooLarge
+}
+
+// ThrottledError is returned when SQS throttles a request (OverLimit, KmsThrottled,
+// RequestThrottled or HTTP 503) and any retries configured by WithFailConfig are exhausted.
+type ThrottledError struct {
+	*goaws.RetryableClientError
+}
+
+func NewThrottledError(err error) *ThrottledError {
+	return &ThrottledError{
+		goaws.NewRetryableClientError(fmt.Errorf("request throttled: %w", err)),
+	}
+}
+
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+type TooManyMessageAttributesError struct {
+	*goaws.ClientErr
+}
+
+func NewTooManyMessageAttributesError(count, max int) *TooManyMessageAttributesError {
+	return &TooManyMessageAttributesError{
+		goaws.NewClientError(fmt.Errorf("%d message attribute names exceeds maximum of %d", count, max)),
+	}
+}
+
+func (e *TooManyMessageAttributesError) Is(target error) bool {
+	return target == ErrTooManyMessageAttributes
+}
+
+// PurgeInProgressError is returned when the queue was purged within the last 60 seconds.
+// SQS allows only one purge per queue per 60 seconds; the purge may be retried afterwards.
+type PurgeInProgressError struct {
+	*goaws.RetryableClientError
+}
+
+func NewPurgeInProgressError(url string) *PurgeInProgressError {
+	return &PurgeInProgressError{
+		goaws.NewRetryableClientError(fmt.Errorf("purge in progress for queue: %s", url)),
+	}
+}
+
+func (e *PurgeInProgressError) Is(target error) bool {
+	return target == ErrPurgeInProgress
+}
+
+// BodyDecodeError is returned by ReceiveTyped, along with the messages that decoded,
+// when one or more message bodies can't be unmarshaled. Failures holds each undecoded
+// message, so it can be deleted or left to reach the dead-letter queue.
+type BodyDecodeError struct {
+	*goaws.ClientErr
+	Failures []DecodeFailure
+}
+
+func NewBodyDecodeError(failures []DecodeFailure) *BodyDecodeError {
+	errs := make([]error, 0, len(failures))
+	for _, f := range failures {
+		errs = append(errs, fmt.Errorf("message %s: %w", f.Message.MessageId, f.Err))
+	}
+	return &BodyDecodeError{
+		ClientErr: goaws.NewClientError(fmt.Errorf("json.Unmarshal: %w", errors.Join(errs...))),
+		Failures:  failures,
+	}
+}
+
+func (e *BodyDecodeError) Is(target error) bool {
+	return target == ErrBodyDecode
+}
+
+// ReceiveTimeoutError is returned by ReceiveMessage when the call is cut off by the
+// RecMsgOptions.WithTimeout deadline before SQS responds. The receive may be retried.
+type ReceiveTimeoutError struct {
+	*goaws.RetryableInternalError
+}
+
+func NewReceiveTimeoutError(err error) *ReceiveTimeoutError {
+	return &ReceiveTimeoutError{
+		goaws.NewRetryableInternalError(fmt.Errorf("receive timed out: %w", err)),
+	}
+}
+
+func (e *ReceiveTimeoutError) Is(target error) bool {
+	return target == ErrReceiveTimeout
+}
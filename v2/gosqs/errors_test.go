@@ -0,0 +1,44 @@
+package gosqs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorsIs(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		sentinel error
+		notFound bool
+	}{
+		{name: "empty queue url in request", err: NewEmptyQueueUrlInRequestError(), sentinel: ErrEmptyQueueUrlInRequest},
+		{name: "empty queue url in response", err: NewEmptyQueueUrlInResponseError(), sentinel: ErrEmptyQueueUrlInResponse},
+		{name: "invalid message content", err: NewInvalidMessageContentError(nil), sentinel: ErrInvalidMessageContent},
+		{name: "invalid receipt handles", err: NewInvalidReceiptHandlesError(1, 2), sentinel: ErrInvalidReceiptHandles},
+		{name: "no message ids", err: NewNoMessageIDsInBatchRequestError(), sentinel: ErrNoMessageIDsInBatchRequest},
+		{name: "max messages exceeded", err: NewMaxMessagesExceededError(11), sentinel: ErrMaxMessagesInBatchRequest},
+		{name: "queue not found", err: NewQueueNotFoundError("test"), sentinel: ErrQueueNotFound, notFound: true},
+		{name: "invalid address", err: NewInvalidAddressError("test"), sentinel: ErrInvalidAddress},
+		{name: "message too large", err: NewMessageTooLargeError(262145, 262144), sentinel: ErrMessageTooLarge},
+		{name: "throttled", err: NewThrottledError(errors.New("over limit")), sentinel: ErrThrottled},
+		{name: "too many message attributes", err: NewTooManyMessageAttributesError(11, 10), sentinel: ErrTooManyMessageAttributes},
+		{name: "purge in progress", err: NewPurgeInProgressError("test"), sentinel: ErrPurgeInProgress},
+		{name: "body decode", err: NewBodyDecodeError([]DecodeFailure{{Message: &Message{MessageId: "test"}, Err: errors.New("test")}}), sentinel: ErrBodyDecode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wrapped := fmt.Errorf("wrapped: %w", tt.err)
+			assert.ErrorIs(t, tt.err, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.Equal(t, tt.notFound, errors.Is(wrapped, goaws.ErrNotFound))
+			assert.NotErrorIs(t, wrapped, errors.New(tt.sentinel.Error()))
+		})
+	}
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/ggarcia209/go-aws-v2/v2/gos3"
 )
 
 // MessagesLogic defines common methods for SQS Messages
@@ -25,6 +31,7 @@ type MessagesLogic interface {
 	DeleteMessage(ctx context.Context, url, handle string) error
 	DeleteMessageBatch(ctx context.Context, req DeleteMessageBatchRequest) (*DeleteMessageBatchResponse, error)
 	ChangeMessageVisibilityBatch(ctx context.Context, req BatchUpdateVisibilityTimeoutRequest) (*BatchUpdateVisibilityTimeoutResponse, error)
+	ChangeMessageVisibilityAll(ctx context.Context, req BatchUpdateVisibilityTimeoutRequest) (*BatchUpdateVisibilityTimeoutResponse, error)
 }
 
 // SQSMessagesClientAPI defines the interface for the AWS SQS client methods used by this package.
@@ -39,19 +46,137 @@ type SQSMessagesClientAPI interface {
 }
 
 type Messages struct {
-	svc SQSMessagesClientAPI
+	svc      SQSMessagesClientAPI
+	dedupe   *dedupeCache
+	clock    goaws.Clock
+	offload  *payloadOffloader
+	compress bool
+	fc       *goaws.FailConfig
 }
 
 func NewMessages(svc SQSMessagesClientAPI) *Messages {
+	clock := goaws.RealClock{}
 	return &Messages{
-		svc: svc,
+		svc:    svc,
+		dedupe: newDedupeCache(DefaultDedupeCapacity, DefaultDedupeWindow, clock),
+		clock:  clock,
 	}
 }
 
+// WithDedupe configures the number of idempotency keys remembered by SendMessage
+// and how long each key suppresses duplicate sends, and returns s for chaining.
+func (s *Messages) WithDedupe(capacity int, window time.Duration) *Messages {
+	s.dedupe = newDedupeCache(capacity, window, s.clock)
+	return s
+}
+
+// WithPayloadOffloading enables storing message bodies larger than the queue's maximum
+// message size in the given S3 bucket, and returns s for chaining. SendMessage sends a
+// pointer to the stored body, which ReceiveMessage replaces with the body from S3.
+// Stored bodies are not deleted; use a bucket lifecycle rule to expire them.
+func (s *Messages) WithPayloadOffloading(store gos3.S3Logic, bucket string) *Messages {
+	s.offload = &payloadOffloader{store: store, bucket: bucket}
+	return s
+}
+
+// WithCompression enables gzip compression of message bodies, and returns s for chaining.
+// SendMessage compresses each body and sets the ContentEncodingAttribute message attribute;
+// ReceiveMessage decompresses bodies with the attribute set, so compression is transparent
+// to callers of both. Compressed bodies count against the queue's maximum message size.
+func (s *Messages) WithCompression() *Messages {
+	s.compress = true
+	return s
+}
+
+// WithFailConfig enables retrying throttled (OverLimit, KmsThrottled, RequestThrottled
+// and HTTP 503) SendMessage, ReceiveMessage and DeleteMessage requests with the given
+// exponential backoff parameters, and returns s for chaining. Retries are disabled by default.
+func (s *Messages) WithFailConfig(fc *goaws.FailConfig) *Messages {
+	s.fc = fc
+	return s
+}
+
+// WithClock sets the clock used to expire idempotency keys, and returns s for chaining.
+func (s *Messages) WithClock(clock goaws.Clock) *Messages {
+	s.clock = clock
+	if s.dedupe != nil {
+		s.dedupe.mu.Lock()
+		s.dedupe.clock = clock
+		s.dedupe.mu.Unlock()
+	}
+	return s
+}
+
 // SendMessage sends a new message to a queue per the options argument.
 // Unique MD5 checksums are generated for the MessageDeduplicationID
 // and MessageGroupID fields if not set for messages sent to FIFO Queues.
+// If options.IdempotencyKey is set and a message with the same key was sent
+// within the dedupe window, the message is not resent and the prior response is returned.
+// A MessageTooLargeError is returned without sending if the body and attributes
+// exceed options.MaximumMessageSize, unless payload offloading is enabled. Offloading adds
+// a message attribute, so a TooManyMessageAttributesError is returned without uploading
+// if the message already has MaxMessageAttributes attributes.
+// Concurrent sends with the same IdempotencyKey send the message once; the others wait
+// for its response, and one of them sends the message if that send fails.
 func (s *Messages) SendMessage(ctx context.Context, options SendMsgOptions) (*SendMsgResponse, error) {
+	if options.IdempotencyKey == "" || s.dedupe == nil {
+		return s.sendMessage(ctx, options)
+	}
+
+	entry, resp, err := s.dedupe.claim(ctx, options.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		return resp, nil
+	}
+	resp, err = s.sendMessage(ctx, options)
+	s.dedupe.finish(entry, resp)
+	return resp, err
+}
+
+// sendMessage sends a new message to a queue per the options argument, ignoring
+// options.IdempotencyKey.
+func (s *Messages) sendMessage(ctx context.Context, options SendMsgOptions) (*SendMsgResponse, error) {
+	if s.compress {
+		body, err := compressBody(options.MessageBody)
+		if err != nil {
+			return nil, err
+		}
+		attributes := withAttribute(options.MessageAttributes, ContentEncodingAttribute, types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(ContentEncodingGzip),
+		})
+		if len(attributes) > MaxMessageAttributes {
+			return nil, NewTooManyMessageAttributesError(len(attributes), MaxMessageAttributes)
+		}
+		options.MessageBody = body
+		options.MessageAttributes = attributes
+	}
+
+	maxSize := options.MaximumMessageSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaximumMessageSize
+	}
+	if size := messageSize(options.MessageBody, options.MessageAttributes); size > maxSize {
+		if s.offload == nil {
+			return nil, NewMessageTooLargeError(size, maxSize)
+		}
+		attributes := withAttribute(options.MessageAttributes, ExtendedPayloadSizeAttribute, types.MessageAttributeValue{
+			DataType:    aws.String("Number"),
+			StringValue: aws.String(strconv.Itoa(len(options.MessageBody))),
+		})
+		if len(attributes) > MaxMessageAttributes {
+			return nil, NewTooManyMessageAttributesError(len(attributes), MaxMessageAttributes)
+		}
+		pointer, err := s.offload.upload(ctx, options.MessageBody)
+		if err != nil {
+			return nil, err
+		}
+		options.MessageAttributes = attributes
+		options.MessageBody = pointer
+	}
+
 	// ensure values are valid
 	if options.DelaySeconds < 0 {
 		options.DelaySeconds = 0
@@ -80,13 +205,19 @@ func (s *Messages) SendMessage(ctx context.Context, options SendMsgOptions) (*Se
 		}
 	}
 
-	out, err := s.svc.SendMessage(ctx, input)
+	var out *sqs.SendMessageOutput
+	err := s.withRetries(ctx, func() (err error) {
+		out, err = s.svc.SendMessage(ctx, input)
+		return err
+	})
 	if err != nil {
 		var notExist *types.QueueDoesNotExist
 		var invalidAddress *types.InvalidAddress
 		var badContent *types.InvalidMessageContents
 		var re *awshttp.ResponseError
 		switch {
+		case isThrottled(err):
+			return nil, NewThrottledError(fmt.Errorf("s.svc.SendMessage: %w", err))
 		case errors.As(err, &notExist):
 			return nil, NewQueueNotFoundError(options.QueueURL)
 		case errors.As(err, &invalidAddress):
@@ -95,25 +226,28 @@ func (s *Messages) SendMessage(ctx context.Context, options SendMsgOptions) (*Se
 			return nil, NewInvalidMessageContentError(input.MessageBody)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.SendMessage: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return nil, goaws.NewAccessDeniedError(fmt.Errorf("s.svc.SendMessage: %w", err))
 			case http.StatusBadRequest:
 				return nil, NewInvalidMessageContentError(input.MessageBody)
 			case http.StatusNotFound:
 				return nil, NewQueueNotFoundError(options.QueueURL)
 			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.SendMessage: %w", err))
 			}
 		default:
-			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
+			return nil, goaws.NewServiceError(fmt.Errorf("s.svc.SendMessage: %w", err))
 		}
 	}
-	resp := wrapSendMsgOutput(out)
-	return resp, nil
+	return wrapSendMsgOutput(out), nil
 }
 
-// ReceiveMessage receives a message from a queue per the options argument
+// ReceiveMessage receives a message from a queue per the options argument.
+// The response's Empty and TimedOut fields report an empty receive; a receive cut
+// off by options.WithTimeout returns a ReceiveTimeoutError.
 func (s *Messages) ReceiveMessage(ctx context.Context, options RecMsgOptions) (*ReceiveMessageResponse, error) {
 	var msgs = make([]*Message, 0)
 
@@ -140,24 +274,107 @@ func (s *Messages) ReceiveMessage(ctx context.Context, options RecMsgOptions) (*
 	if checkFifo(options.QueueURL) && options.ReceiveRequestAttemptId == "" {
 		options.ReceiveRequestAttemptId = GenerateDedupeID(options.QueueURL)
 	}
+	// bound the receive call so a stuck request can't hang indefinitely
+	parent := ctx
+	if options.WithTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(options.WaitTimeSeconds)*time.Second+options.WithTimeout)
+		defer cancel()
+	}
 
-	msgResult, err := s.svc.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+	attributeNames := options.MessageAttributeNames
+	if s.offload != nil {
+		attributeNames = withAttributeName(attributeNames, ExtendedPayloadSizeAttribute)
+	}
+	if s.compress {
+		attributeNames = withAttributeName(attributeNames, ContentEncodingAttribute)
+	}
+
+	input := &sqs.ReceiveMessageInput{
 		AttributeNames:          options.AttributeNames,
 		MaxNumberOfMessages:     options.MaxNumberOfMessages,
-		MessageAttributeNames:   options.MessageAttributeNames,
+		MessageAttributeNames:   attributeNames,
 		QueueUrl:                aws.String(options.QueueURL),
 		ReceiveRequestAttemptId: aws.String(options.ReceiveRequestAttemptId),
 		VisibilityTimeout:       options.VisibilityTimeout,
 		WaitTimeSeconds:         options.WaitTimeSeconds,
+	}
+	var msgResult *sqs.ReceiveMessageOutput
+	err := s.withRetries(ctx, func() (err error) {
+		msgResult, err = s.svc.ReceiveMessage(ctx, input)
+		return err
 	})
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.ReceiveMessage: %w", err))
+		if options.WithTimeout > 0 && errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+			return nil, NewReceiveTimeoutError(fmt.Errorf("s.svc.ReceiveMessage: %w", err))
+		}
+		if isThrottled(err) {
+			return nil, NewThrottledError(fmt.Errorf("s.svc.ReceiveMessage: %w", err))
+		}
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.ReceiveMessage: %w", err))
 	}
 	for _, msg := range msgResult.Messages {
 		conv := convertMessage(msg)
+		if _, ok := conv.MessageAttributes[ExtendedPayloadSizeAttribute]; ok && s.offload != nil {
+			// download with the caller's ctx; the receive deadline doesn't cover the download
+			body, err := s.offload.download(parent, conv.Body)
+			if err != nil {
+				return nil, err
+			}
+			conv.Body = body
+		}
+		if av, ok := conv.MessageAttributes[ContentEncodingAttribute]; ok && av.Value == ContentEncodingGzip {
+			body, err := decompressBody(conv.Body)
+			if err != nil {
+				return nil, err
+			}
+			conv.Body = body
+		}
 		msgs = append(msgs, conv)
 	}
-	return &ReceiveMessageResponse{Messages: msgs}, nil
+	empty := len(msgs) == 0
+	return &ReceiveMessageResponse{Messages: msgs, Empty: empty, TimedOut: empty && options.WaitTimeSeconds > 0}, nil
+}
+
+// ReceiveTyped receives messages from a queue per the options argument and
+// unmarshals each message's JSON Body into T. If any message body cannot be
+// unmarshaled, the messages that decoded are returned with a BodyDecodeError
+// holding the others.
+func ReceiveTyped[T any](ctx context.Context, s *Messages, options RecMsgOptions) ([]TypedMessage[T], error) {
+	resp, err := s.ReceiveMessage(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]TypedMessage[T], 0, len(resp.Messages))
+	var failures []DecodeFailure
+	for _, msg := range resp.Messages {
+		var body T
+		if err := json.Unmarshal([]byte(msg.Body), &body); err != nil {
+			failures = append(failures, DecodeFailure{Message: msg, Err: err})
+			continue
+		}
+		msgs = append(msgs, TypedMessage[T]{
+			MessageId:     msg.MessageId,
+			ReceiptHandle: msg.ReceiptHandle,
+			Body:          body,
+			Raw:           msg,
+		})
+	}
+	if len(failures) > 0 {
+		return msgs, NewBodyDecodeError(failures)
+	}
+	return msgs, nil
+}
+
+// messageSize returns the size SQS counts against a queue's MaximumMessageSize:
+// the body plus each attribute's name, data type and value.
+func messageSize(body string, attributes map[string]types.MessageAttributeValue) int {
+	size := len(body)
+	for name, av := range attributes {
+		size += len(name) + len(aws.ToString(av.DataType)) + len(aws.ToString(av.StringValue)) + len(av.BinaryValue)
+	}
+	return size
 }
 
 func wrapSendMsgOutput(out *sqs.SendMessageOutput) *SendMsgResponse {
@@ -228,14 +445,40 @@ func convertMessage(msg types.Message) *Message {
 	}
 
 	return &Message{
-		Attributes:              attributes,
-		Body:                    body,
-		MD5OfBody:               md5OfBody,
-		MessageAttributes:       msgAttributes,
-		MessageId:               messageId,
-		ReceiptHandle:           receiptHandle,
-		MD5OfMessagefAttributes: md5OfMessageAttributes,
+		Attributes:                       attributes,
+		Body:                             body,
+		MD5OfBody:                        md5OfBody,
+		MessageAttributes:                msgAttributes,
+		MessageId:                        messageId,
+		ReceiptHandle:                    receiptHandle,
+		MD5OfMessagefAttributes:          md5OfMessageAttributes,
+		ApproximateReceiveCount:          parseIntAttribute(attributes, types.MessageSystemAttributeNameApproximateReceiveCount),
+		SentTimestamp:                    parseTimestampAttribute(attributes, types.MessageSystemAttributeNameSentTimestamp),
+		ApproximateFirstReceiveTimestamp: parseTimestampAttribute(attributes, types.MessageSystemAttributeNameApproximateFirstReceiveTimestamp),
+		SequenceNumber:                   attributes[string(types.MessageSystemAttributeNameSequenceNumber)],
+		MessageGroupId:                   attributes[string(types.MessageSystemAttributeNameMessageGroupId)],
+		TraceHeader:                      attributes[string(types.MessageSystemAttributeNameAWSTraceHeader)],
+	}
+}
+
+// parseIntAttribute returns the integer value of the given system attribute,
+// or 0 if the attribute is missing or malformed.
+func parseIntAttribute(attributes map[string]string, name types.MessageSystemAttributeName) int {
+	n, err := strconv.Atoi(attributes[string(name)])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// parseTimestampAttribute converts the given epoch milliseconds system attribute
+// to a time.Time, or returns the zero time if the attribute is missing or malformed.
+func parseTimestampAttribute(attributes map[string]string, name types.MessageSystemAttributeName) time.Time {
+	ms, err := strconv.ParseInt(attributes[string(name)], 10, 64)
+	if err != nil {
+		return time.Time{}
 	}
+	return time.UnixMilli(ms)
 }
 
 // determine if FIFO queue from url (".fifo")
@@ -250,6 +493,26 @@ func checkFifo(url string) bool {
 	return false
 }
 
+// withAttribute returns a copy of attributes with name set to value,
+// leaving the caller's map unmodified.
+func withAttribute(attributes map[string]types.MessageAttributeValue, name string, value types.MessageAttributeValue) map[string]types.MessageAttributeValue {
+	out := make(map[string]types.MessageAttributeValue, len(attributes)+1)
+	for k, v := range attributes {
+		out[k] = v
+	}
+	out[name] = value
+	return out
+}
+
+// withAttributeName returns names with the given message attribute name added
+// unless it, or all attributes, are already requested.
+func withAttributeName(names []string, name string) []string {
+	if slices.Contains(names, name) || slices.Contains(names, "All") || slices.Contains(names, ".*") {
+		return names
+	}
+	return append(slices.Clone(names), name)
+}
+
 // GenerateDedupeID generates a MD5 hash from a
 // timestamp of the current time + the given queue url.
 func GenerateDedupeID(msgBody string) string {
@@ -258,35 +521,94 @@ func GenerateDedupeID(msgBody string) string {
 	return hashStr
 }
 
+// MessageGroupIDForKey returns a stable FIFO MessageGroupId for partitionKey. Keys are
+// spread across the given number of groups by hash, so messages with the same key are
+// always in the same group and stay ordered while different keys are processed in
+// parallel. If groups < 1, each key is its own group.
+// ex: opts.MessageGroupId = MessageGroupIDForKey(order.CustomerID, 64)
+func MessageGroupIDForKey(partitionKey string, groups int) string {
+	if groups < 1 {
+		return GenerateDedupeID(partitionKey)
+	}
+	h := fnv.New32a()
+	h.Write([]byte(partitionKey))
+	return "group-" + strconv.FormatUint(uint64(h.Sum32()%uint32(groups)), 10)
+}
+
 // DeleteMessage deletes a message from the specified queue (by url) with the
 // given handle.
 func (s *Messages) DeleteMessage(ctx context.Context, url, handle string) error {
-	if _, err := s.svc.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+	input := &sqs.DeleteMessageInput{
 		QueueUrl:      aws.String(url),
 		ReceiptHandle: aws.String(handle),
+	}
+	if err := s.withRetries(ctx, func() error {
+		_, err := s.svc.DeleteMessage(ctx, input)
+		return err
 	}); err != nil {
 		var notExist *types.InvalidAddress
 		var re *awshttp.ResponseError
 		switch {
+		case isThrottled(err):
+			return NewThrottledError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
 		case errors.As(err, &notExist):
 			return NewInvalidAddressError(url)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", re.Err))
+				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return goaws.NewAccessDeniedError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
 			case http.StatusNotFound:
 				return NewInvalidAddressError(url)
 			default:
-				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", re.Err))
+				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
 			}
 		default:
-			return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
+			return goaws.NewServiceError(fmt.Errorf("s.svc.DeleteMessage: %w", err))
 		}
 	}
 	return nil
 }
 
+// withRetries calls fn until it succeeds or returns an error that isn't a throttling
+// error, waiting between attempts per s.fc. If the retry budget is exhausted or ctx's
+// deadline would pass before the next attempt, the last error is returned.
+// Retries are disabled if s.fc is nil.
+func (s *Messages) withRetries(ctx context.Context, fn func() error) error {
+	if s.fc == nil {
+		return fn()
+	}
+	retries := s.fc.NewRetries()
+	for {
+		err := fn()
+		if err == nil || !isThrottled(err) {
+			return err
+		}
+		if retries.ExponentialBackoffContext(ctx) != nil { // waits
+			return err
+		}
+	}
+}
+
+// isThrottled returns true if err is an SQS throttling error or an HTTP 503 response.
+func isThrottled(err error) bool {
+	var (
+		overLimit        *types.OverLimit
+		kmsThrottled     *types.KmsThrottled
+		requestThrottled *types.RequestThrottled
+		re               *awshttp.ResponseError
+	)
+	switch {
+	case errors.As(err, &overLimit), errors.As(err, &kmsThrottled), errors.As(err, &requestThrottled):
+		return true
+	case errors.As(err, &re):
+		return re.ResponseError != nil && re.Response != nil && re.HTTPStatusCode() == http.StatusServiceUnavailable
+	}
+	return false
+}
+
 // DeleteMessageBatch deletes a batch of messages
 func (s *Messages) DeleteMessageBatch(ctx context.Context, req DeleteMessageBatchRequest) (*DeleteMessageBatchResponse, error) {
 	if req.QueueURL == "" {
@@ -298,7 +620,7 @@ func (s *Messages) DeleteMessageBatch(ctx context.Context, req DeleteMessageBatc
 	if len(req.MessageIDs) == 0 {
 		return nil, NewNoMessageIDsInBatchRequestError()
 	}
-	if len(req.MessageIDs) > 10 {
+	if len(req.MessageIDs) > maxBatchEntries {
 		return nil, NewMaxMessagesExceededError(len(req.MessageIDs))
 	}
 
@@ -320,7 +642,7 @@ func (s *Messages) DeleteMessageBatch(ctx context.Context, req DeleteMessageBatc
 	result, err := s.svc.DeleteMessageBatch(ctx, batchRequest)
 	if err != nil {
 		wrap := wrapBatchDeleteOutput(result, handles)
-		return wrap, goaws.NewInternalError(fmt.Errorf("s.svc.DeleteMessageBatch: %w", err))
+		return wrap, goaws.NewServiceError(fmt.Errorf("s.svc.DeleteMessageBatch: %w", err))
 	}
 	wrap := wrapBatchDeleteOutput(result, handles)
 	return wrap, nil
@@ -369,7 +691,7 @@ func (s *Messages) ChangeMessageVisibilityBatch(ctx context.Context, req BatchUp
 	if len(req.MessageIDs) == 0 {
 		return nil, NewNoMessageIDsInBatchRequestError()
 	}
-	if len(req.MessageIDs) > 10 {
+	if len(req.MessageIDs) > maxBatchEntries {
 		return nil, NewMaxMessagesExceededError(len(req.MessageIDs))
 	}
 
@@ -388,12 +710,48 @@ func (s *Messages) ChangeMessageVisibilityBatch(ctx context.Context, req BatchUp
 
 	output, err := s.svc.ChangeMessageVisibilityBatch(ctx, input)
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.ChangeMessageVisibilityBatch: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.ChangeMessageVisibilityBatch: %w", err))
 	}
 
 	return wrapBatchUpdateVisibilityTimeoutOutput(output), nil
 }
 
+// ChangeMessageVisibilityAll updates the visibility timeout for any number of messages by
+// calling ChangeMessageVisibilityBatch for each group of up to maxBatchEntries messages, and
+// returns the combined results. If a call fails, the results of the preceding calls are
+// returned with the error.
+func (s *Messages) ChangeMessageVisibilityAll(ctx context.Context, req BatchUpdateVisibilityTimeoutRequest) (*BatchUpdateVisibilityTimeoutResponse, error) {
+	if req.QueueURL == "" {
+		return nil, NewEmptyQueueUrlInRequestError()
+	}
+	if len(req.MessageIDs) != len(req.ReceiptHandles) {
+		return nil, NewInvalidReceiptHandlesError(len(req.MessageIDs), len(req.ReceiptHandles))
+	}
+	if len(req.MessageIDs) == 0 {
+		return nil, NewNoMessageIDsInBatchRequestError()
+	}
+
+	resp := &BatchUpdateVisibilityTimeoutResponse{
+		Successful: make([]BatchUpdateVisibilityTimeoutEntry, 0),
+		Failed:     make([]BatchUpdateVisibilityTimeoutErrEntry, 0),
+	}
+	for i := 0; i < len(req.MessageIDs); i += maxBatchEntries {
+		end := min(i+maxBatchEntries, len(req.MessageIDs))
+		chunk := req
+		chunk.MessageIDs = req.MessageIDs[i:end]
+		chunk.ReceiptHandles = req.ReceiptHandles[i:end]
+
+		out, err := s.ChangeMessageVisibilityBatch(ctx, chunk)
+		if err != nil {
+			return resp, err
+		}
+		resp.Successful = append(resp.Successful, out.Successful...)
+		resp.Failed = append(resp.Failed, out.Failed...)
+	}
+
+	return resp, nil
+}
+
 // wrap sqs.DeleteMessageBatchOutput object
 func wrapBatchUpdateVisibilityTimeoutOutput(output *sqs.ChangeMessageVisibilityBatchOutput) *BatchUpdateVisibilityTimeoutResponse {
 	wrapSuccessful := make([]BatchUpdateVisibilityTimeoutEntry, 0)
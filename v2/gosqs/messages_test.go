@@ -3,11 +3,18 @@ package gosqs
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -99,6 +106,242 @@ func TestSQSMessages_SendMessage(t *testing.T) {
 	}
 }
 
+func TestSQSMessages_SendMessage_RequestID(t *testing.T) {
+	responseError := func(status int) error {
+		return &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+				Err:      errors.New(http.StatusText(status)),
+			},
+			RequestID: "abc",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{name: "Forbidden", status: http.StatusForbidden},
+		{name: "InternalServerError", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := NewMockSQSMessagesClientAPI(ctrl)
+			mockSvc.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, responseError(tt.status)).Times(1)
+			s := &Messages{svc: mockSvc}
+
+			_, err := s.SendMessage(context.Background(), SendMsgOptions{
+				QueueURL:    "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+				MessageBody: "hello world",
+			})
+			require.Error(t, err)
+			var rid goaws.RequestIDer
+			require.ErrorAs(t, err, &rid)
+			assert.Equal(t, "abc", rid.RequestID())
+		})
+	}
+}
+
+func TestSQSMessages_SendMessage_IdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		capacity      int
+		window        time.Duration
+		keys          []string
+		wait          time.Duration
+		expectedCalls int
+	}{
+		{name: "DuplicateSuppressed", keys: []string{"a", "a"}, expectedCalls: 1},
+		{name: "DistinctKeys", keys: []string{"a", "b"}, expectedCalls: 2},
+		{name: "NoKey", keys: []string{"", ""}, expectedCalls: 2},
+		{name: "WithinWindow", window: time.Minute, keys: []string{"a", "a"}, wait: 59 * time.Second, expectedCalls: 1},
+		{name: "WindowExpired", window: time.Minute, keys: []string{"a", "a"}, wait: time.Minute + time.Second, expectedCalls: 2},
+		{name: "Evicted", capacity: 1, keys: []string{"a", "b", "a"}, expectedCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.SendMessageOutput{
+				MessageId: aws.String("msg-id-123"),
+			}, nil).Times(tt.expectedCalls)
+
+			clock := goaws.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			s := NewMessages(m).WithClock(clock).WithDedupe(tt.capacity, tt.window)
+
+			for _, key := range tt.keys {
+				res, err := s.SendMessage(context.Background(), SendMsgOptions{
+					QueueURL:       "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+					MessageBody:    "hello world",
+					IdempotencyKey: key,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, "msg-id-123", res.MessageId)
+				clock.Advance(tt.wait)
+			}
+		})
+	}
+}
+
+func TestSQSMessages_SendMessage_IdempotencyKeyConcurrent(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	gomock.InOrder(
+		// the first send fails, so one of the waiting callers claims the key and sends again
+		m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+				close(started)
+				<-release
+				return nil, errors.New("send error")
+			}).Times(1),
+		m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.SendMessageOutput{
+			MessageId: aws.String("msg-id-123"),
+		}, nil).Times(1),
+	)
+	s := NewMessages(m)
+
+	send := func() (*SendMsgResponse, error) {
+		return s.SendMessage(context.Background(), SendMsgOptions{
+			QueueURL:       "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+			MessageBody:    "hello world",
+			IdempotencyKey: "a",
+		})
+	}
+
+	firstErr := make(chan error)
+	go func() {
+		_, err := send()
+		firstErr <- err
+	}()
+	<-started
+
+	const callers = 10
+	resps := make([]*SendMsgResponse, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resps[i], errs[i] = send()
+		}()
+	}
+	close(release)
+	require.Error(t, <-firstErr)
+	wg.Wait()
+
+	for i := range callers {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "msg-id-123", resps[i].MessageId)
+	}
+}
+
+func TestSQSMessages_SendMessage_IdempotencyKeyCanceled(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+			close(started)
+			<-release
+			return &sqs.SendMessageOutput{MessageId: aws.String("msg-id-123")}, nil
+		}).Times(1)
+	s := NewMessages(m)
+
+	opts := SendMsgOptions{
+		QueueURL:       "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+		MessageBody:    "hello world",
+		IdempotencyKey: "a",
+	}
+	firstErr := make(chan error)
+	go func() {
+		_, err := s.SendMessage(context.Background(), opts)
+		firstErr <- err
+	}()
+	<-started
+
+	// a caller waiting on the in-flight send gives up when its ctx is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.SendMessage(ctx, opts)
+	require.Error(t, err)
+	assert.EqualError(t, err, "dedupe claim: context canceled")
+	assert.Implements(t, (*goaws.AwsError)(nil), err)
+
+	close(release)
+	require.NoError(t, <-firstErr)
+}
+
+func TestSQSMessages_SendMessage_MessageSize(t *testing.T) {
+	attrs := map[string]types.MessageAttributeValue{
+		"key": {DataType: aws.String("String"), StringValue: aws.String("value")}, // 3 + 6 + 5 bytes
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		attributes    map[string]types.MessageAttributeValue
+		maxSize       int
+		expectSend    bool
+		expectedError error
+	}{
+		{name: "AtDefaultLimit", body: strings.Repeat("a", DefaultMaximumMessageSize), expectSend: true},
+		{name: "OverDefaultLimit", body: strings.Repeat("a", DefaultMaximumMessageSize+1), expectedError: NewMessageTooLargeError(DefaultMaximumMessageSize+1, DefaultMaximumMessageSize)},
+		{name: "AttributesAtLimit", body: strings.Repeat("a", 1010), attributes: attrs, maxSize: 1024, expectSend: true},
+		{name: "AttributesOverLimit", body: strings.Repeat("a", 1011), attributes: attrs, maxSize: 1024, expectedError: NewMessageTooLargeError(1025, 1024)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			if tt.expectSend {
+				m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.SendMessageOutput{
+					MessageId: aws.String("msg-id-123"),
+				}, nil).Times(1)
+			}
+			s := &Messages{svc: m}
+
+			_, err := s.SendMessage(context.Background(), SendMsgOptions{
+				QueueURL:           "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+				MessageBody:        tt.body,
+				MessageAttributes:  tt.attributes,
+				MaximumMessageSize: tt.maxSize,
+			})
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrMessageTooLarge)
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestSQSMessages_ReceiveMessage(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -137,6 +380,42 @@ func TestSQSMessages_ReceiveMessage(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "FifoMessage",
+			opts: RecMsgOptions{
+				QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue.fifo",
+			},
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+					Messages: []types.Message{
+						{
+							Body:      aws.String("hello fifo"),
+							MessageId: aws.String("msg-id-456"),
+							Attributes: map[string]string{
+								"SequenceNumber": "18849496460467696128",
+								"MessageGroupId": "group-1",
+							},
+						},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expectedMsgs: []*Message{
+				{
+					Body:      "hello fifo",
+					MessageId: "msg-id-456",
+					Attributes: map[string]string{
+						"SequenceNumber": "18849496460467696128",
+						"MessageGroupId": "group-1",
+					},
+					MessageAttributes: map[string]MsgAV{},
+					SequenceNumber:    "18849496460467696128",
+					MessageGroupId:    "group-1",
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			name: "Error",
 			opts: RecMsgOptions{
@@ -169,15 +448,404 @@ func TestSQSMessages_ReceiveMessage(t *testing.T) {
 				assert.Implements(t, (*goaws.AwsError)(nil), err)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, len(tt.expectedMsgs), len(msgs.Messages))
-				if len(msgs.Messages) > 0 {
-					assert.Equal(t, tt.expectedMsgs[0].Body, msgs.Messages[0].Body)
+				assert.Equal(t, tt.expectedMsgs, msgs.Messages)
+			}
+		})
+	}
+}
+
+func TestSQSMessages_ReceiveMessage_WithTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        RecMsgOptions
+		maxDeadline time.Duration
+		hasDeadline bool
+	}{
+		{
+			name: "DeadlineSet",
+			opts: RecMsgOptions{
+				QueueURL:        "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+				WaitTimeSeconds: 2,
+				WithTimeout:     500 * time.Millisecond,
+			},
+			maxDeadline: 2500 * time.Millisecond,
+			hasDeadline: true,
+		},
+		{
+			name: "NoTimeout",
+			opts: RecMsgOptions{
+				QueueURL:        "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+				WaitTimeSeconds: 2,
+			},
+			hasDeadline: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				deadline time.Time
+				ok       bool
+			)
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, _ *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+					deadline, ok = ctx.Deadline()
+					return &sqs.ReceiveMessageOutput{}, nil
+				}).Times(1)
+			s := &Messages{svc: m}
+
+			start := time.Now()
+			_, err := s.ReceiveMessage(context.Background(), tt.opts)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.hasDeadline, ok)
+			if tt.hasDeadline {
+				assert.WithinDuration(t, start.Add(tt.maxDeadline), deadline, 100*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestSQSMessages_ReceiveMessage_Empty(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          RecMsgOptions
+		mockErr       error
+		expectedResp  *ReceiveMessageResponse
+		expectedError error
+	}{
+		{
+			name:         "EmptyQueue",
+			opts:         RecMsgOptions{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", WaitTimeSeconds: 20},
+			expectedResp: &ReceiveMessageResponse{Messages: []*Message{}, Empty: true, TimedOut: true},
+		},
+		{
+			name:          "CutOffByWithTimeout",
+			opts:          RecMsgOptions{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", WaitTimeSeconds: 20, WithTimeout: time.Second},
+			mockErr:       fmt.Errorf("operation error SQS: ReceiveMessage: %w", context.DeadlineExceeded),
+			expectedError: NewReceiveTimeoutError(fmt.Errorf("s.svc.ReceiveMessage: %w", fmt.Errorf("operation error SQS: ReceiveMessage: %w", context.DeadlineExceeded))),
+		},
+		{
+			name:          "DeadlineExceededWithoutTimeout",
+			opts:          RecMsgOptions{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", WaitTimeSeconds: 20},
+			mockErr:       context.DeadlineExceeded,
+			expectedError: goaws.NewInternalError(fmt.Errorf("s.svc.ReceiveMessage: %w", context.DeadlineExceeded)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			var out *sqs.ReceiveMessageOutput
+			if tt.mockErr == nil {
+				out = &sqs.ReceiveMessageOutput{}
+			}
+			m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(out, tt.mockErr).Times(1)
+			s := &Messages{svc: m}
+
+			resp, err := s.ReceiveMessage(context.Background(), tt.opts)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedResp, resp)
+				assert.NotNil(t, resp.Messages)
+			}
+		})
+	}
+}
+
+func TestMessageAttributeSet_Names(t *testing.T) {
+	tests := []struct {
+		name          string
+		set           MessageAttributeSet
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:     "Sorted",
+			set:      NewMessageAttributeSet("TenantId", "EventType"),
+			expected: []string{"EventType", "TenantId"},
+		},
+		{
+			name:     "Deduplicated",
+			set:      NewMessageAttributeSet("TenantId", "TenantId", "").Add("EventType").Add("TenantId"),
+			expected: []string{"EventType", "TenantId"},
+		},
+		{
+			name:     "Empty",
+			set:      NewMessageAttributeSet(),
+			expected: []string{},
+		},
+		{
+			name:     "AtLimit",
+			set:      NewMessageAttributeSet("a0", "a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9"),
+			expected: []string{"a0", "a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9"},
+		},
+		{
+			name:          "OverLimit",
+			set:           NewMessageAttributeSet("a0", "a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9", "a10"),
+			expectedError: NewTooManyMessageAttributesError(11, MaxMessageAttributeNames),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			names, err := tt.set.Names()
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.ErrorIs(t, err, ErrTooManyMessageAttributes)
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSQSMessages_ReceiveMessage_MessageAttributeSet(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	names, err := NewMessageAttributeSet("TenantId", "EventType").Names()
+	require.NoError(t, err)
+
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			assert.Equal(t, []string{"EventType", "TenantId"}, in.MessageAttributeNames)
+			assert.Empty(t, in.AttributeNames)
+			return &sqs.ReceiveMessageOutput{}, nil
+		}).Times(1)
+	s := &Messages{svc: m}
+
+	_, err = s.ReceiveMessage(context.Background(), RecMsgOptions{
+		QueueURL:              "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+		MessageAttributeNames: names,
+	})
+	require.NoError(t, err)
+}
+
+func TestReceiveTyped(t *testing.T) {
+	type order struct {
+		ID    string `json:"id"`
+		Total int    `json:"total"`
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(ctrl *gomock.Controller) SQSMessagesClientAPI
+		expected       []TypedMessage[order]
+		expectedFailed []string
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+					Messages: []types.Message{
+						{MessageId: aws.String("msg-1"), ReceiptHandle: aws.String("handle-1"), Body: aws.String(`{"id":"a1","total":42}`)},
+						{MessageId: aws.String("msg-2"), ReceiptHandle: aws.String("handle-2"), Body: aws.String(`{"id":"b2","total":7}`)},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected: []TypedMessage[order]{
+				{MessageId: "msg-1", ReceiptHandle: "handle-1", Body: order{ID: "a1", Total: 42}},
+				{MessageId: "msg-2", ReceiptHandle: "handle-2", Body: order{ID: "b2", Total: 7}},
+			},
+		},
+		{
+			name: "InvalidBody",
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+					Messages: []types.Message{
+						{MessageId: aws.String("msg-1"), ReceiptHandle: aws.String("handle-1"), Body: aws.String("not json")},
+						{MessageId: aws.String("msg-2"), ReceiptHandle: aws.String("handle-2"), Body: aws.String(`{"id":"b2","total":7}`)},
+						{MessageId: aws.String("msg-3"), ReceiptHandle: aws.String("handle-3"), Body: aws.String(`{"id":3}`)},
+					},
+				}, nil).Times(1)
+				return m
+			},
+			expected: []TypedMessage[order]{
+				{MessageId: "msg-2", ReceiptHandle: "handle-2", Body: order{ID: "b2", Total: 7}},
+			},
+			expectedFailed: []string{"msg-1", "msg-3"},
+			expectedError: errors.New("json.Unmarshal: message msg-1: invalid character 'o' in literal null (expecting 'u')\n" +
+				"message msg-3: json: cannot unmarshal number into Go struct field order.id of type string"),
+		},
+		{
+			name: "ReceiveError",
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("receive error")).Times(1)
+				return m
+			},
+			expectedError: errors.New("s.svc.ReceiveMessage: receive error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &Messages{svc: tt.mockSetup(ctrl)}
+
+			msgs, err := ReceiveTyped[order](context.Background(), s, RecMsgOptions{
+				QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+			})
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				if tt.expectedFailed == nil {
+					assert.Nil(t, msgs)
+					return
+				}
+				var decodeErr *BodyDecodeError
+				require.ErrorAs(t, err, &decodeErr)
+				failed := make([]string, 0, len(decodeErr.Failures))
+				for _, f := range decodeErr.Failures {
+					failed = append(failed, f.Message.MessageId)
 				}
+				assert.Equal(t, tt.expectedFailed, failed)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, msgs, len(tt.expected))
+			for i, msg := range msgs {
+				assert.Equal(t, tt.expected[i].MessageId, msg.MessageId)
+				assert.Equal(t, tt.expected[i].ReceiptHandle, msg.ReceiptHandle)
+				assert.Equal(t, tt.expected[i].Body, msg.Body)
+				require.NotNil(t, msg.Raw)
+				assert.Equal(t, msg.ReceiptHandle, msg.Raw.ReceiptHandle)
 			}
 		})
 	}
 }
 
+func TestConvertMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      types.Message
+		expected *Message
+	}{
+		{
+			name: "SystemAttributes",
+			msg: types.Message{
+				Body:      aws.String("hello world"),
+				MessageId: aws.String("msg-id-123"),
+				Attributes: map[string]string{
+					"ApproximateReceiveCount":          "3",
+					"SentTimestamp":                    "1700000000000",
+					"ApproximateFirstReceiveTimestamp": "1700000001500",
+				},
+			},
+			expected: &Message{
+				Body:      "hello world",
+				MessageId: "msg-id-123",
+				Attributes: map[string]string{
+					"ApproximateReceiveCount":          "3",
+					"SentTimestamp":                    "1700000000000",
+					"ApproximateFirstReceiveTimestamp": "1700000001500",
+				},
+				MessageAttributes:                map[string]MsgAV{},
+				ApproximateReceiveCount:          3,
+				SentTimestamp:                    time.UnixMilli(1700000000000),
+				ApproximateFirstReceiveTimestamp: time.UnixMilli(1700000001500),
+			},
+		},
+		{
+			name: "FifoAttributes",
+			msg: types.Message{
+				Body:      aws.String("hello fifo"),
+				MessageId: aws.String("msg-id-456"),
+				Attributes: map[string]string{
+					"SequenceNumber": "18849496460467696128",
+					"MessageGroupId": "group-1",
+				},
+			},
+			expected: &Message{
+				Body:      "hello fifo",
+				MessageId: "msg-id-456",
+				Attributes: map[string]string{
+					"SequenceNumber": "18849496460467696128",
+					"MessageGroupId": "group-1",
+				},
+				MessageAttributes: map[string]MsgAV{},
+				SequenceNumber:    "18849496460467696128",
+				MessageGroupId:    "group-1",
+			},
+		},
+		{
+			name: "TraceHeader",
+			msg: types.Message{
+				Body:      aws.String("traced"),
+				MessageId: aws.String("msg-id-789"),
+				Attributes: map[string]string{
+					"AWSTraceHeader": "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+				},
+			},
+			expected: &Message{
+				Body:      "traced",
+				MessageId: "msg-id-789",
+				Attributes: map[string]string{
+					"AWSTraceHeader": "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+				},
+				MessageAttributes: map[string]MsgAV{},
+				TraceHeader:       "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+			},
+		},
+		{
+			name: "MalformedAttributes",
+			msg: types.Message{
+				MessageId: aws.String("msg-id-123"),
+				Attributes: map[string]string{
+					"ApproximateReceiveCount": "many",
+					"SentTimestamp":           "yesterday",
+				},
+			},
+			expected: &Message{
+				MessageId: "msg-id-123",
+				Attributes: map[string]string{
+					"ApproximateReceiveCount": "many",
+					"SentTimestamp":           "yesterday",
+				},
+				MessageAttributes: map[string]MsgAV{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, convertMessage(tt.msg))
+		})
+	}
+}
+
 func TestSQSMessages_DeleteMessage(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -232,6 +900,151 @@ func TestSQSMessages_DeleteMessage(t *testing.T) {
 	}
 }
 
+func TestSQSMessages_Retries(t *testing.T) {
+	const url = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	overLimit := &types.OverLimit{Message: aws.String("too many requests")}
+	serviceUnavailable := &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+				},
+			},
+			Err: errors.New("service unavailable"),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		fc            *goaws.FailConfig
+		mockSetup     func(ctrl *gomock.Controller) SQSMessagesClientAPI
+		call          func(s *Messages) error
+		expectedError error
+	}{
+		{
+			name: "SendMessage",
+			fc:   goaws.NewFailConfig(1, 5, 1),
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				gomock.InOrder(
+					m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, overLimit).Times(1),
+					m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.SendMessageOutput{
+						MessageId: aws.String("msg-id-123"),
+					}, nil).Times(1),
+				)
+				return m
+			},
+			call: func(s *Messages) error {
+				res, err := s.SendMessage(context.Background(), SendMsgOptions{QueueURL: url, MessageBody: "hello world"})
+				if err == nil {
+					assert.Equal(t, "msg-id-123", res.MessageId)
+				}
+				return err
+			},
+		},
+		{
+			name: "ReceiveMessage",
+			fc:   goaws.NewFailConfig(1, 5, 1),
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				gomock.InOrder(
+					m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.KmsThrottled{}).Times(1),
+					m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+						Messages: []types.Message{{MessageId: aws.String("msg-id-123"), Body: aws.String("hello world")}},
+					}, nil).Times(1),
+				)
+				return m
+			},
+			call: func(s *Messages) error {
+				res, err := s.ReceiveMessage(context.Background(), RecMsgOptions{QueueURL: url})
+				if err == nil {
+					require.Len(t, res.Messages, 1)
+					assert.Equal(t, "hello world", res.Messages[0].Body)
+				}
+				return err
+			},
+		},
+		{
+			name: "DeleteMessage",
+			fc:   goaws.NewFailConfig(1, 5, 1),
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				gomock.InOrder(
+					m.EXPECT().DeleteMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, serviceUnavailable).Times(2),
+					m.EXPECT().DeleteMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.DeleteMessageOutput{}, nil).Times(1),
+				)
+				return m
+			},
+			call: func(s *Messages) error {
+				return s.DeleteMessage(context.Background(), url, "handle-123")
+			},
+		},
+		{
+			name: "RetriesDisabled",
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, overLimit).Times(1)
+				return m
+			},
+			call: func(s *Messages) error {
+				_, err := s.SendMessage(context.Background(), SendMsgOptions{QueueURL: url, MessageBody: "hello world"})
+				return err
+			},
+			expectedError: NewThrottledError(fmt.Errorf("s.svc.SendMessage: %w", overLimit)),
+		},
+		{
+			name: "MaxRetriesExceeded",
+			fc:   goaws.NewFailConfig(1, 5, 1),
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().DeleteMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, overLimit).MinTimes(2)
+				return m
+			},
+			call: func(s *Messages) error {
+				return s.DeleteMessage(context.Background(), url, "handle-123")
+			},
+			expectedError: NewThrottledError(fmt.Errorf("s.svc.DeleteMessage: %w", overLimit)),
+		},
+		{
+			name: "NotThrottled",
+			fc:   goaws.NewFailConfig(1, 5, 1),
+			mockSetup: func(ctrl *gomock.Controller) SQSMessagesClientAPI {
+				m := NewMockSQSMessagesClientAPI(ctrl)
+				m.EXPECT().DeleteMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("delete error")).Times(1)
+				return m
+			},
+			call: func(s *Messages) error {
+				return s.DeleteMessage(context.Background(), url, "handle-123")
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.DeleteMessage: delete error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewMessages(tt.mockSetup(ctrl))
+			if tt.fc != nil {
+				clock := goaws.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+				s = s.WithFailConfig(tt.fc.WithClock(clock))
+			}
+
+			err := tt.call(s)
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				assert.Equal(t, errors.Is(tt.expectedError, ErrThrottled), errors.Is(err, ErrThrottled))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestSQSMessages_DeleteMessageBatch(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -377,3 +1190,137 @@ func TestSQSMessages_ChangeMessageVisibilityBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestSQSMessages_ChangeMessageVisibilityAll(t *testing.T) {
+	ids := make([]string, 15)
+	handles := make([]string, 15)
+	for i := range 15 {
+		ids[i] = fmt.Sprintf("msg-%d", i)
+		handles[i] = fmt.Sprintf("handle-%d", i)
+	}
+	req := BatchUpdateVisibilityTimeoutRequest{
+		QueueURL:       "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+		MessageIDs:     ids,
+		ReceiptHandles: handles,
+		TimeoutSeconds: 30,
+	}
+
+	// echo returns each entry as successful, except msg-12 which fails.
+	echo := func(t *testing.T, expectedLen int) func(context.Context, *sqs.ChangeMessageVisibilityBatchInput, ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityBatchOutput, error) {
+		return func(_ context.Context, in *sqs.ChangeMessageVisibilityBatchInput, _ ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityBatchOutput, error) {
+			assert.Len(t, in.Entries, expectedLen)
+			out := &sqs.ChangeMessageVisibilityBatchOutput{}
+			for _, e := range in.Entries {
+				assert.Equal(t, int32(30), e.VisibilityTimeout)
+				if aws.ToString(e.Id) == "msg-12" {
+					out.Failed = append(out.Failed, types.BatchResultErrorEntry{
+						Id:      e.Id,
+						Code:    aws.String("ReceiptHandleIsInvalid"),
+						Message: aws.String("invalid handle"),
+					})
+					continue
+				}
+				out.Successful = append(out.Successful, types.ChangeMessageVisibilityBatchResultEntry{Id: e.Id})
+			}
+			return out, nil
+		}
+	}
+
+	tests := []struct {
+		name              string
+		mockSetup         func(t *testing.T, m *MockSQSMessagesClientAPI)
+		expectedSucceeded int
+		expectedFailed    []BatchUpdateVisibilityTimeoutErrEntry
+		expectedError     error
+	}{
+		{
+			name: "Success",
+			mockSetup: func(t *testing.T, m *MockSQSMessagesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().ChangeMessageVisibilityBatch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(echo(t, 10)).Times(1),
+					m.EXPECT().ChangeMessageVisibilityBatch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(echo(t, 5)).Times(1),
+				)
+			},
+			expectedSucceeded: 14,
+			expectedFailed: []BatchUpdateVisibilityTimeoutErrEntry{
+				{ErrorCode: "ReceiptHandleIsInvalid", MessageId: "msg-12", ErrorMessage: "invalid handle"},
+			},
+		},
+		{
+			name: "Error",
+			mockSetup: func(t *testing.T, m *MockSQSMessagesClientAPI) {
+				gomock.InOrder(
+					m.EXPECT().ChangeMessageVisibilityBatch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(echo(t, 10)).Times(1),
+					m.EXPECT().ChangeMessageVisibilityBatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("change visibility error")).Times(1),
+				)
+			},
+			expectedSucceeded: 10,
+			expectedFailed:    []BatchUpdateVisibilityTimeoutErrEntry{},
+			expectedError:     goaws.NewInternalError(errors.New("s.svc.ChangeMessageVisibilityBatch: change visibility error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := NewMessages(m)
+
+			res, err := s.ChangeMessageVisibilityAll(context.Background(), req)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, res)
+			assert.Len(t, res.Successful, tt.expectedSucceeded)
+			assert.Equal(t, tt.expectedFailed, res.Failed)
+		})
+	}
+
+	t.Run("EmptyRequest", func(t *testing.T) {
+		t.Parallel()
+		s := NewMessages(NewMockSQSMessagesClientAPI(gomock.NewController(t)))
+		_, err := s.ChangeMessageVisibilityAll(context.Background(), BatchUpdateVisibilityTimeoutRequest{QueueURL: req.QueueURL})
+		assert.ErrorIs(t, err, ErrNoMessageIDsInBatchRequest)
+	})
+}
+
+func TestGenerateDedupeID(t *testing.T) {
+	id := GenerateDedupeID("hello")
+	assert.Equal(t, "5d41402abc4b2a76b9719d911017c592", id)
+	assert.Equal(t, id, GenerateDedupeID("hello"))
+	assert.NotEqual(t, id, GenerateDedupeID("hello world"))
+}
+
+func TestMessageGroupIDForKey(t *testing.T) {
+	const groups = 16
+
+	counts := make(map[string]int)
+	for i := 0; i < 1600; i++ {
+		key := fmt.Sprintf("customer-%d", i)
+		id := MessageGroupIDForKey(key, groups)
+		assert.Equal(t, id, MessageGroupIDForKey(key, groups))
+		counts[id]++
+	}
+
+	// every group is used and no group holds more than twice its share
+	assert.Len(t, counts, groups)
+	for id, n := range counts {
+		assert.Regexp(t, `^group-(\d|1[0-5])$`, id)
+		assert.LessOrEqual(t, n, 2*1600/groups)
+	}
+
+	assert.Equal(t, GenerateDedupeID("customer-1"), MessageGroupIDForKey("customer-1", 0))
+
+	opts := SendMsgDefault
+	opts.SetPartitionKey("customer-1", groups)
+	assert.Equal(t, MessageGroupIDForKey("customer-1", groups), opts.MessageGroupId)
+}
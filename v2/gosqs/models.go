@@ -1,6 +1,9 @@
 package gosqs
 
 import (
+	"slices"
+	"time"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
@@ -50,6 +53,34 @@ type GetQueueUrlResponse struct {
 	QueueUrl string `json:"queue_url"`
 }
 
+// PurgeQueueOptions guards PurgeQueueWithOptions. If DryRun is set, the queue's
+// ApproximateNumberOfMessages is returned without purging. If Confirm is set, it
+// is called with ApproximateNumberOfMessages and the queue is only purged if it returns true.
+type PurgeQueueOptions struct {
+	DryRun  bool
+	Confirm func(approximateNumberOfMessages int) bool
+}
+
+// PurgeQueueResponse reports the queue's ApproximateNumberOfMessages before the
+// purge, if it was checked, and whether the queue was purged.
+type PurgeQueueResponse struct {
+	ApproximateNumberOfMessages int  `json:"approximate_number_of_messages"`
+	Purged                      bool `json:"purged"`
+}
+
+// DefaultMaximumMessageSize is the default SQS queue MaximumMessageSize, in bytes.
+const DefaultMaximumMessageSize = 262144
+
+// maxBatchEntries is the maximum number of entries in a batch request.
+const maxBatchEntries = 10
+
+// MaxMessageAttributeNames is the maximum number of message attribute names
+// accepted by a MessageAttributeSet.
+const MaxMessageAttributeNames = 10
+
+// MaxMessageAttributes is the maximum number of message attributes SQS accepts on a message.
+const MaxMessageAttributes = 10
+
 // SendMsgDefault contains the default options for the sqs.SendMessageInput object.
 var SendMsgDefault = SendMsgOptions{
 	DelaySeconds:            0,
@@ -70,6 +101,18 @@ type SendMsgOptions struct {
 	MessageGroupId          string
 	MessageSystemAttributes map[string]types.MessageSystemAttributeValue
 	QueueURL                string
+	// IdempotencyKey, if set, suppresses resending a message with the same key
+	// within the dedupe window. Applies to standard and FIFO queues.
+	IdempotencyKey string
+	// MaximumMessageSize is the queue's MaximumMessageSize attribute in bytes.
+	// Defaults to DefaultMaximumMessageSize if zero.
+	MaximumMessageSize int
+}
+
+// SetPartitionKey sets o.MessageGroupId to the group derived from partitionKey
+// by MessageGroupIDForKey.
+func (o *SendMsgOptions) SetPartitionKey(partitionKey string, groups int) {
+	o.MessageGroupId = MessageGroupIDForKey(partitionKey, groups)
 }
 
 // SendMessageResponse wraps the sqs.SendMessageOutput object
@@ -94,29 +137,104 @@ var RecMsgDefault = RecMsgOptions{
 
 // RecMsgOptions is used to pass receive message options to the sqs.ReceiveMessageInput object.
 type RecMsgOptions struct {
-	AttributeNames          []types.QueueAttributeName
-	MaxNumberOfMessages     int32
+	// AttributeNames must include "All" or "AWSTraceHeader" for Message.TraceHeader to be set.
+	AttributeNames      []types.QueueAttributeName
+	MaxNumberOfMessages int32
+	// MessageAttributeNames may be built from a MessageAttributeSet to request a subset.
 	MessageAttributeNames   []string
 	QueueURL                string
 	ReceiveRequestAttemptId string
 	VisibilityTimeout       int32
 	WaitTimeSeconds         int32
+	// WithTimeout bounds the receive call with a child context that expires
+	// after WaitTimeSeconds + WithTimeout. No deadline is set if zero.
+	// A receive cut off by this deadline returns a ReceiveTimeoutError.
+	WithTimeout time.Duration
+}
+
+// MessageAttributeSet is a set of message attribute names to request on receive.
+// Requesting only the attributes a consumer reads, rather than "All", keeps
+// responses small.
+//
+//	names, err := NewMessageAttributeSet("TenantId", "EventType").Names()
+//	opts := RecMsgOptions{QueueURL: url, MessageAttributeNames: names}
+type MessageAttributeSet map[string]struct{}
+
+// NewMessageAttributeSet constructs a MessageAttributeSet from the given names.
+func NewMessageAttributeSet(names ...string) MessageAttributeSet {
+	s := make(MessageAttributeSet, len(names))
+	for _, name := range names {
+		s.Add(name)
+	}
+	return s
 }
 
-// ReceiveMessageResponse contains an array of messages received from SQS
+// Add adds the given name to the set. Empty names are ignored.
+func (s MessageAttributeSet) Add(name string) MessageAttributeSet {
+	if name != "" {
+		s[name] = struct{}{}
+	}
+	return s
+}
+
+// Names returns the set's names, sorted, for use as RecMsgOptions.MessageAttributeNames.
+// Returns TooManyMessageAttributesError if the set holds more than MaxMessageAttributeNames names.
+func (s MessageAttributeSet) Names() ([]string, error) {
+	if len(s) > MaxMessageAttributeNames {
+		return nil, NewTooManyMessageAttributesError(len(s), MaxMessageAttributeNames)
+	}
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// ReceiveMessageResponse contains an array of messages received from SQS.
+// Messages is never nil. Empty is set if no messages were received, and TimedOut
+// is set if the long poll expired after WaitTimeSeconds without a message, i.e.
+// the queue was empty for the whole long poll.
 type ReceiveMessageResponse struct {
 	Messages []*Message `json:"messages"`
+	Empty    bool       `json:"empty"`
+	TimedOut bool       `json:"timed_out"`
 }
 
 // Message wraps the sqs.Message type.
+// ApproximateReceiveCount, SentTimestamp, ApproximateFirstReceiveTimestamp, the
+// FIFO SequenceNumber and MessageGroupId, and the X-Ray TraceHeader are parsed from
+// the message's system attributes when present.
 type Message struct {
-	Attributes              map[string]string `json:"attributes"`
-	Body                    string            `json:"body"`
-	MD5OfBody               string            `json:"md5_of_body"`
-	MD5OfMessagefAttributes string            `json:"md5_of_message_attributes"`
-	MessageAttributes       map[string]MsgAV  `json:"message_attributes"`
-	MessageId               string            `json:"message_id"`
-	ReceiptHandle           string            `json:"receipt_handle"`
+	Attributes                       map[string]string `json:"attributes"`
+	Body                             string            `json:"body"`
+	MD5OfBody                        string            `json:"md5_of_body"`
+	MD5OfMessagefAttributes          string            `json:"md5_of_message_attributes"`
+	MessageAttributes                map[string]MsgAV  `json:"message_attributes"`
+	MessageId                        string            `json:"message_id"`
+	ReceiptHandle                    string            `json:"receipt_handle"`
+	ApproximateReceiveCount          int               `json:"approximate_receive_count"`
+	SentTimestamp                    time.Time         `json:"sent_timestamp"`
+	ApproximateFirstReceiveTimestamp time.Time         `json:"approximate_first_receive_timestamp"`
+	SequenceNumber                   string            `json:"sequence_number,omitempty"`
+	MessageGroupId                   string            `json:"message_group_id,omitempty"`
+	TraceHeader                      string            `json:"trace_header,omitempty"`
+}
+
+// TypedMessage holds a received message whose Body was unmarshaled from JSON into T.
+// ReceiptHandle is retained for deleting the message once it is processed; Raw holds
+// the original message including its attributes.
+type TypedMessage[T any] struct {
+	MessageId     string   `json:"message_id"`
+	ReceiptHandle string   `json:"receipt_handle"`
+	Body          T        `json:"body"`
+	Raw           *Message `json:"-"`
+}
+
+// DecodeFailure is a received message whose Body couldn't be unmarshaled by ReceiveTyped.
+type DecodeFailure struct {
+	Message *Message
+	Err     error
 }
 
 // MsgAV represents a single sqs.MessageAttributeValue or sqs.MessageSystemAttributeValue object.
@@ -0,0 +1,72 @@
+package gosqs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/ggarcia209/go-aws-v2/v2/gos3"
+)
+
+// ExtendedPayloadSizeAttribute is the message attribute holding the original body size of
+// messages whose body was offloaded to S3. The attribute name and pointer format match the
+// AWS SQS extended client libraries, so offloaded messages can be read by either.
+const ExtendedPayloadSizeAttribute = "ExtendedPayloadSize"
+
+const payloadPointerClass = "software.amazon.payloadoffloading.PayloadS3Pointer"
+
+// payloadOffloader stores message bodies that exceed the queue's maximum message size in S3.
+type payloadOffloader struct {
+	store  gos3.S3Logic
+	bucket string
+}
+
+type payloadPointer struct {
+	S3BucketName string `json:"s3BucketName"`
+	S3Key        string `json:"s3Key"`
+}
+
+// upload stores body in S3 and returns the pointer sent in its place.
+func (o *payloadOffloader) upload(ctx context.Context, body string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("rand.Read: %w", err))
+	}
+	key := hex.EncodeToString(b)
+
+	if _, err := o.store.UploadFile(ctx, gos3.UploadFileRequest{
+		Bucket: o.bucket,
+		Key:    key,
+		File:   strings.NewReader(body),
+	}); err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("o.store.UploadFile: %w", err))
+	}
+
+	pointer, err := json.Marshal([]any{payloadPointerClass, payloadPointer{S3BucketName: o.bucket, S3Key: key}})
+	if err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("json.Marshal: %w", err))
+	}
+	return string(pointer), nil
+}
+
+// download returns the body stored in S3 for the given pointer.
+func (o *payloadOffloader) download(ctx context.Context, pointer string) (string, error) {
+	var parts []json.RawMessage
+	if err := json.Unmarshal([]byte(pointer), &parts); err != nil || len(parts) != 2 {
+		return "", NewInvalidMessageContentError(&pointer)
+	}
+	var ptr payloadPointer
+	if err := json.Unmarshal(parts[1], &ptr); err != nil || ptr.S3BucketName == "" || ptr.S3Key == "" {
+		return "", NewInvalidMessageContentError(&pointer)
+	}
+
+	resp, err := o.store.GetObject(ctx, gos3.GetFileRequest{Bucket: ptr.S3BucketName, Key: ptr.S3Key})
+	if err != nil {
+		return "", goaws.NewInternalError(fmt.Errorf("o.store.GetObject: %w", err))
+	}
+	return string(resp.File), nil
+}
@@ -0,0 +1,228 @@
+package gosqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/ggarcia209/go-aws-v2/v2/goaws"
+	"github.com/ggarcia209/go-aws-v2/v2/gos3"
+	"github.com/ggarcia209/go-aws-v2/v2/mocks/gos3mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const testQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+func TestSQSMessages_PayloadOffloading_RoundTrip(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	largeBody := strings.Repeat("a", DefaultMaximumMessageSize+1)
+	stored := make(map[string]string)
+
+	store := gos3mock.NewMockS3Logic(ctrl)
+	store.EXPECT().UploadFile(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req gos3.UploadFileRequest) (*gos3.UploadFileResponse, error) {
+			assert.Equal(t, "payload-bucket", req.Bucket)
+			b, err := io.ReadAll(req.File)
+			require.NoError(t, err)
+			stored[req.Key] = string(b)
+			return &gos3.UploadFileResponse{}, nil
+		}).Times(1)
+	store.EXPECT().GetObject(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req gos3.GetFileRequest) (*gos3.GetObjectResponse, error) {
+			assert.Equal(t, "payload-bucket", req.Bucket)
+			body, ok := stored[req.Key]
+			require.True(t, ok)
+			return &gos3.GetObjectResponse{File: []byte(body)}, nil
+		}).Times(1)
+
+	var sent *sqs.SendMessageInput
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+			sent = in
+			return &sqs.SendMessageOutput{MessageId: aws.String("msg-1")}, nil
+		}).Times(1)
+	m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			assert.Contains(t, in.MessageAttributeNames, ExtendedPayloadSizeAttribute)
+			return &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{{
+					MessageId:         aws.String("msg-1"),
+					ReceiptHandle:     aws.String("handle-1"),
+					Body:              sent.MessageBody,
+					MessageAttributes: sent.MessageAttributes,
+				}},
+			}, nil
+		}).Times(1)
+
+	s := NewMessages(m).WithPayloadOffloading(store, "payload-bucket")
+
+	_, err := s.SendMessage(context.Background(), SendMsgOptions{
+		QueueURL:    testQueueURL,
+		MessageBody: largeBody,
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"type": {DataType: aws.String("String"), StringValue: aws.String("report")},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, sent)
+	assert.Contains(t, aws.ToString(sent.MessageBody), payloadPointerClass)
+	assert.Less(t, len(aws.ToString(sent.MessageBody)), DefaultMaximumMessageSize)
+	assert.Equal(t, "Number", aws.ToString(sent.MessageAttributes[ExtendedPayloadSizeAttribute].DataType))
+	assert.Equal(t, "262145", aws.ToString(sent.MessageAttributes[ExtendedPayloadSizeAttribute].StringValue))
+	assert.Equal(t, "report", aws.ToString(sent.MessageAttributes["type"].StringValue))
+
+	resp, err := s.ReceiveMessage(context.Background(), RecMsgOptions{QueueURL: testQueueURL})
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 1)
+	assert.Equal(t, largeBody, resp.Messages[0].Body)
+	assert.Equal(t, "handle-1", resp.Messages[0].ReceiptHandle)
+}
+
+func TestSQSMessages_PayloadOffloading_Send(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		attributes    int
+		storeSetup    func(m *gos3mock.MockS3Logic)
+		expectSend    bool
+		expectedError error
+	}{
+		{
+			name:       "SmallBodyNotOffloaded",
+			body:       "hello world",
+			storeSetup: func(m *gos3mock.MockS3Logic) {},
+			expectSend: true,
+		},
+		{
+			name: "UploadError",
+			body: strings.Repeat("a", DefaultMaximumMessageSize+1),
+			storeSetup: func(m *gos3mock.MockS3Logic) {
+				m.EXPECT().UploadFile(gomock.Any(), gomock.Any()).Return(nil, errors.New("upload error")).Times(1)
+			},
+			expectedError: errors.New("o.store.UploadFile: upload error"),
+		},
+		{
+			// the pointer's size attribute would be the 11th attribute
+			name:          "TooManyAttributes",
+			body:          strings.Repeat("a", DefaultMaximumMessageSize+1),
+			attributes:    MaxMessageAttributes,
+			storeSetup:    func(m *gos3mock.MockS3Logic) {},
+			expectedError: NewTooManyMessageAttributesError(MaxMessageAttributes+1, MaxMessageAttributes),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := gos3mock.NewMockS3Logic(ctrl)
+			tt.storeSetup(store)
+			m := NewMockSQSMessagesClientAPI(ctrl)
+			if tt.expectSend {
+				m.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+						assert.Equal(t, tt.body, aws.ToString(in.MessageBody))
+						assert.NotContains(t, in.MessageAttributes, ExtendedPayloadSizeAttribute)
+						return &sqs.SendMessageOutput{MessageId: aws.String("msg-1")}, nil
+					}).Times(1)
+			}
+			s := NewMessages(m).WithPayloadOffloading(store, "payload-bucket")
+
+			attributes := make(map[string]types.MessageAttributeValue, tt.attributes)
+			for i := range tt.attributes {
+				attributes[fmt.Sprintf("attr%d", i)] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("v")}
+			}
+			_, err := s.SendMessage(context.Background(), SendMsgOptions{QueueURL: testQueueURL, MessageBody: tt.body, MessageAttributes: attributes})
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSQSMessages_PayloadOffloading_InvalidPointer(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.ReceiveMessageOutput{
+		Messages: []types.Message{{
+			MessageId: aws.String("msg-1"),
+			Body:      aws.String("not a pointer"),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				ExtendedPayloadSizeAttribute: {DataType: aws.String("Number"), StringValue: aws.String("300000")},
+			},
+		}},
+	}, nil).Times(1)
+	s := NewMessages(m).WithPayloadOffloading(gos3mock.NewMockS3Logic(ctrl), "payload-bucket")
+
+	_, err := s.ReceiveMessage(context.Background(), RecMsgOptions{QueueURL: testQueueURL})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidMessageContent)
+}
+
+func TestSQSMessages_PayloadOffloading_ReceiveWithTimeout(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := gos3mock.NewMockS3Logic(ctrl)
+	store.EXPECT().GetObject(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req gos3.GetFileRequest) (*gos3.GetObjectResponse, error) {
+			// the download isn't bounded by the receive deadline
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			return &gos3.GetObjectResponse{File: []byte("large body")}, nil
+		}).Times(1)
+
+	m := NewMockSQSMessagesClientAPI(ctrl)
+	m.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			return &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{{
+					MessageId: aws.String("msg-1"),
+					Body:      aws.String(`["` + payloadPointerClass + `",{"s3BucketName":"payload-bucket","s3Key":"key-1"}]`),
+					MessageAttributes: map[string]types.MessageAttributeValue{
+						ExtendedPayloadSizeAttribute: {DataType: aws.String("Number"), StringValue: aws.String("300000")},
+					},
+				}},
+			}, nil
+		}).Times(1)
+	s := NewMessages(m).WithPayloadOffloading(store, "payload-bucket")
+
+	resp, err := s.ReceiveMessage(context.Background(), RecMsgOptions{QueueURL: testQueueURL, WithTimeout: time.Second})
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 1)
+	assert.Equal(t, "large body", resp.Messages[0].Body)
+}
+
+func TestWithAttributeName(t *testing.T) {
+	assert.Equal(t, []string{ExtendedPayloadSizeAttribute}, withAttributeName(nil, ExtendedPayloadSizeAttribute))
+	assert.Equal(t, []string{"type", ExtendedPayloadSizeAttribute}, withAttributeName([]string{"type"}, ExtendedPayloadSizeAttribute))
+	assert.Equal(t, []string{"All"}, withAttributeName([]string{"All"}, ExtendedPayloadSizeAttribute))
+	assert.Equal(t, []string{ExtendedPayloadSizeAttribute}, withAttributeName([]string{ExtendedPayloadSizeAttribute}, ExtendedPayloadSizeAttribute))
+}
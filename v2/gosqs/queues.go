@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
@@ -22,6 +23,9 @@ type QueuesLogic interface {
 	GetQueueURL(ctx context.Context, name string) (*GetQueueUrlResponse, error)
 	DeleteQueue(ctx context.Context, url string) error
 	PurgeQueue(ctx context.Context, url string) error
+	PurgeQueueWithOptions(ctx context.Context, url string, options PurgeQueueOptions) (*PurgeQueueResponse, error)
+	GetQueueArn(ctx context.Context, url string) (string, error)
+	SetQueuePolicy(ctx context.Context, url, policy string) error
 }
 
 // SQSQueuesClientAPI defines the interface for the AWS SQS client methods used by this package.
@@ -32,6 +36,8 @@ type SQSQueuesClientAPI interface {
 	GetQueueUrl(ctx context.Context, params *sqs.GetQueueUrlInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error)
 	DeleteQueue(ctx context.Context, params *sqs.DeleteQueueInput, optFns ...func(*sqs.Options)) (*sqs.DeleteQueueOutput, error)
 	PurgeQueue(ctx context.Context, params *sqs.PurgeQueueInput, optFns ...func(*sqs.Options)) (*sqs.PurgeQueueOutput, error)
+	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	SetQueueAttributes(ctx context.Context, params *sqs.SetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.SetQueueAttributesOutput, error)
 }
 
 // SQSQueuesLogic implements Queues logic
@@ -75,7 +81,7 @@ func (s *Queues) CreateQueue(ctx context.Context, name string, options QueueOpti
 	}
 	result, err := s.svc.CreateQueue(ctx, input)
 	if err != nil {
-		return nil, goaws.NewInternalError(fmt.Errorf("s.svc.CreateQueue: %w", err))
+		return nil, goaws.NewServiceError(fmt.Errorf("s.svc.CreateQueue: %w", err))
 	}
 
 	if result.QueueUrl == nil {
@@ -99,16 +105,18 @@ func (s *Queues) GetQueueURL(ctx context.Context, name string) (*GetQueueUrlResp
 			return nil, NewQueueNotFoundError(name)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueUrl: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueUrl: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return nil, goaws.NewAccessDeniedError(fmt.Errorf("s.svc.GetQueueUrl: %w", err))
 			case http.StatusNotFound:
 				return nil, NewQueueNotFoundError(name)
 			default:
-				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueUrl: %w", re.Err))
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueUrl: %w", err))
 			}
 		default:
-			return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueUrl: %w", err))
+			return nil, goaws.NewServiceError(fmt.Errorf("s.svc.GetQueueUrl: %w", err))
 		}
 	}
 
@@ -132,16 +140,18 @@ func (s *Queues) DeleteQueue(ctx context.Context, url string) error {
 			return NewQueueNotFoundError(url)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteQueue: %w", re.Err))
+				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteQueue: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return goaws.NewAccessDeniedError(fmt.Errorf("s.svc.DeleteQueue: %w", err))
 			case http.StatusNotFound:
 				return NewQueueNotFoundError(url)
 			default:
-				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteQueue: %w", re.Err))
+				return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteQueue: %w", err))
 			}
 		default:
-			return goaws.NewInternalError(fmt.Errorf("s.svc.DeleteQueue: %w", err))
+			return goaws.NewServiceError(fmt.Errorf("s.svc.DeleteQueue: %w", err))
 		}
 	}
 
@@ -149,27 +159,139 @@ func (s *Queues) DeleteQueue(ctx context.Context, url string) error {
 }
 
 // PurgeQueue purges the specified queue.
+// Returns PurgeInProgressError if the queue was purged within the last 60 seconds.
 func (s *Queues) PurgeQueue(ctx context.Context, url string) error {
 	if _, err := s.svc.PurgeQueue(ctx, &sqs.PurgeQueueInput{
 		QueueUrl: aws.String(url),
 	}); err != nil {
 		var notExist *types.QueueDoesNotExist
+		var inProgress *types.PurgeQueueInProgress
 		var re *awshttp.ResponseError
 		switch {
 		case errors.As(err, &notExist):
 			return NewQueueNotFoundError(url)
+		case errors.As(err, &inProgress):
+			return NewPurgeInProgressError(url)
 		case errors.As(err, &re):
 			if re.ResponseError == nil {
-				return goaws.NewInternalError(fmt.Errorf("s.svc.PurgeQueue: %w", re.Err))
+				return goaws.NewInternalError(fmt.Errorf("s.svc.PurgeQueue: %w", err))
 			}
 			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return goaws.NewAccessDeniedError(fmt.Errorf("s.svc.PurgeQueue: %w", err))
 			case http.StatusNotFound:
 				return NewQueueNotFoundError(url)
 			default:
-				return goaws.NewInternalError(fmt.Errorf("s.svc.PurgeQueue: %w", re.Err))
+				return goaws.NewInternalError(fmt.Errorf("s.svc.PurgeQueue: %w", err))
 			}
 		default:
-			return goaws.NewInternalError(fmt.Errorf("s.svc.PurgeQueue: %w", err))
+			return goaws.NewServiceError(fmt.Errorf("s.svc.PurgeQueue: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// PurgeQueueWithOptions purges the specified queue, guarded by options.
+// If options.DryRun or options.Confirm is set, the queue's ApproximateNumberOfMessages
+// is checked first; the queue is not purged on a dry run or if Confirm returns false.
+func (s *Queues) PurgeQueueWithOptions(ctx context.Context, url string, options PurgeQueueOptions) (*PurgeQueueResponse, error) {
+	resp := &PurgeQueueResponse{}
+	if options.DryRun || options.Confirm != nil {
+		attributes, err := s.getQueueAttributes(ctx, url, types.QueueAttributeNameApproximateNumberOfMessages)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+		if err != nil {
+			return nil, goaws.NewInternalError(fmt.Errorf("strconv.Atoi: %w", err))
+		}
+		resp.ApproximateNumberOfMessages = n
+
+		if options.DryRun || !options.Confirm(n) {
+			return resp, nil
+		}
+	}
+
+	if err := s.PurgeQueue(ctx, url); err != nil {
+		return nil, err
+	}
+	resp.Purged = true
+
+	return resp, nil
+}
+
+// GetQueueArn returns the ARN of the queue at the given URL, e.g. to subscribe the queue to an SNS topic.
+func (s *Queues) GetQueueArn(ctx context.Context, url string) (string, error) {
+	attributes, err := s.getQueueAttributes(ctx, url, types.QueueAttributeNameQueueArn)
+	if err != nil {
+		return "", err
+	}
+
+	arn, ok := attributes[string(types.QueueAttributeNameQueueArn)]
+	if !ok || arn == "" {
+		return "", goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueAttributes: %s attribute missing in response", types.QueueAttributeNameQueueArn))
+	}
+
+	return arn, nil
+}
+
+func (s *Queues) getQueueAttributes(ctx context.Context, url string, names ...types.QueueAttributeName) (map[string]string, error) {
+	result, err := s.svc.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(url),
+		AttributeNames: names,
+	})
+	if err != nil {
+		var notExist *types.QueueDoesNotExist
+		var re *awshttp.ResponseError
+		switch {
+		case errors.As(err, &notExist):
+			return nil, NewQueueNotFoundError(url)
+		case errors.As(err, &re):
+			if re.ResponseError == nil {
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueAttributes: %w", err))
+			}
+			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return nil, goaws.NewAccessDeniedError(fmt.Errorf("s.svc.GetQueueAttributes: %w", err))
+			case http.StatusNotFound:
+				return nil, NewQueueNotFoundError(url)
+			default:
+				return nil, goaws.NewInternalError(fmt.Errorf("s.svc.GetQueueAttributes: %w", err))
+			}
+		default:
+			return nil, goaws.NewServiceError(fmt.Errorf("s.svc.GetQueueAttributes: %w", err))
+		}
+	}
+
+	return result.Attributes, nil
+}
+
+// SetQueuePolicy sets the access policy of the queue at the given URL, replacing any existing policy.
+func (s *Queues) SetQueuePolicy(ctx context.Context, url, policy string) error {
+	if _, err := s.svc.SetQueueAttributes(ctx, &sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(url),
+		Attributes: map[string]string{string(types.QueueAttributeNamePolicy): policy},
+	}); err != nil {
+		var notExist *types.QueueDoesNotExist
+		var re *awshttp.ResponseError
+		switch {
+		case errors.As(err, &notExist):
+			return NewQueueNotFoundError(url)
+		case errors.As(err, &re):
+			if re.ResponseError == nil {
+				return goaws.NewInternalError(fmt.Errorf("s.svc.SetQueueAttributes: %w", err))
+			}
+			switch re.HTTPStatusCode() {
+			case http.StatusForbidden:
+				return goaws.NewAccessDeniedError(fmt.Errorf("s.svc.SetQueueAttributes: %w", err))
+			case http.StatusNotFound:
+				return NewQueueNotFoundError(url)
+			default:
+				return goaws.NewInternalError(fmt.Errorf("s.svc.SetQueueAttributes: %w", err))
+			}
+		default:
+			return goaws.NewServiceError(fmt.Errorf("s.svc.SetQueueAttributes: %w", err))
 		}
 	}
 
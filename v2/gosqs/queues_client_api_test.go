@@ -81,6 +81,26 @@ func (mr *MockSQSQueuesClientAPIMockRecorder) DeleteQueue(ctx, params any, optFn
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueue", reflect.TypeOf((*MockSQSQueuesClientAPI)(nil).DeleteQueue), varargs...)
 }
 
+// GetQueueAttributes mocks base method.
+func (m *MockSQSQueuesClientAPI) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetQueueAttributes", varargs...)
+	ret0, _ := ret[0].(*sqs.GetQueueAttributesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueAttributes indicates an expected call of GetQueueAttributes.
+func (mr *MockSQSQueuesClientAPIMockRecorder) GetQueueAttributes(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueAttributes", reflect.TypeOf((*MockSQSQueuesClientAPI)(nil).GetQueueAttributes), varargs...)
+}
+
 // GetQueueUrl mocks base method.
 func (m *MockSQSQueuesClientAPI) GetQueueUrl(ctx context.Context, params *sqs.GetQueueUrlInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error) {
 	m.ctrl.T.Helper()
@@ -120,3 +140,23 @@ func (mr *MockSQSQueuesClientAPIMockRecorder) PurgeQueue(ctx, params any, optFns
 	varargs := append([]any{ctx, params}, optFns...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeQueue", reflect.TypeOf((*MockSQSQueuesClientAPI)(nil).PurgeQueue), varargs...)
 }
+
+// SetQueueAttributes mocks base method.
+func (m *MockSQSQueuesClientAPI) SetQueueAttributes(ctx context.Context, params *sqs.SetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.SetQueueAttributesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetQueueAttributes", varargs...)
+	ret0, _ := ret[0].(*sqs.SetQueueAttributesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetQueueAttributes indicates an expected call of SetQueueAttributes.
+func (mr *MockSQSQueuesClientAPIMockRecorder) SetQueueAttributes(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQueueAttributes", reflect.TypeOf((*MockSQSQueuesClientAPI)(nil).SetQueueAttributes), varargs...)
+}
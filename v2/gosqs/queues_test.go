@@ -3,11 +3,15 @@ package gosqs
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/ggarcia209/go-aws-v2/v2/goaws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -257,6 +261,16 @@ func TestSQSQueues_PurgeQueue(t *testing.T) {
 			},
 			expectedError: NewQueueNotFoundError("https://sqs.us-east-1.amazonaws.com/123456789012/missing-queue"),
 		},
+		{
+			name: "PurgeInProgress",
+			url:  "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+			mockSetup: func(ctrl *gomock.Controller) SQSQueuesClientAPI {
+				m := NewMockSQSQueuesClientAPI(ctrl)
+				m.EXPECT().PurgeQueue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.PurgeQueueInProgress{}).Times(1)
+				return m
+			},
+			expectedError: NewPurgeInProgressError("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+		},
 		{
 			name: "Error",
 			url:  "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
@@ -290,3 +304,276 @@ func TestSQSQueues_PurgeQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestSQSQueues_PurgeQueueWithOptions(t *testing.T) {
+	url := "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	countOutput := &sqs.GetQueueAttributesOutput{
+		Attributes: map[string]string{string(types.QueueAttributeNameApproximateNumberOfMessages): "42"},
+	}
+
+	tests := []struct {
+		name          string
+		options       PurgeQueueOptions
+		mockSetup     func(m *MockSQSQueuesClientAPI)
+		expected      *PurgeQueueResponse
+		expectedError error
+	}{
+		{
+			name:    "NoGuard",
+			options: PurgeQueueOptions{},
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().PurgeQueue(gomock.Any(), &sqs.PurgeQueueInput{QueueUrl: aws.String(url)}, gomock.Any()).Return(&sqs.PurgeQueueOutput{}, nil).Times(1)
+			},
+			expected: &PurgeQueueResponse{Purged: true},
+		},
+		{
+			name:    "DryRun",
+			options: PurgeQueueOptions{DryRun: true},
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), &sqs.GetQueueAttributesInput{
+					QueueUrl:       aws.String(url),
+					AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+				}, gomock.Any()).Return(countOutput, nil).Times(1)
+			},
+			expected: &PurgeQueueResponse{ApproximateNumberOfMessages: 42},
+		},
+		{
+			name:    "Confirmed",
+			options: PurgeQueueOptions{Confirm: func(n int) bool { return n < 100 }},
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(countOutput, nil).Times(1)
+				m.EXPECT().PurgeQueue(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.PurgeQueueOutput{}, nil).Times(1)
+			},
+			expected: &PurgeQueueResponse{ApproximateNumberOfMessages: 42, Purged: true},
+		},
+		{
+			name:    "NotConfirmed",
+			options: PurgeQueueOptions{Confirm: func(n int) bool { return n == 0 }},
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(countOutput, nil).Times(1)
+			},
+			expected: &PurgeQueueResponse{ApproximateNumberOfMessages: 42},
+		},
+		{
+			name:    "PurgeInProgress",
+			options: PurgeQueueOptions{Confirm: func(int) bool { return true }},
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(countOutput, nil).Times(1)
+				m.EXPECT().PurgeQueue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.PurgeQueueInProgress{}).Times(1)
+			},
+			expectedError: NewPurgeInProgressError(url),
+		},
+		{
+			name:    "QueueDoesNotExist",
+			options: PurgeQueueOptions{DryRun: true},
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.QueueDoesNotExist{}).Times(1)
+			},
+			expectedError: NewQueueNotFoundError(url),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSQueuesClientAPI(ctrl)
+			tt.mockSetup(m)
+			s := &Queues{svc: m}
+
+			resp, err := s.PurgeQueueWithOptions(context.Background(), url, tt.options)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestSQSQueues_GetQueueArn(t *testing.T) {
+	url := "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name          string
+		mockSetup     func(t *testing.T, m *MockSQSQueuesClientAPI)
+		expectedArn   string
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+						assert.Equal(t, url, aws.ToString(in.QueueUrl))
+						assert.Equal(t, []types.QueueAttributeName{types.QueueAttributeNameQueueArn}, in.AttributeNames)
+						return &sqs.GetQueueAttributesOutput{
+							Attributes: map[string]string{"QueueArn": "arn:aws:sqs:us-east-1:123456789012:test-queue"},
+						}, nil
+					}).Times(1)
+			},
+			expectedArn: "arn:aws:sqs:us-east-1:123456789012:test-queue",
+		},
+		{
+			name: "MissingAttribute",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(&sqs.GetQueueAttributesOutput{}, nil).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.GetQueueAttributes: QueueArn attribute missing in response")),
+		},
+		{
+			name: "QueueDoesNotExist",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.QueueDoesNotExist{}).Times(1)
+			},
+			expectedError: NewQueueNotFoundError(url),
+		},
+		{
+			name: "Error",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("attributes error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.GetQueueAttributes: attributes error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSQueuesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := &Queues{svc: m}
+
+			arn, err := s.GetQueueArn(context.Background(), url)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedArn, arn)
+			}
+		})
+	}
+}
+
+func TestSQSQueues_SetQueuePolicy(t *testing.T) {
+	url := "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name          string
+		mockSetup     func(t *testing.T, m *MockSQSQueuesClientAPI)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().SetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, in *sqs.SetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.SetQueueAttributesOutput, error) {
+						assert.Equal(t, url, aws.ToString(in.QueueUrl))
+						assert.Equal(t, map[string]string{"Policy": `{"Version":"2012-10-17"}`}, in.Attributes)
+						return &sqs.SetQueueAttributesOutput{}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name: "QueueDoesNotExist",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().SetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.QueueDoesNotExist{}).Times(1)
+			},
+			expectedError: NewQueueNotFoundError(url),
+		},
+		{
+			name: "Error",
+			mockSetup: func(t *testing.T, m *MockSQSQueuesClientAPI) {
+				m.EXPECT().SetQueueAttributes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("attributes error")).Times(1)
+			},
+			expectedError: goaws.NewInternalError(errors.New("s.svc.SetQueueAttributes: attributes error")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSQueuesClientAPI(ctrl)
+			tt.mockSetup(t, m)
+			s := &Queues{svc: m}
+
+			err := s.SetQueuePolicy(context.Background(), url, `{"Version":"2012-10-17"}`)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Implements(t, (*goaws.AwsError)(nil), err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSQSQueues_AccessDenied(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(m *MockSQSQueuesClientAPI)
+		call      func(s *Queues) error
+	}{
+		{
+			name: "AccessDeniedCode",
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().CreateQueue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied"}).Times(1)
+			},
+			call: func(s *Queues) error {
+				_, err := s.CreateQueue(context.Background(), "test-queue", QueueOptions{}, nil)
+				return err
+			},
+		},
+		{
+			name: "StatusForbidden",
+			mockSetup: func(m *MockSQSQueuesClientAPI) {
+				m.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &awshttp.ResponseError{
+					ResponseError: &smithyhttp.ResponseError{
+						Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusForbidden}},
+						Err:      errors.New("forbidden"),
+					},
+				}).Times(1)
+			},
+			call: func(s *Queues) error {
+				_, err := s.GetQueueURL(context.Background(), "test-queue")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockSQSQueuesClientAPI(ctrl)
+			tt.mockSetup(m)
+			s := &Queues{svc: m}
+
+			err := tt.call(s)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, goaws.ErrAccessDenied)
+			assert.Implements(t, (*goaws.AwsError)(nil), err)
+			assert.True(t, err.(goaws.AwsError).ClientError())
+		})
+	}
+}
@@ -15,6 +15,7 @@ func NewSQS(config goaws.AwsConfig) *SQS {
 	svc := sqs.New(sqs.Options{
 		Credentials: config.Config.Credentials,
 		Region:      config.Config.Region,
+		HTTPClient:  config.Config.HTTPClient,
 	})
 	return &SQS{
 		Queues:   NewQueues(svc),
@@ -12,7 +12,9 @@ package godynamomock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	types "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	godynamo "github.com/ggarcia209/go-aws-v2/v2/godynamo"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +43,21 @@ func (m *MockQueriesLogic) EXPECT() *MockQueriesLogicMockRecorder {
 	return m.recorder
 }
 
+// BatchExecuteStatement mocks base method.
+func (m *MockQueriesLogic) BatchExecuteStatement(ctx context.Context, statements []godynamo.BatchStatement) ([]godynamo.BatchStatementResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchExecuteStatement", ctx, statements)
+	ret0, _ := ret[0].([]godynamo.BatchStatementResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchExecuteStatement indicates an expected call of BatchExecuteStatement.
+func (mr *MockQueriesLogicMockRecorder) BatchExecuteStatement(ctx, statements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchExecuteStatement", reflect.TypeOf((*MockQueriesLogic)(nil).BatchExecuteStatement), ctx, statements)
+}
+
 // BatchGet mocks base method.
 func (m *MockQueriesLogic) BatchGet(ctx context.Context, tableName string, queries []*godynamo.Query, expr godynamo.Expression) ([]godynamo.QueryRow, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +73,65 @@ func (mr *MockQueriesLogicMockRecorder) BatchGet(ctx, tableName, queries, expr a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockQueriesLogic)(nil).BatchGet), ctx, tableName, queries, expr)
 }
 
+// BatchGetAligned mocks base method.
+func (m *MockQueriesLogic) BatchGetAligned(ctx context.Context, params godynamo.BatchGetParams) ([]godynamo.BatchGetResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetAligned", ctx, params)
+	ret0, _ := ret[0].([]godynamo.BatchGetResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetAligned indicates an expected call of BatchGetAligned.
+func (mr *MockQueriesLogicMockRecorder) BatchGetAligned(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetAligned", reflect.TypeOf((*MockQueriesLogic)(nil).BatchGetAligned), ctx, params)
+}
+
+// BatchGetAll mocks base method.
+func (m *MockQueriesLogic) BatchGetAll(ctx context.Context, tableName string, queries []*godynamo.Query, expr godynamo.Expression) ([]godynamo.QueryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetAll", ctx, tableName, queries, expr)
+	ret0, _ := ret[0].([]godynamo.QueryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetAll indicates an expected call of BatchGetAll.
+func (mr *MockQueriesLogicMockRecorder) BatchGetAll(ctx, tableName, queries, expr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetAll", reflect.TypeOf((*MockQueriesLogic)(nil).BatchGetAll), ctx, tableName, queries, expr)
+}
+
+// BatchGetWithParams mocks base method.
+func (m *MockQueriesLogic) BatchGetWithParams(ctx context.Context, params godynamo.BatchGetParams) ([]godynamo.QueryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetWithParams", ctx, params)
+	ret0, _ := ret[0].([]godynamo.QueryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetWithParams indicates an expected call of BatchGetWithParams.
+func (mr *MockQueriesLogicMockRecorder) BatchGetWithParams(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetWithParams", reflect.TypeOf((*MockQueriesLogic)(nil).BatchGetWithParams), ctx, params)
+}
+
+// BatchWrite mocks base method.
+func (m *MockQueriesLogic) BatchWrite(ctx context.Context, tableName string, puts []any, deletes []*godynamo.Query) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchWrite", ctx, tableName, puts, deletes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchWrite indicates an expected call of BatchWrite.
+func (mr *MockQueriesLogicMockRecorder) BatchWrite(ctx, tableName, puts, deletes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchWrite", reflect.TypeOf((*MockQueriesLogic)(nil).BatchWrite), ctx, tableName, puts, deletes)
+}
+
 // BatchWriteCreate mocks base method.
 func (m *MockQueriesLogic) BatchWriteCreate(ctx context.Context, tableName string, items []any) error {
 	m.ctrl.T.Helper()
@@ -84,6 +160,21 @@ func (mr *MockQueriesLogicMockRecorder) BatchWriteDelete(ctx, tableName, queries
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchWriteDelete", reflect.TypeOf((*MockQueriesLogic)(nil).BatchWriteDelete), ctx, tableName, queries)
 }
 
+// ConditionalDeleteAll mocks base method.
+func (m *MockQueriesLogic) ConditionalDeleteAll(ctx context.Context, tableName string, queries []godynamo.ConditionalDelete, expr godynamo.Expression) ([]*godynamo.Query, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConditionalDeleteAll", ctx, tableName, queries, expr)
+	ret0, _ := ret[0].([]*godynamo.Query)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConditionalDeleteAll indicates an expected call of ConditionalDeleteAll.
+func (mr *MockQueriesLogicMockRecorder) ConditionalDeleteAll(ctx, tableName, queries, expr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConditionalDeleteAll", reflect.TypeOf((*MockQueriesLogic)(nil).ConditionalDeleteAll), ctx, tableName, queries, expr)
+}
+
 // CreateItem mocks base method.
 func (m *MockQueriesLogic) CreateItem(ctx context.Context, item any, tableName string) error {
 	m.ctrl.T.Helper()
@@ -98,6 +189,63 @@ func (mr *MockQueriesLogicMockRecorder) CreateItem(ctx, item, tableName any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockQueriesLogic)(nil).CreateItem), ctx, item, tableName)
 }
 
+// CreateItemIfNotExists mocks base method.
+func (m *MockQueriesLogic) CreateItemIfNotExists(ctx context.Context, item any, tableName, keyAttr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemIfNotExists", ctx, item, tableName, keyAttr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateItemIfNotExists indicates an expected call of CreateItemIfNotExists.
+func (mr *MockQueriesLogicMockRecorder) CreateItemIfNotExists(ctx, item, tableName, keyAttr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemIfNotExists", reflect.TypeOf((*MockQueriesLogic)(nil).CreateItemIfNotExists), ctx, item, tableName, keyAttr)
+}
+
+// CreateItemWithMetrics mocks base method.
+func (m *MockQueriesLogic) CreateItemWithMetrics(ctx context.Context, item any, tableName string) (*godynamo.WriteMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemWithMetrics", ctx, item, tableName)
+	ret0, _ := ret[0].(*godynamo.WriteMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItemWithMetrics indicates an expected call of CreateItemWithMetrics.
+func (mr *MockQueriesLogicMockRecorder) CreateItemWithMetrics(ctx, item, tableName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemWithMetrics", reflect.TypeOf((*MockQueriesLogic)(nil).CreateItemWithMetrics), ctx, item, tableName)
+}
+
+// CreateItemWithParams mocks base method.
+func (m *MockQueriesLogic) CreateItemWithParams(ctx context.Context, params godynamo.CreateItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemWithParams", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateItemWithParams indicates an expected call of CreateItemWithParams.
+func (mr *MockQueriesLogicMockRecorder) CreateItemWithParams(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemWithParams", reflect.TypeOf((*MockQueriesLogic)(nil).CreateItemWithParams), ctx, params)
+}
+
+// CreateItemWithTTL mocks base method.
+func (m *MockQueriesLogic) CreateItemWithTTL(ctx context.Context, item any, tableName, ttlAttr string, expireAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemWithTTL", ctx, item, tableName, ttlAttr, expireAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateItemWithTTL indicates an expected call of CreateItemWithTTL.
+func (mr *MockQueriesLogicMockRecorder) CreateItemWithTTL(ctx, item, tableName, ttlAttr, expireAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemWithTTL", reflect.TypeOf((*MockQueriesLogic)(nil).CreateItemWithTTL), ctx, item, tableName, ttlAttr, expireAt)
+}
+
 // DeleteItem mocks base method.
 func (m *MockQueriesLogic) DeleteItem(ctx context.Context, query *godynamo.Query, tableName string) error {
 	m.ctrl.T.Helper()
@@ -112,6 +260,21 @@ func (mr *MockQueriesLogicMockRecorder) DeleteItem(ctx, query, tableName any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockQueriesLogic)(nil).DeleteItem), ctx, query, tableName)
 }
 
+// ExecuteStatement mocks base method.
+func (m *MockQueriesLogic) ExecuteStatement(ctx context.Context, statement string, params []any) (*godynamo.QueryResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteStatement", ctx, statement, params)
+	ret0, _ := ret[0].(*godynamo.QueryResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteStatement indicates an expected call of ExecuteStatement.
+func (mr *MockQueriesLogicMockRecorder) ExecuteStatement(ctx, statement, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteStatement", reflect.TypeOf((*MockQueriesLogic)(nil).ExecuteStatement), ctx, statement, params)
+}
+
 // GetItem mocks base method.
 func (m *MockQueriesLogic) GetItem(ctx context.Context, params godynamo.GetItemParams) error {
 	m.ctrl.T.Helper()
@@ -126,6 +289,20 @@ func (mr *MockQueriesLogicMockRecorder) GetItem(ctx, params any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockQueriesLogic)(nil).GetItem), ctx, params)
 }
 
+// PutRawItem mocks base method.
+func (m *MockQueriesLogic) PutRawItem(ctx context.Context, tableName string, item map[string]types.AttributeValue, expr godynamo.Expression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutRawItem", ctx, tableName, item, expr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutRawItem indicates an expected call of PutRawItem.
+func (mr *MockQueriesLogicMockRecorder) PutRawItem(ctx, tableName, item, expr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRawItem", reflect.TypeOf((*MockQueriesLogic)(nil).PutRawItem), ctx, tableName, item, expr)
+}
+
 // QueryItems mocks base method.
 func (m *MockQueriesLogic) QueryItems(ctx context.Context, params godynamo.QueryItemsParams) (*godynamo.QueryResults, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +318,75 @@ func (mr *MockQueriesLogicMockRecorder) QueryItems(ctx, params any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryItems", reflect.TypeOf((*MockQueriesLogic)(nil).QueryItems), ctx, params)
 }
 
+// QueryItemsUntilLimit mocks base method.
+func (m *MockQueriesLogic) QueryItemsUntilLimit(ctx context.Context, params godynamo.QueryItemsParams, limit int) (*godynamo.QueryResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryItemsUntilLimit", ctx, params, limit)
+	ret0, _ := ret[0].(*godynamo.QueryResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryItemsUntilLimit indicates an expected call of QueryItemsUntilLimit.
+func (mr *MockQueriesLogicMockRecorder) QueryItemsUntilLimit(ctx, params, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryItemsUntilLimit", reflect.TypeOf((*MockQueriesLogic)(nil).QueryItemsUntilLimit), ctx, params, limit)
+}
+
+// QueryIterator mocks base method.
+func (m *MockQueriesLogic) QueryIterator(params godynamo.QueryItemsParams) *godynamo.QueryIterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryIterator", params)
+	ret0, _ := ret[0].(*godynamo.QueryIterator)
+	return ret0
+}
+
+// QueryIterator indicates an expected call of QueryIterator.
+func (mr *MockQueriesLogicMockRecorder) QueryIterator(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryIterator", reflect.TypeOf((*MockQueriesLogic)(nil).QueryIterator), params)
+}
+
+// RegisterTable mocks base method.
+func (m *MockQueriesLogic) RegisterTable(table *godynamo.Table) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterTable", table)
+}
+
+// RegisterTable indicates an expected call of RegisterTable.
+func (mr *MockQueriesLogicMockRecorder) RegisterTable(table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTable", reflect.TypeOf((*MockQueriesLogic)(nil).RegisterTable), table)
+}
+
+// RemoveIf mocks base method.
+func (m *MockQueriesLogic) RemoveIf(ctx context.Context, query *godynamo.Query, tableName, attr string, cond godynamo.Conditions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveIf", ctx, query, tableName, attr, cond)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveIf indicates an expected call of RemoveIf.
+func (mr *MockQueriesLogicMockRecorder) RemoveIf(ctx, query, tableName, attr, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveIf", reflect.TypeOf((*MockQueriesLogic)(nil).RemoveIf), ctx, query, tableName, attr, cond)
+}
+
+// ScanEach mocks base method.
+func (m *MockQueriesLogic) ScanEach(ctx context.Context, params godynamo.QueryItemsParams, fn func(godynamo.QueryRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanEach", ctx, params, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanEach indicates an expected call of ScanEach.
+func (mr *MockQueriesLogicMockRecorder) ScanEach(ctx, params, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanEach", reflect.TypeOf((*MockQueriesLogic)(nil).ScanEach), ctx, params, fn)
+}
+
 // ScanItems mocks base method.
 func (m *MockQueriesLogic) ScanItems(ctx context.Context, params godynamo.QueryItemsParams) (*godynamo.ScanResults, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +402,32 @@ func (mr *MockQueriesLogicMockRecorder) ScanItems(ctx, params any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanItems", reflect.TypeOf((*MockQueriesLogic)(nil).ScanItems), ctx, params)
 }
 
+// ScanMissingAttribute mocks base method.
+func (m *MockQueriesLogic) ScanMissingAttribute(ctx context.Context, tableName, attr string, model any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanMissingAttribute", ctx, tableName, attr, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanMissingAttribute indicates an expected call of ScanMissingAttribute.
+func (mr *MockQueriesLogicMockRecorder) ScanMissingAttribute(ctx, tableName, attr, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanMissingAttribute", reflect.TypeOf((*MockQueriesLogic)(nil).ScanMissingAttribute), ctx, tableName, attr, model)
+}
+
+// UnregisterTable mocks base method.
+func (m *MockQueriesLogic) UnregisterTable(tableName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnregisterTable", tableName)
+}
+
+// UnregisterTable indicates an expected call of UnregisterTable.
+func (mr *MockQueriesLogicMockRecorder) UnregisterTable(tableName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterTable", reflect.TypeOf((*MockQueriesLogic)(nil).UnregisterTable), tableName)
+}
+
 // UpdateItem mocks base method.
 func (m *MockQueriesLogic) UpdateItem(ctx context.Context, query *godynamo.Query, tableName string, expr godynamo.Expression) error {
 	m.ctrl.T.Helper()
@@ -169,3 +441,18 @@ func (mr *MockQueriesLogicMockRecorder) UpdateItem(ctx, query, tableName, expr a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockQueriesLogic)(nil).UpdateItem), ctx, query, tableName, expr)
 }
+
+// UpdateItemWithMetrics mocks base method.
+func (m *MockQueriesLogic) UpdateItemWithMetrics(ctx context.Context, query *godynamo.Query, tableName string, expr godynamo.Expression) (*godynamo.WriteMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemWithMetrics", ctx, query, tableName, expr)
+	ret0, _ := ret[0].(*godynamo.WriteMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItemWithMetrics indicates an expected call of UpdateItemWithMetrics.
+func (mr *MockQueriesLogicMockRecorder) UpdateItemWithMetrics(ctx, query, tableName, expr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemWithMetrics", reflect.TypeOf((*MockQueriesLogic)(nil).UpdateItemWithMetrics), ctx, query, tableName, expr)
+}
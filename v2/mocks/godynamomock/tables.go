@@ -69,6 +69,51 @@ func (mr *MockTablesLogicMockRecorder) DeleteTable(ctx, tableName any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTable", reflect.TypeOf((*MockTablesLogic)(nil).DeleteTable), ctx, tableName)
 }
 
+// DescribeExport mocks base method.
+func (m *MockTablesLogic) DescribeExport(ctx context.Context, exportArn string) (*godynamo.ExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeExport", ctx, exportArn)
+	ret0, _ := ret[0].(*godynamo.ExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeExport indicates an expected call of DescribeExport.
+func (mr *MockTablesLogicMockRecorder) DescribeExport(ctx, exportArn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeExport", reflect.TypeOf((*MockTablesLogic)(nil).DescribeExport), ctx, exportArn)
+}
+
+// DescribeTimeToLive mocks base method.
+func (m *MockTablesLogic) DescribeTimeToLive(ctx context.Context, tableName string) (*godynamo.TTLStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTimeToLive", ctx, tableName)
+	ret0, _ := ret[0].(*godynamo.TTLStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTimeToLive indicates an expected call of DescribeTimeToLive.
+func (mr *MockTablesLogicMockRecorder) DescribeTimeToLive(ctx, tableName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTimeToLive", reflect.TypeOf((*MockTablesLogic)(nil).DescribeTimeToLive), ctx, tableName)
+}
+
+// ExportToS3 mocks base method.
+func (m *MockTablesLogic) ExportToS3(ctx context.Context, tableArn, s3Bucket, s3Prefix string) (*godynamo.ExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportToS3", ctx, tableArn, s3Bucket, s3Prefix)
+	ret0, _ := ret[0].(*godynamo.ExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportToS3 indicates an expected call of ExportToS3.
+func (mr *MockTablesLogicMockRecorder) ExportToS3(ctx, tableArn, s3Bucket, s3Prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportToS3", reflect.TypeOf((*MockTablesLogic)(nil).ExportToS3), ctx, tableArn, s3Bucket, s3Prefix)
+}
+
 // ListTables mocks base method.
 func (m *MockTablesLogic) ListTables(ctx context.Context, params godynamo.ListTableParams) ([]string, int, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +129,27 @@ func (mr *MockTablesLogicMockRecorder) ListTables(ctx, params any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTables", reflect.TypeOf((*MockTablesLogic)(nil).ListTables), ctx, params)
 }
+
+// RegisterTable mocks base method.
+func (m *MockTablesLogic) RegisterTable(table *godynamo.Table) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterTable", table)
+}
+
+// RegisterTable indicates an expected call of RegisterTable.
+func (mr *MockTablesLogicMockRecorder) RegisterTable(table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTable", reflect.TypeOf((*MockTablesLogic)(nil).RegisterTable), table)
+}
+
+// UnregisterTable mocks base method.
+func (m *MockTablesLogic) UnregisterTable(tableName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnregisterTable", tableName)
+}
+
+// UnregisterTable indicates an expected call of UnregisterTable.
+func (mr *MockTablesLogicMockRecorder) UnregisterTable(tableName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterTable", reflect.TypeOf((*MockTablesLogic)(nil).UnregisterTable), tableName)
+}
@@ -55,3 +55,18 @@ func (mr *MockTransactionsLogicMockRecorder) TxWrite(ctx, items, requestToken an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxWrite", reflect.TypeOf((*MockTransactionsLogic)(nil).TxWrite), ctx, items, requestToken)
 }
+
+// TxWriteWithRetry mocks base method.
+func (m *MockTransactionsLogic) TxWriteWithRetry(ctx context.Context, items []godynamo.TransactionItem, requestToken string) ([]godynamo.TransactionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxWriteWithRetry", ctx, items, requestToken)
+	ret0, _ := ret[0].([]godynamo.TransactionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxWriteWithRetry indicates an expected call of TxWriteWithRetry.
+func (mr *MockTransactionsLogicMockRecorder) TxWriteWithRetry(ctx, items, requestToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxWriteWithRetry", reflect.TypeOf((*MockTransactionsLogic)(nil).TxWriteWithRetry), ctx, items, requestToken)
+}
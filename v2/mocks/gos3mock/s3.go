@@ -11,6 +11,7 @@ package gos3mock
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	gos3 "github.com/ggarcia209/go-aws-v2/v2/gos3"
@@ -41,6 +42,35 @@ func (m *MockS3Logic) EXPECT() *MockS3LogicMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUpload mocks base method.
+func (m *MockS3Logic) AbortMultipartUpload(ctx context.Context, bucket, key, uploadId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", ctx, bucket, key, uploadId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockS3LogicMockRecorder) AbortMultipartUpload(ctx, bucket, key, uploadId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockS3Logic)(nil).AbortMultipartUpload), ctx, bucket, key, uploadId)
+}
+
+// CheckBucketExists mocks base method.
+func (m *MockS3Logic) CheckBucketExists(ctx context.Context, bucket string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBucketExists", ctx, bucket)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckBucketExists indicates an expected call of CheckBucketExists.
+func (mr *MockS3LogicMockRecorder) CheckBucketExists(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBucketExists", reflect.TypeOf((*MockS3Logic)(nil).CheckBucketExists), ctx, bucket)
+}
+
 // CheckIfObjectExists mocks base method.
 func (m *MockS3Logic) CheckIfObjectExists(ctx context.Context, req gos3.GetFileRequest) (*gos3.ObjectExistsResponse, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +86,49 @@ func (mr *MockS3LogicMockRecorder) CheckIfObjectExists(ctx, req any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfObjectExists", reflect.TypeOf((*MockS3Logic)(nil).CheckIfObjectExists), ctx, req)
 }
 
+// CopyObject mocks base method.
+func (m *MockS3Logic) CopyObject(ctx context.Context, req gos3.CopyObjectRequest) (*gos3.CopyObjectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyObject", ctx, req)
+	ret0, _ := ret[0].(*gos3.CopyObjectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyObject indicates an expected call of CopyObject.
+func (mr *MockS3LogicMockRecorder) CopyObject(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyObject", reflect.TypeOf((*MockS3Logic)(nil).CopyObject), ctx, req)
+}
+
+// CreateBucket mocks base method.
+func (m *MockS3Logic) CreateBucket(ctx context.Context, bucket, region string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", ctx, bucket, region)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBucket indicates an expected call of CreateBucket.
+func (mr *MockS3LogicMockRecorder) CreateBucket(ctx, bucket, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockS3Logic)(nil).CreateBucket), ctx, bucket, region)
+}
+
+// DeleteBucket mocks base method.
+func (m *MockS3Logic) DeleteBucket(ctx context.Context, bucket string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBucket", ctx, bucket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBucket indicates an expected call of DeleteBucket.
+func (mr *MockS3LogicMockRecorder) DeleteBucket(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucket", reflect.TypeOf((*MockS3Logic)(nil).DeleteBucket), ctx, bucket)
+}
+
 // DeleteFile mocks base method.
 func (m *MockS3Logic) DeleteFile(ctx context.Context, bucket, key string, versionId *string) error {
 	m.ctrl.T.Helper()
@@ -70,6 +143,21 @@ func (mr *MockS3LogicMockRecorder) DeleteFile(ctx, bucket, key, versionId any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockS3Logic)(nil).DeleteFile), ctx, bucket, key, versionId)
 }
 
+// DownloadConcurrent mocks base method.
+func (m *MockS3Logic) DownloadConcurrent(ctx context.Context, req gos3.GetFileRequest, w io.WriterAt, concurrency int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadConcurrent", ctx, req, w, concurrency)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadConcurrent indicates an expected call of DownloadConcurrent.
+func (mr *MockS3LogicMockRecorder) DownloadConcurrent(ctx, req, w, concurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadConcurrent", reflect.TypeOf((*MockS3Logic)(nil).DownloadConcurrent), ctx, req, w, concurrency)
+}
+
 // GetObject mocks base method.
 func (m *MockS3Logic) GetObject(ctx context.Context, req gos3.GetFileRequest) (*gos3.GetObjectResponse, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +173,22 @@ func (mr *MockS3LogicMockRecorder) GetObject(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockS3Logic)(nil).GetObject), ctx, req)
 }
 
+// GetObjectStream mocks base method.
+func (m *MockS3Logic) GetObjectStream(ctx context.Context, req gos3.GetFileRequest) (io.ReadCloser, *gos3.GetObjectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectStream", ctx, req)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gos3.GetObjectResponse)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetObjectStream indicates an expected call of GetObjectStream.
+func (mr *MockS3LogicMockRecorder) GetObjectStream(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectStream", reflect.TypeOf((*MockS3Logic)(nil).GetObjectStream), ctx, req)
+}
+
 // GetPresignedURL mocks base method.
 func (m *MockS3Logic) GetPresignedURL(ctx context.Context, req gos3.GetPresignedUrlRequest) (*gos3.GetPresignedUrlResponse, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +219,77 @@ func (mr *MockS3LogicMockRecorder) HeadObject(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadObject", reflect.TypeOf((*MockS3Logic)(nil).HeadObject), ctx, req)
 }
 
+// ListMultipartUploads mocks base method.
+func (m *MockS3Logic) ListMultipartUploads(ctx context.Context, bucket string) ([]gos3.MultipartUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMultipartUploads", ctx, bucket)
+	ret0, _ := ret[0].([]gos3.MultipartUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMultipartUploads indicates an expected call of ListMultipartUploads.
+func (mr *MockS3LogicMockRecorder) ListMultipartUploads(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMultipartUploads", reflect.TypeOf((*MockS3Logic)(nil).ListMultipartUploads), ctx, bucket)
+}
+
+// PutBucketLifecycle mocks base method.
+func (m *MockS3Logic) PutBucketLifecycle(ctx context.Context, bucket string, rules []gos3.LifecycleRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBucketLifecycle", ctx, bucket, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutBucketLifecycle indicates an expected call of PutBucketLifecycle.
+func (mr *MockS3LogicMockRecorder) PutBucketLifecycle(ctx, bucket, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBucketLifecycle", reflect.TypeOf((*MockS3Logic)(nil).PutBucketLifecycle), ctx, bucket, rules)
+}
+
+// PutBucketVersioning mocks base method.
+func (m *MockS3Logic) PutBucketVersioning(ctx context.Context, bucket string, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBucketVersioning", ctx, bucket, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutBucketVersioning indicates an expected call of PutBucketVersioning.
+func (mr *MockS3LogicMockRecorder) PutBucketVersioning(ctx, bucket, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBucketVersioning", reflect.TypeOf((*MockS3Logic)(nil).PutBucketVersioning), ctx, bucket, enabled)
+}
+
+// StreamPrefixAsZip mocks base method.
+func (m *MockS3Logic) StreamPrefixAsZip(ctx context.Context, bucket, prefix string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPrefixAsZip", ctx, bucket, prefix, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPrefixAsZip indicates an expected call of StreamPrefixAsZip.
+func (mr *MockS3LogicMockRecorder) StreamPrefixAsZip(ctx, bucket, prefix, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPrefixAsZip", reflect.TypeOf((*MockS3Logic)(nil).StreamPrefixAsZip), ctx, bucket, prefix, w)
+}
+
+// UpdateObjectMetadata mocks base method.
+func (m *MockS3Logic) UpdateObjectMetadata(ctx context.Context, bucket, key string, metadata map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateObjectMetadata", ctx, bucket, key, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateObjectMetadata indicates an expected call of UpdateObjectMetadata.
+func (mr *MockS3LogicMockRecorder) UpdateObjectMetadata(ctx, bucket, key, metadata any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateObjectMetadata", reflect.TypeOf((*MockS3Logic)(nil).UpdateObjectMetadata), ctx, bucket, key, metadata)
+}
+
 // UploadFile mocks base method.
 func (m *MockS3Logic) UploadFile(ctx context.Context, req gos3.UploadFileRequest) (*gos3.UploadFileResponse, error) {
 	m.ctrl.T.Helper()
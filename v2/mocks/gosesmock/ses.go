@@ -41,6 +41,21 @@ func (m *MockSESLogic) EXPECT() *MockSESLogicMockRecorder {
 	return m.recorder
 }
 
+// GetConfigurationSetEventDestinations mocks base method.
+func (m *MockSESLogic) GetConfigurationSetEventDestinations(ctx context.Context, configSet string) ([]goses.EventDestination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigurationSetEventDestinations", ctx, configSet)
+	ret0, _ := ret[0].([]goses.EventDestination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigurationSetEventDestinations indicates an expected call of GetConfigurationSetEventDestinations.
+func (mr *MockSESLogicMockRecorder) GetConfigurationSetEventDestinations(ctx, configSet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigurationSetEventDestinations", reflect.TypeOf((*MockSESLogic)(nil).GetConfigurationSetEventDestinations), ctx, configSet)
+}
+
 // ListVerifiedIdentities mocks base method.
 func (m *MockSESLogic) ListVerifiedIdentities(ctx context.Context) (*goses.ListVerifiedIdentitiesResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,11 +72,12 @@ func (mr *MockSESLogicMockRecorder) ListVerifiedIdentities(ctx any) *gomock.Call
 }
 
 // SendEmail mocks base method.
-func (m *MockSESLogic) SendEmail(ctx context.Context, params goses.SendEmailParams) error {
+func (m *MockSESLogic) SendEmail(ctx context.Context, params goses.SendEmailParams) (*goses.SendEmailResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendEmail", ctx, params)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*goses.SendEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendEmail indicates an expected call of SendEmail.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	types "github.com/aws/aws-sdk-go-v2/service/sns/types"
 	gosns "github.com/ggarcia209/go-aws-v2/v2/gosns"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,21 @@ func (m *MockSNSLogic) EXPECT() *MockSNSLogicMockRecorder {
 	return m.recorder
 }
 
+// ConfirmSubscription mocks base method.
+func (m *MockSNSLogic) ConfirmSubscription(ctx context.Context, topicArn, token string) (*gosns.ConfirmSubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmSubscription", ctx, topicArn, token)
+	ret0, _ := ret[0].(*gosns.ConfirmSubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmSubscription indicates an expected call of ConfirmSubscription.
+func (mr *MockSNSLogicMockRecorder) ConfirmSubscription(ctx, topicArn, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmSubscription", reflect.TypeOf((*MockSNSLogic)(nil).ConfirmSubscription), ctx, topicArn, token)
+}
+
 // CreateTopic mocks base method.
 func (m *MockSNSLogic) CreateTopic(ctx context.Context, name string) (*gosns.CreateTopicResponse, error) {
 	m.ctrl.T.Helper()
@@ -86,17 +102,32 @@ func (mr *MockSNSLogicMockRecorder) Publish(ctx, msgStr, topicArn any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockSNSLogic)(nil).Publish), ctx, msgStr, topicArn)
 }
 
+// PublishWithAttributes mocks base method.
+func (m *MockSNSLogic) PublishWithAttributes(ctx context.Context, msgStr, topicArn string, attributes map[string]types.MessageAttributeValue) (*gosns.PublishResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishWithAttributes", ctx, msgStr, topicArn, attributes)
+	ret0, _ := ret[0].(*gosns.PublishResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishWithAttributes indicates an expected call of PublishWithAttributes.
+func (mr *MockSNSLogicMockRecorder) PublishWithAttributes(ctx, msgStr, topicArn, attributes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishWithAttributes", reflect.TypeOf((*MockSNSLogic)(nil).PublishWithAttributes), ctx, msgStr, topicArn, attributes)
+}
+
 // Subscribe mocks base method.
-func (m *MockSNSLogic) Subscribe(ectx context.Context, ndpoint, protocol, topicArn string) (*gosns.SubscribeResponse, error) {
+func (m *MockSNSLogic) Subscribe(ctx context.Context, endpoint, protocol, topicArn string, options gosns.SubscribeOptions) (*gosns.SubscribeResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Subscribe", ectx, ndpoint, protocol, topicArn)
+	ret := m.ctrl.Call(m, "Subscribe", ctx, endpoint, protocol, topicArn, options)
 	ret0, _ := ret[0].(*gosns.SubscribeResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Subscribe indicates an expected call of Subscribe.
-func (mr *MockSNSLogicMockRecorder) Subscribe(ectx, ndpoint, protocol, topicArn any) *gomock.Call {
+func (mr *MockSNSLogicMockRecorder) Subscribe(ctx, endpoint, protocol, topicArn, options any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSNSLogic)(nil).Subscribe), ectx, ndpoint, protocol, topicArn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSNSLogic)(nil).Subscribe), ctx, endpoint, protocol, topicArn, options)
 }
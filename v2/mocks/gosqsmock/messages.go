@@ -41,6 +41,21 @@ func (m *MockMessagesLogic) EXPECT() *MockMessagesLogicMockRecorder {
 	return m.recorder
 }
 
+// ChangeMessageVisibilityAll mocks base method.
+func (m *MockMessagesLogic) ChangeMessageVisibilityAll(ctx context.Context, req gosqs.BatchUpdateVisibilityTimeoutRequest) (*gosqs.BatchUpdateVisibilityTimeoutResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMessageVisibilityAll", ctx, req)
+	ret0, _ := ret[0].(*gosqs.BatchUpdateVisibilityTimeoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeMessageVisibilityAll indicates an expected call of ChangeMessageVisibilityAll.
+func (mr *MockMessagesLogicMockRecorder) ChangeMessageVisibilityAll(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMessageVisibilityAll", reflect.TypeOf((*MockMessagesLogic)(nil).ChangeMessageVisibilityAll), ctx, req)
+}
+
 // ChangeMessageVisibilityBatch mocks base method.
 func (m *MockMessagesLogic) ChangeMessageVisibilityBatch(ctx context.Context, req gosqs.BatchUpdateVisibilityTimeoutRequest) (*gosqs.BatchUpdateVisibilityTimeoutResponse, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +70,21 @@ func (mr *MockQueuesLogicMockRecorder) DeleteQueue(ctx, url any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueue", reflect.TypeOf((*MockQueuesLogic)(nil).DeleteQueue), ctx, url)
 }
 
+// GetQueueArn mocks base method.
+func (m *MockQueuesLogic) GetQueueArn(ctx context.Context, url string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueArn", ctx, url)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueArn indicates an expected call of GetQueueArn.
+func (mr *MockQueuesLogicMockRecorder) GetQueueArn(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueArn", reflect.TypeOf((*MockQueuesLogic)(nil).GetQueueArn), ctx, url)
+}
+
 // GetQueueURL mocks base method.
 func (m *MockQueuesLogic) GetQueueURL(ctx context.Context, name string) (*gosqs.GetQueueUrlResponse, error) {
 	m.ctrl.T.Helper()
@@ -98,3 +113,32 @@ func (mr *MockQueuesLogicMockRecorder) PurgeQueue(ctx, url any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeQueue", reflect.TypeOf((*MockQueuesLogic)(nil).PurgeQueue), ctx, url)
 }
+
+// PurgeQueueWithOptions mocks base method.
+func (m *MockQueuesLogic) PurgeQueueWithOptions(ctx context.Context, url string, options gosqs.PurgeQueueOptions) (*gosqs.PurgeQueueResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeQueueWithOptions", ctx, url, options)
+	ret0, _ := ret[0].(*gosqs.PurgeQueueResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeQueueWithOptions indicates an expected call of PurgeQueueWithOptions.
+func (mr *MockQueuesLogicMockRecorder) PurgeQueueWithOptions(ctx, url, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeQueueWithOptions", reflect.TypeOf((*MockQueuesLogic)(nil).PurgeQueueWithOptions), ctx, url, options)
+}
+
+// SetQueuePolicy mocks base method.
+func (m *MockQueuesLogic) SetQueuePolicy(ctx context.Context, url, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQueuePolicy", ctx, url, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetQueuePolicy indicates an expected call of SetQueuePolicy.
+func (mr *MockQueuesLogicMockRecorder) SetQueuePolicy(ctx, url, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQueuePolicy", reflect.TypeOf((*MockQueuesLogic)(nil).SetQueuePolicy), ctx, url, policy)
+}